@@ -35,7 +35,7 @@ func (s *Service) Address(ctx context.Context, req *pb.AddressRequest) (*pb.Addr
 
 	address := types.BytesToAddress(decodeString)
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -63,6 +63,16 @@ func (s *Service) Address(ctx context.Context, req *pb.AddressRequest) (*pb.Addr
 		})
 	}
 
+	if vestings := cState.Vesting().GetByAddress(address); vestings != nil {
+		for _, item := range vestings.List {
+			totalStake, ok := totalStakesGroupByCoin[item.Coin]
+			if !ok {
+				totalStake = big.NewInt(0)
+			}
+			totalStakesGroupByCoin[item.Coin] = big.NewInt(0).Add(totalStake, item.Remaining())
+		}
+	}
+
 	if req.Delegated {
 		if req.Height != 0 {
 			cState.Candidates().LoadCandidates()
@@ -15,7 +15,7 @@ import (
 
 // Addresses returns list of addresses.
 func (s *Service) Addresses(ctx context.Context, req *pb.AddressesRequest) (*pb.AddressesResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AutoRouteRequest contains address of delegator in the "Mx..." format
+type AutoRouteRequest struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height,string,omitempty"`
+}
+
+// AutoRouteResponse is the auto-route preference of the delegator, empty ToPublicKey means kicked stakes are returned to the balance
+type AutoRouteResponse struct {
+	Enabled     bool   `json:"enabled"`
+	ToPublicKey string `json:"to_public_key,omitempty"`
+}
+
+// AutoRoute returns the auto-route preference of the address, routed stakes are returned by frozen funds API.
+func (s *Service) AutoRoute(ctx context.Context, req *AutoRouteRequest) (*AutoRouteResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(req.Address[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	address := types.BytesToAddress(decodeString)
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if req.Height != 0 {
+		cState.Candidates().LoadCandidates()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &AutoRouteResponse{}
+	if route := cState.WaitList().GetRoute(address); route != nil {
+		res.Enabled = true
+		if route.CandidateID != 0 {
+			res.ToPublicKey = cState.Candidates().PubKey(route.CandidateID).String()
+		}
+	}
+
+	return res, nil
+}
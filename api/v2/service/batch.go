@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	_struct "google.golang.org/protobuf/types/known/structpb"
+)
+
+// BatchRequest is a list of API v2 requests executed against one state
+type BatchRequest struct {
+	Height   uint64              `json:"height,string"`
+	Requests []*BatchRequestItem `json:"requests"`
+}
+
+// BatchRequestItem is a single request of batch, params are the JSON body of the method request
+type BatchRequestItem struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// BatchResponse contains results of batch items in the order of requests
+type BatchResponse struct {
+	Height  uint64               `json:"height,string"`
+	Results []*BatchResponseItem `json:"results"`
+}
+
+// BatchResponseItem contains a result or an error of a single request
+type BatchResponseItem struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *BatchError     `json:"error,omitempty"`
+}
+
+// BatchError has the same format as errors of API v2
+type BatchError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Data    map[string]string `json:"data,omitempty"`
+}
+
+type batchMethod struct {
+	request func() proto.Message
+	call    func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+var batchMethods = map[string]batchMethod{
+	"Address": {
+		request: func() proto.Message { return &pb.AddressRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.Address(ctx, req.(*pb.AddressRequest))
+		},
+	},
+	"Addresses": {
+		request: func() proto.Message { return &pb.AddressesRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.Addresses(ctx, req.(*pb.AddressesRequest))
+		},
+	},
+	"Candidate": {
+		request: func() proto.Message { return &pb.CandidateRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.Candidate(ctx, req.(*pb.CandidateRequest))
+		},
+	},
+	"CoinInfo": {
+		request: func() proto.Message { return &pb.CoinInfoRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.CoinInfo(ctx, req.(*pb.CoinInfoRequest))
+		},
+	},
+	"CoinInfoById": {
+		request: func() proto.Message { return &pb.CoinIdRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.CoinInfoById(ctx, req.(*pb.CoinIdRequest))
+		},
+	},
+	"EstimateCoinBuy": {
+		request: func() proto.Message { return &pb.EstimateCoinBuyRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.EstimateCoinBuy(ctx, req.(*pb.EstimateCoinBuyRequest))
+		},
+	},
+	"EstimateCoinSell": {
+		request: func() proto.Message { return &pb.EstimateCoinSellRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.EstimateCoinSell(ctx, req.(*pb.EstimateCoinSellRequest))
+		},
+	},
+	"EstimateCoinSellAll": {
+		request: func() proto.Message { return &pb.EstimateCoinSellAllRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.EstimateCoinSellAll(ctx, req.(*pb.EstimateCoinSellAllRequest))
+		},
+	},
+	"EstimateTxCommission": {
+		request: func() proto.Message { return &pb.EstimateTxCommissionRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.EstimateTxCommission(ctx, req.(*pb.EstimateTxCommissionRequest))
+		},
+	},
+	"Frozen": {
+		request: func() proto.Message { return &pb.FrozenRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.Frozen(ctx, req.(*pb.FrozenRequest))
+		},
+	},
+	"MaxGasPrice": {
+		request: func() proto.Message { return &pb.MaxGasPriceRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.MaxGasPrice(ctx, req.(*pb.MaxGasPriceRequest))
+		},
+	},
+	"MinGasPrice": {
+		request: func() proto.Message { return &emptypb.Empty{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.MinGasPrice(ctx, req.(*emptypb.Empty))
+		},
+	},
+	"PriceCommission": {
+		request: func() proto.Message { return &pb.PriceCommissionRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.PriceCommission(ctx, req.(*pb.PriceCommissionRequest))
+		},
+	},
+	"SwapPool": {
+		request: func() proto.Message { return &pb.SwapPoolRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.SwapPool(ctx, req.(*pb.SwapPoolRequest))
+		},
+	},
+	"SwapPoolProvider": {
+		request: func() proto.Message { return &pb.SwapPoolProviderRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.SwapPoolProvider(ctx, req.(*pb.SwapPoolProviderRequest))
+		},
+	},
+	"WaitList": {
+		request: func() proto.Message { return &pb.WaitListRequest{} },
+		call: func(s *Service, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return s.WaitList(ctx, req.(*pb.WaitListRequest))
+		},
+	},
+}
+
+var (
+	batchUnmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+	batchMarshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// BatchMaxSize returns maximum number of requests in one batch
+func (s *Service) BatchMaxSize() int {
+	return s.minterCfg.APIv2BatchMaxSize
+}
+
+// Batch executes a list of heterogeneous requests against one state and returns the height used.
+func (s *Service) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+	if len(req.Requests) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty batch")
+	}
+	if maxSize := s.BatchMaxSize(); maxSize > 0 && len(req.Requests) > maxSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch size %d exceeds limit %d", len(req.Requests), maxSize)
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.LastCommittedHeight()
+		// min gas price depends on the mempool, so it is known only for the latest state
+		ctx = withMinGasPrice(ctx, s.blockchain.MinGasPrice())
+	}
+
+	cState, err := s.getStateForHeight(ctx, height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	ctx = withState(ctx, cState)
+
+	res := &BatchResponse{
+		Height:  height,
+		Results: make([]*BatchResponseItem, 0, len(req.Requests)),
+	}
+	for i, item := range req.Requests {
+		if timeoutStatus := s.checkTimeout(ctx, fmt.Sprintf("batch item %s [%d]", item.Method, i)); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+		res.Results = append(res.Results, s.batchItem(ctx, item, height))
+	}
+
+	return res, nil
+}
+
+func (s *Service) batchItem(ctx context.Context, item *BatchRequestItem, height uint64) *BatchResponseItem {
+	method, ok := batchMethods[item.Method]
+	if !ok {
+		return batchErrorItem(status.Errorf(codes.Unimplemented, "method %q is not supported in batch", item.Method))
+	}
+
+	req := method.request()
+	if len(item.Params) != 0 {
+		if err := batchUnmarshalOptions.Unmarshal(item.Params, req); err != nil {
+			return batchErrorItem(status.Error(codes.InvalidArgument, err.Error()))
+		}
+	}
+	setRequestHeight(req, height)
+
+	resp, err := method.call(s, ctx, req)
+	if err != nil {
+		return batchErrorItem(err)
+	}
+
+	result, err := batchMarshalOptions.Marshal(resp)
+	if err != nil {
+		return batchErrorItem(status.Error(codes.Internal, err.Error()))
+	}
+
+	return &BatchResponseItem{Result: result}
+}
+
+// setRequestHeight overrides height of request, so the handlers take the height dependent branches
+func setRequestHeight(req proto.Message, height uint64) {
+	m := req.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("height")
+	if field == nil || field.Kind() != protoreflect.Uint64Kind {
+		return
+	}
+	m.Set(field, protoreflect.ValueOfUint64(height))
+}
+
+func batchErrorItem(err error) *BatchResponseItem {
+	s, ok := status.FromError(err)
+	if !ok {
+		s = status.New(codes.Unknown, err.Error())
+	}
+
+	batchErr := &BatchError{
+		Code:    strconv.Itoa(runtime.HTTPStatusFromCode(s.Code())),
+		Message: s.Message(),
+	}
+
+	details := s.Details()
+	if len(details) == 0 {
+		return &BatchResponseItem{Error: batchErr}
+	}
+	detail, ok := details[0].(*_struct.Struct)
+	if !ok {
+		return &BatchResponseItem{Error: batchErr}
+	}
+
+	batchErr.Data = map[string]string{}
+	for k, v := range detail.AsMap() {
+		if k == "code" {
+			batchErr.Code = fmt.Sprintf("%s", v)
+			continue
+		}
+		batchErr.Data[k] = fmt.Sprintf("%s", v)
+	}
+
+	return &BatchResponseItem{Error: batchErr}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestService_Batch(t *testing.T) {
+	t.Parallel()
+	cState := getTestState(t)
+
+	addr := types.Address{1}
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(100)))
+
+	ctx := withTestState(t, cState)
+
+	s := &Service{minterCfg: &config.Config{BaseConfig: config.BaseConfig{APIv2BatchMaxSize: 6}}}
+	res, err := s.Batch(ctx, &BatchRequest{
+		Height: 1,
+		Requests: []*BatchRequestItem{
+			{Method: "Address", Params: json.RawMessage(`{"address":"` + addr.String() + `"}`)},
+			{Method: "CoinInfoById", Params: json.RawMessage(`{"id":0}`)},
+			{Method: "CoinInfoById", Params: json.RawMessage(`{"id":10}`)},
+			{Method: "Address", Params: json.RawMessage(`{"address":1}`)},
+			{Method: "SendTransaction"},
+			{Method: "MinGasPrice"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Height != 1 {
+		t.Errorf("height of batch is %d, want 1", res.Height)
+	}
+
+	tests := []struct {
+		method string
+		result map[string]interface{}
+		code   string
+	}{
+		{method: "Address", result: map[string]interface{}{"transaction_count": "0", "bip_value": "100000000000000000000"}},
+		{method: "CoinInfoById", result: map[string]interface{}{"id": "0", "symbol": types.GetBaseCoin().String()}},
+		{method: "CoinInfoById", code: "102"},
+		{method: "Address", code: "400"},
+		{method: "SendTransaction", code: "501"},
+		{method: "MinGasPrice", code: "400"},
+	}
+	if len(res.Results) != len(tests) {
+		t.Fatalf("batch has %d results, want %d", len(res.Results), len(tests))
+	}
+	for i, test := range tests {
+		item := res.Results[i]
+		if test.code != "" {
+			if item.Error == nil || item.Error.Code != test.code {
+				t.Errorf("item %d %s has error %+v, want code %s", i, test.method, item.Error, test.code)
+			}
+			continue
+		}
+		if item.Error != nil {
+			t.Errorf("item %d %s has error %+v", i, test.method, item.Error)
+			continue
+		}
+		result := map[string]interface{}{}
+		if err := json.Unmarshal(item.Result, &result); err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range test.result {
+			if result[k] != v {
+				t.Errorf("item %d %s has %s %v, want %v", i, test.method, k, result[k], v)
+			}
+		}
+	}
+
+	for _, req := range []*BatchRequest{
+		{Height: 1},
+		{Height: 1, Requests: make([]*BatchRequestItem, 7)},
+	} {
+		if _, err := s.Batch(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("batch of %d requests returned %v, want %s", len(req.Requests), err, codes.InvalidArgument)
+		}
+	}
+}
+
+func TestSetRequestHeight(t *testing.T) {
+	t.Parallel()
+
+	req := &pb.AddressRequest{Height: 5}
+	setRequestHeight(req, 10)
+	if req.Height != 10 {
+		t.Errorf("height of request is %d, want 10", req.Height)
+	}
+
+	setRequestHeight(&emptypb.Empty{}, 10)
+}
+
+func TestService_MinGasPrice_pinned(t *testing.T) {
+	t.Parallel()
+
+	res, err := new(Service).MinGasPrice(withMinGasPrice(context.Background(), 5), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MinGasPrice != 5 {
+		t.Errorf("min gas price is %d, want 5", res.MinGasPrice)
+	}
+}
@@ -85,6 +85,15 @@ func (s *Service) Block(ctx context.Context, req *pb.BlockRequest) (*pb.BlockRes
 			var m proto.Message
 			switch e := event.(type) {
 			case *events.JailEvent:
+				if e.Level != 0 {
+					// the level of the downtime offence is not in the protobuf API
+					data, err := toStruct(e)
+					if err != nil {
+						return nil, status.Error(codes.Internal, err.Error())
+					}
+					m = data
+					break
+				}
 				m = &pb.JailEvent{
 					ValidatorPubKey: e.ValidatorPubKeyString(),
 					JailedUntil:     e.JailedUntil,
@@ -96,7 +105,22 @@ func (s *Service) Block(ctx context.Context, req *pb.BlockRequest) (*pb.BlockRes
 					Coin:    e.Coin,
 					Amount:  e.Amount,
 				}
+			case *events.TriggerOrderEvent, *events.UpdateParamEvent, *events.LowSelfBondEvent, *events.CandidateCommissionChangedEvent:
+				data, err := toStruct(e)
+				if err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+				m = data
 			case *events.RewardEvent:
+				if _, ok := pb.RewardEvent_Role_value[e.Role]; !ok {
+					// rewards of pools added by validators have no role in the protobuf API
+					data, err := toStruct(e)
+					if err != nil {
+						return nil, status.Error(codes.Internal, err.Error())
+					}
+					m = data
+					break
+				}
 				m = &pb.RewardEvent{
 					Role:            pb.RewardEvent_Role(pb.RewardEvent_Role_value[e.Role]),
 					Address:         e.AddressString(),
@@ -27,7 +27,7 @@ func (s *Service) Candidate(ctx context.Context, req *pb.CandidateRequest) (*pb.
 
 	pubkey := types.BytesToPubkey(decodeString)
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CandidateInfoRequest contains public key of candidate in the "Mp..." format
+type CandidateInfoRequest struct {
+	PublicKey string `json:"public_key"`
+	Height    uint64 `json:"height,string,omitempty"`
+}
+
+// CandidateInfoResponse is the state of the candidate which is not a part of the Candidate response.
+// pb.CandidateResponse is generated from the proto of node-grpc-gateway, which can't be changed with the node,
+// so these fields are returned by this endpoint and by the fields of the GraphQL Candidate type
+type CandidateInfoResponse struct {
+	SelfStake            string `json:"self_stake"`
+	TotalStake           string `json:"total_stake"`
+	SelfBondRatio        uint64 `json:"self_bond_ratio,string"`
+	MinSelfBondRatio     uint64 `json:"min_self_bond_ratio,string"`
+	SelfBondInsufficient bool   `json:"self_bond_insufficient"`
+
+	PendingCommission       uint64 `json:"pending_commission,string,omitempty"`
+	PendingCommissionHeight uint64 `json:"pending_commission_height,string,omitempty"`
+}
+
+// CandidateInfo returns the share of stakes of the owner of the candidate and the announced commission. Ratios are in basis points.
+func (s *Service) CandidateInfo(ctx context.Context, req *CandidateInfoRequest) (*CandidateInfoResponse, error) {
+	if !strings.HasPrefix(req.PublicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+
+	decodeString, err := hex.DecodeString(req.PublicKey[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pubkey := types.BytesToPubkey(decodeString)
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if req.Height != 0 {
+		cState.Candidates().LoadCandidates()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	if candidate == nil {
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	if req.Height != 0 {
+		cState.Candidates().LoadStakesOfCandidate(pubkey)
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &CandidateInfoResponse{
+		SelfStake:        cState.Candidates().GetSelfBipStake(pubkey).String(),
+		TotalStake:       cState.Candidates().GetTotalStake(pubkey).String(),
+		SelfBondRatio:    cState.Candidates().SelfBondRatio(pubkey),
+		MinSelfBondRatio: cState.Candidates().MinSelfBondRatio(),
+	}
+	res.SelfBondInsufficient = res.SelfBondRatio < res.MinSelfBondRatio
+	if change := cState.Candidates().GetCommissionChange(pubkey); change != nil {
+		res.PendingCommission = uint64(change.Commission)
+		res.PendingCommissionHeight = change.Height
+	}
+
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	checkStatusRedeemable = "redeemable"
+	checkStatusRedeemed   = "redeemed"
+	checkStatusCancelled  = "cancelled"
+	checkStatusExpired    = "expired"
+)
+
+// CheckStatusRequest contains the raw check in the hex format with the optional "Mc" prefix
+type CheckStatusRequest struct {
+	Check  string `json:"check"`
+	Height uint64 `json:"height,string,omitempty"`
+}
+
+// CheckStatusResponse is the status of the check: redeemable, redeemed, cancelled or expired
+type CheckStatusResponse struct {
+	Hash     string `json:"hash"`
+	Issuer   string `json:"issuer"`
+	Coin     uint64 `json:"coin,string"`
+	Value    string `json:"value"`
+	DueBlock uint64 `json:"due_block,string"`
+	Status   string `json:"status"`
+	Escrowed bool   `json:"escrowed"`
+	Funded   bool   `json:"funded"`
+}
+
+// CheckStatus returns the status of the check decoded from its raw bytes
+func (s *Service) CheckStatus(ctx context.Context, req *CheckStatusRequest) (*CheckStatusResponse, error) {
+	rawCheck := req.Check
+	if strings.HasPrefix(strings.Title(rawCheck), "Mc") {
+		rawCheck = rawCheck[2:]
+	}
+
+	decodeString, err := hex.DecodeString(rawCheck)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid check")
+	}
+
+	decodedCheck, err := check.DecodeFromBytes(decodeString)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	hash := decodedCheck.Hash()
+	escrow := cState.Checks().GetEscrow(hash)
+
+	res := &CheckStatusResponse{
+		Hash:     hash.String(),
+		Issuer:   issuer.String(),
+		Coin:     uint64(decodedCheck.Coin),
+		Value:    decodedCheck.Value.String(),
+		DueBlock: decodedCheck.DueBlock,
+		Status:   checkStatusRedeemable,
+		Escrowed: escrow != nil,
+	}
+
+	switch {
+	case cState.Checks().IsCheckCancelled(hash):
+		res.Status = checkStatusCancelled
+	case cState.Checks().IsCheckUsed(decodedCheck):
+		res.Status = checkStatusRedeemed
+	case decodedCheck.DueBlock <= height:
+		res.Status = checkStatusExpired
+	}
+
+	if escrow != nil {
+		res.Funded = true
+	} else if res.Status == checkStatusRedeemable {
+		res.Funded = cState.Accounts().GetBalance(issuer, decodedCheck.Coin).Cmp(decodedCheck.Value) != -1
+	}
+
+	return res, nil
+}
@@ -15,7 +15,7 @@ import (
 
 // CoinInfo returns information about coin symbol.
 func (s *Service) CoinInfo(ctx context.Context, req *pb.CoinInfoRequest) (*pb.CoinInfoResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -54,7 +54,7 @@ func (s *Service) CoinInfo(ctx context.Context, req *pb.CoinInfoRequest) (*pb.Co
 
 // CoinInfoById returns information about coin ID.
 func (s *Service) CoinInfoById(ctx context.Context, req *pb.CoinIdRequest) (*pb.CoinInfoResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
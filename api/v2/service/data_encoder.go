@@ -2,13 +2,16 @@ package service
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strconv"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
 	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	_struct "google.golang.org/protobuf/types/known/structpb"
@@ -317,10 +320,17 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			Value: d.Value.String(),
 		}
 	case transaction.TypeEditCandidateCommission:
-		d := data.(*transaction.EditCandidateCommission)
-		m = &pb.EditCandidateCommission{
-			PubKey:     d.PubKey.String(),
-			Commission: uint64(d.Commission),
+		switch d := data.(type) {
+		case *transaction.EditCandidateCommissionV340:
+			m = &pb.EditCandidateCommission{
+				PubKey:     d.PubKey.String(),
+				Commission: uint64(d.Commission),
+			}
+		case *transaction.EditCandidateCommission:
+			m = &pb.EditCandidateCommission{
+				PubKey:     d.PubKey.String(),
+				Commission: uint64(d.Commission),
+			}
 		}
 	case transaction.TypeVoteCommission:
 		d := data.(*transaction.VoteCommissionDataV3)
@@ -389,6 +399,286 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			},
 			Value: d.Value.String(),
 		}
+	case transaction.TypeCreateVesting:
+		d := data.(*transaction.CreateVestingData)
+		vesting := map[string]interface{}{
+			"beneficiary": d.Beneficiary.String(),
+			"coin": map[string]string{
+				"id":     d.Coin.String(),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":        d.Value.String(),
+			"start_height": strconv.FormatUint(d.StartHeight, 10),
+			"cliff_height": strconv.FormatUint(d.CliffHeight, 10),
+			"end_height":   strconv.FormatUint(d.EndHeight, 10),
+			"revocable":    d.Revocable,
+		}
+		if d.Revocable {
+			vesting["revoker"] = d.Revoker.String()
+		}
+		dataStruct, err := toStruct(vesting)
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeClaimVesting:
+		d := data.(*transaction.ClaimVestingData)
+		dataStruct, err := toStruct(map[string]string{
+			"id": strconv.FormatUint(uint64(d.ID), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRevokeVesting:
+		d := data.(*transaction.RevokeVestingData)
+		dataStruct, err := toStruct(map[string]string{
+			"beneficiary": d.Beneficiary.String(),
+			"id":          strconv.FormatUint(uint64(d.ID), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateHTLC:
+		d := data.(*transaction.CreateHTLCData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"recipient": d.Recipient.String(),
+			"coin": map[string]string{
+				"id":     d.Coin.String(),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":    d.Value.String(),
+			"hashlock": hex.EncodeToString(d.Hashlock[:]),
+			"timeout":  strconv.FormatUint(d.Timeout, 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeClaimHTLC:
+		d := data.(*transaction.ClaimHTLCData)
+		dataStruct, err := toStruct(map[string]string{
+			"id":       strconv.FormatUint(uint64(d.ID), 10),
+			"preimage": hex.EncodeToString(d.Preimage),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRefundHTLC:
+		d := data.(*transaction.RefundHTLCData)
+		dataStruct, err := toStruct(map[string]string{
+			"id": strconv.FormatUint(uint64(d.ID), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRedeemReusableCheck:
+		d := data.(*transaction.RedeemReusableCheckData)
+		dataStruct, err := toStruct(map[string]string{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+			"proof":     base64.StdEncoding.EncodeToString(d.Proof[:]),
+			"value":     d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRevokeReusableCheck:
+		d := data.(*transaction.RevokeReusableCheckData)
+		dataStruct, err := toStruct(map[string]string{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeEscrowCheck:
+		d := data.(*transaction.EscrowCheckData)
+		dataStruct, err := toStruct(map[string]string{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCancelCheck:
+		d := data.(*transaction.CancelCheckData)
+		dataStruct, err := toStruct(map[string]string{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeAddLimitOrderV2:
+		d := data.(*transaction.AddLimitOrderV2Data)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin_to_sell": map[string]interface{}{
+				"id":     d.CoinToSell.String(),
+				"symbol": rCoins.GetCoin(d.CoinToSell).GetFullSymbol(),
+			},
+			"value_to_sell": d.ValueToSell.String(),
+			"coin_to_buy": map[string]interface{}{
+				"id":     d.CoinToBuy.String(),
+				"symbol": rCoins.GetCoin(d.CoinToBuy).GetFullSymbol(),
+			},
+			"value_to_buy":  d.ValueToBuy.String(),
+			"expire_height": strconv.FormatUint(d.ExpireHeight, 10),
+			"mode":          strconv.Itoa(int(d.Mode)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeAddTriggerOrder:
+		d := data.(*transaction.AddTriggerOrderData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin_to_sell": map[string]interface{}{
+				"id":     d.CoinToSell.String(),
+				"symbol": rCoins.GetCoin(d.CoinToSell).GetFullSymbol(),
+			},
+			"value_to_sell": d.ValueToSell.String(),
+			"coin_to_buy": map[string]interface{}{
+				"id":     d.CoinToBuy.String(),
+				"symbol": rCoins.GetCoin(d.CoinToBuy).GetFullSymbol(),
+			},
+			"trigger_value_to_buy": d.TriggerValueToBuy.String(),
+			"minimum_value_to_buy": d.MinimumValueToBuy.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCancelTriggerOrder:
+		d := data.(*transaction.CancelTriggerOrderData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"id": strconv.FormatUint(uint64(d.ID), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSetAutoCompound:
+		d := data.(*transaction.SetAutoCompoundData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key": d.PubKey.String(),
+			"enabled": d.Enabled,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCancelUnbond:
+		d := data.(*transaction.CancelUnbondData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"height":  strconv.FormatUint(d.Height, 10),
+			"pub_key": d.PubKey.String(),
+			"coin": map[string]string{
+				"id":     d.Coin.String(),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteParam:
+		d := data.(*transaction.VoteParamData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key": d.PubKey.String(),
+			"height":  strconv.FormatUint(d.Height, 10),
+			"name":    d.Name,
+			"value":   strconv.FormatUint(d.Value, 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteRewardPool:
+		d := data.(*transaction.VoteRewardPoolData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key":    d.PubKey.String(),
+			"height":     strconv.FormatUint(d.Height, 10),
+			"name":       d.Name,
+			"address":    d.Address.String(),
+			"commission": strconv.FormatUint(uint64(d.Commission), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSetAutoRoute:
+		d := data.(*transaction.SetAutoRouteData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"enabled": d.Enabled,
+			"pub_key": d.PubKey.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeBatch:
+		d := data.(*transaction.BatchData)
+		txs := make([]map[string]interface{}, 0, len(d.Txs))
+		for i, innerData := range d.DecodedTxs() {
+			innerJSON, err := encodeInnerTx(innerData, d.Txs[i].Type, rCoins)
+			if err != nil {
+				return nil, err
+			}
+			txs = append(txs, map[string]interface{}{
+				"type": strconv.Itoa(int(d.Txs[i].Type)),
+				"data": innerJSON,
+			})
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"txs": txs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateProposal:
+		d := data.(*transaction.CreateProposalData)
+		innerJSON, err := encodeInnerTx(d.DecodedData(), d.Type, rCoins)
+		if err != nil {
+			return nil, err
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"multisig": d.Multisig.String(),
+			"gas_coin": map[string]string{
+				"id":     d.GasCoin.String(),
+				"symbol": rCoins.GetCoin(d.GasCoin).GetFullSymbol(),
+			},
+			"type":          strconv.Itoa(int(d.Type)),
+			"data":          innerJSON,
+			"expire_height": strconv.FormatUint(d.ExpireHeight, 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeApproveProposal:
+		d := data.(*transaction.ApproveProposalData)
+		dataStruct, err := toStruct(map[string]string{
+			"id": strconv.FormatUint(uint64(d.ID), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRejectProposal:
+		d := data.(*transaction.RejectProposalData)
+		dataStruct, err := toStruct(map[string]string{
+			"id": strconv.FormatUint(uint64(d.ID), 10),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
 	default:
 		return nil, errors.New("unknown tx type")
 	}
@@ -401,6 +691,19 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 	return a, nil
 }
 
+// encodeInnerTx encodes data of the tx which is a part of another tx
+func encodeInnerTx(data transaction.Data, txType transaction.TxType, rCoins coins.RCoins) (json.RawMessage, error) {
+	innerAny, err := encode(data, txType, rCoins)
+	if err != nil {
+		return nil, err
+	}
+	innerJSON, err := protojson.Marshal(innerAny)
+	if err != nil {
+		return nil, err
+	}
+	return innerJSON, nil
+}
+
 func priceCommissionData(d *transaction.VoteCommissionDataV3, coin *coins.Model) proto.Message {
 	return &pb.VoteCommissionData{
 		PubKey: d.PubKey.String(),
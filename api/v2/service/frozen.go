@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/MinterTeam/minter-go-node/coreV2/code"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
@@ -19,7 +20,7 @@ func (s *Service) Frozen(ctx context.Context, req *pb.FrozenRequest) (*pb.Frozen
 		return nil, status.Error(codes.InvalidArgument, "invalid address")
 	}
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -35,7 +36,7 @@ func (s *Service) Frozen(ctx context.Context, req *pb.FrozenRequest) (*pb.Frozen
 	}
 	var frozen []*pb.FrozenResponse_Frozen
 
-	for i := s.blockchain.Height(); i <= s.blockchain.Height()+types.GetUnbondPeriod(); i++ {
+	for i := s.blockchain.Height(); i <= s.blockchain.Height()+cState.Params().Get(params.UnbondPeriod); i++ {
 
 		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
 			return nil, timeoutStatus.Err()
@@ -84,7 +85,7 @@ func (s *Service) Frozen(ctx context.Context, req *pb.FrozenRequest) (*pb.Frozen
 }
 
 func (s *Service) FrozenAll(ctx context.Context, req *pb.FrozenAllRequest) (*pb.FrozenResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -125,7 +126,7 @@ func (s *Service) FrozenAll(ctx context.Context, req *pb.FrozenAllRequest) (*pb.
 	}
 	endHeight := req.EndHeight
 	if endHeight == 0 {
-		endHeight = startHeight + types.GetUnbondPeriod()
+		endHeight = startHeight + cState.Params().Get(params.UnbondPeriod)
 	}
 
 	var frozen []*pb.FrozenResponse_Frozen
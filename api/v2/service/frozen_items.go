@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FrozenItemsRequest contains address in the "Mx..." format
+type FrozenItemsRequest struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height,string,omitempty"`
+}
+
+// FrozenItemCoin is a coin of frozen funds
+type FrozenItemCoin struct {
+	ID     uint64 `json:"id,string"`
+	Symbol string `json:"symbol"`
+}
+
+// FrozenItemResponse is frozen funds with the identifier unique within the height of unfreezing
+type FrozenItemResponse struct {
+	Height             uint64         `json:"height,string"`
+	ID                 uint32         `json:"id,string"`
+	Address            string         `json:"address"`
+	CandidateKey       string         `json:"candidate_key,omitempty"`
+	Coin               FrozenItemCoin `json:"coin"`
+	Value              string         `json:"value"`
+	MoveToCandidateKey string         `json:"move_to_candidate_key,omitempty"`
+}
+
+// FrozenItemsResponse is a list of frozen funds ordered by the height of unfreezing
+type FrozenItemsResponse struct {
+	Frozen []*FrozenItemResponse `json:"frozen"`
+}
+
+// FrozenItems returns frozen funds of the address with their identifiers, which are used to cancel unbonds
+func (s *Service) FrozenItems(ctx context.Context, req *FrozenItemsRequest) (*FrozenItemsResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	startHeight := req.Height
+	if startHeight == 0 {
+		startHeight = s.blockchain.Height()
+	}
+
+	fundsAll := cState.FrozenFunds().GetFrozenFundsAll(ctx, startHeight, startHeight+cState.Params().Get(params.UnbondPeriod)+1)
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	frozen := make([]*FrozenItemResponse, 0)
+	for _, funds := range fundsAll {
+		for i, fund := range funds.List {
+			if fund.Address.String() != req.Address {
+				continue
+			}
+
+			item := &FrozenItemResponse{
+				Height:  funds.Height(),
+				ID:      funds.ID(i),
+				Address: fund.Address.String(),
+				Coin: FrozenItemCoin{
+					ID:     uint64(fund.Coin),
+					Symbol: cState.Coins().GetCoin(fund.Coin).GetFullSymbol(),
+				},
+				Value: fund.Value.String(),
+			}
+			if fund.CandidateKey != nil {
+				item.CandidateKey = fund.CandidateKey.String()
+			}
+			if fund.GetMoveToCandidateID() != 0 {
+				item.MoveToCandidateKey = cState.Candidates().PubKey(fund.GetMoveToCandidateID()).String()
+			}
+			frozen = append(frozen, item)
+		}
+	}
+
+	return &FrozenItemsResponse{Frozen: frozen}, nil
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc/codes"
@@ -9,7 +11,17 @@ import (
 )
 
 // MinGasPrice returns current min gas price.
-func (s *Service) MinGasPrice(context.Context, *empty.Empty) (*pb.MinGasPriceResponse, error) {
+// Requests pinned to the state of a past height are rejected, min gas price depends on the current mempool.
+func (s *Service) MinGasPrice(ctx context.Context, _ *empty.Empty) (*pb.MinGasPriceResponse, error) {
+	if minGasPrice, ok := ctx.Value(minGasPriceContextKey{}).(uint32); ok {
+		return &pb.MinGasPriceResponse{
+			MinGasPrice: uint64(minGasPrice),
+		}, nil
+	}
+	if _, ok := ctx.Value(stateContextKey{}).(*state.CheckState); ok {
+		return nil, status.Error(codes.FailedPrecondition, "min gas price is available only for the latest height")
+	}
+
 	return &pb.MinGasPriceResponse{
 		MinGasPrice: uint64(s.blockchain.MinGasPrice()),
 	}, nil
@@ -17,7 +29,7 @@ func (s *Service) MinGasPrice(context.Context, *empty.Empty) (*pb.MinGasPriceRes
 
 // MaxGas returns current max gas.
 func (s *Service) MaxGasPrice(ctx context.Context, req *pb.MaxGasPriceRequest) (*pb.MaxGasPriceResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -0,0 +1,1173 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/vesting"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const graphQLDefaultLimit = 100
+
+// GraphQLRequest is a GraphQL query executed against the state of one height
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Height        uint64                 `json:"height,string,omitempty"`
+}
+
+// EnabledGraphQL returns true if GraphQL endpoint is enabled
+func (s *Service) EnabledGraphQL() bool {
+	return s.minterCfg.APIv2GraphQL
+}
+
+// GraphQLMaxCost returns maximum cost of one GraphQL query
+func (s *Service) GraphQLMaxCost() int {
+	return s.minterCfg.APIv2GraphQLMaxCost
+}
+
+// GraphQL executes query, all resolvers read the same state of the requested height.
+func (s *Service) GraphQL(ctx context.Context, req *GraphQLRequest) (*graphql.Result, error) {
+	if len(req.Query) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty query")
+	}
+
+	schema, err := graphQLSchema()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, nil
+	}
+
+	if err := checkGraphQLCost(schema, document, req.Variables, s.GraphQLMaxCost()); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, nil
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.LastCommittedHeight()
+	}
+
+	cState, err := s.getStateForHeight(ctx, height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	ctx = context.WithValue(withState(ctx, cState), graphQLContextKey{}, &graphQLContext{
+		service:           s,
+		cState:            cState,
+		height:            height,
+		stakesOfCandidate: map[types.Pubkey]struct{}{},
+	})
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	result.Extensions = map[string]interface{}{"height": height}
+
+	return result, nil
+}
+
+type graphQLContextKey struct{}
+
+// graphQLContext is shared by all resolvers of one query
+type graphQLContext struct {
+	service *Service
+	cState  *state.CheckState
+	height  uint64
+
+	candidatesLoaded  bool
+	validatorsLoaded  bool
+	stakesLoaded      bool
+	stakesOfCandidate map[types.Pubkey]struct{}
+}
+
+func graphQLContextFrom(p graphql.ResolveParams) (*graphQLContext, error) {
+	gqlCtx, ok := p.Context.Value(graphQLContextKey{}).(*graphQLContext)
+	if !ok {
+		return nil, errors.New("state is not available")
+	}
+
+	if timeoutStatus := gqlCtx.service.checkTimeout(p.Context, "graphql "+p.Info.FieldName); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	return gqlCtx, nil
+}
+
+func (c *graphQLContext) loadCandidates() {
+	if c.candidatesLoaded {
+		return
+	}
+	c.cState.Candidates().LoadCandidates()
+	c.candidatesLoaded = true
+}
+
+func (c *graphQLContext) loadValidators() {
+	if c.validatorsLoaded {
+		return
+	}
+	c.cState.Validators().LoadValidators()
+	c.validatorsLoaded = true
+}
+
+func (c *graphQLContext) loadStakes() {
+	c.loadCandidates()
+	if c.stakesLoaded {
+		return
+	}
+	c.cState.Candidates().LoadStakes()
+	c.stakesLoaded = true
+}
+
+func (c *graphQLContext) loadStakesOfCandidate(pubkey types.Pubkey) {
+	c.loadCandidates()
+	if c.stakesLoaded {
+		return
+	}
+	if _, ok := c.stakesOfCandidate[pubkey]; ok {
+		return
+	}
+	c.cState.Candidates().LoadStakesOfCandidate(pubkey)
+	c.stakesOfCandidate[pubkey] = struct{}{}
+}
+
+type graphQLBalance struct {
+	Coin     types.CoinID
+	Value    *big.Int
+	BipValue *big.Int
+}
+
+type graphQLStake struct {
+	PubKey   types.Pubkey
+	Owner    types.Address
+	Coin     types.CoinID
+	Value    *big.Int
+	BipValue *big.Int
+}
+
+type graphQLFrozenFund struct {
+	Height          uint64
+	Address         types.Address
+	CandidateKey    *types.Pubkey
+	Coin            types.CoinID
+	Value           *big.Int
+	MoveToCandidate *types.Pubkey
+}
+
+type graphQLWaitlistItem struct {
+	PubKey types.Pubkey
+	Coin   types.CoinID
+	Value  *big.Int
+}
+
+type graphQLEvent struct {
+	Type string
+	Data interface{}
+}
+
+var (
+	graphQLSchemaOnce sync.Once
+	graphQLSchemaInst graphql.Schema
+	graphQLSchemaErr  error
+)
+
+func graphQLSchema() (graphql.Schema, error) {
+	graphQLSchemaOnce.Do(func() {
+		graphQLSchemaInst, graphQLSchemaErr = newGraphQLSchema()
+	})
+	return graphQLSchemaInst, graphQLSchemaErr
+}
+
+var graphQLJSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Arbitrary JSON value",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+})
+
+func graphQLLimitArg() *graphql.ArgumentConfig {
+	return &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: graphQLDefaultLimit,
+		Description:  "Maximum number of items, each item is counted in the query cost",
+	}
+}
+
+func graphQLLimit(p graphql.ResolveParams) int {
+	limit, ok := p.Args["limit"].(int)
+	if !ok {
+		return graphQLDefaultLimit
+	}
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+func graphQLString(resolve func(p graphql.ResolveParams) (interface{}, error)) *graphql.Field {
+	return &graphql.Field{Type: graphql.String, Resolve: resolve}
+}
+
+func newGraphQLSchema() (graphql.Schema, error) {
+	coinType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Coin",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*coins.Model).ID()), nil
+			}},
+			"symbol": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).GetFullSymbol(), nil
+			}),
+			"name": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).Name(), nil
+			}),
+			"crr": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*coins.Model).Crr()), nil
+			}},
+			"volume": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).Volume().String(), nil
+			}),
+			"reserve": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).Reserve().String(), nil
+			}),
+			"maxSupply": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).MaxSupply().String(), nil
+			}),
+			"mintable": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).IsMintable(), nil
+			}},
+			"burnable": &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*coins.Model).IsBurnable(), nil
+			}},
+			"ownerAddress": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				gqlCtx, err := graphQLContextFrom(p)
+				if err != nil {
+					return nil, err
+				}
+				info := gqlCtx.cState.Coins().GetSymbolInfo(p.Source.(*coins.Model).Symbol())
+				if info == nil || info.OwnerAddress() == nil {
+					return nil, nil
+				}
+				return info.OwnerAddress().String(), nil
+			}),
+		},
+	})
+
+	resolveCoin := func(id func(source interface{}) types.CoinID) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			gqlCtx, err := graphQLContextFrom(p)
+			if err != nil {
+				return nil, err
+			}
+			coin := gqlCtx.cState.Coins().GetCoin(id(p.Source))
+			if coin == nil {
+				return nil, nil
+			}
+			return coin, nil
+		}
+	}
+
+	balanceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Balance",
+		Fields: graphql.Fields{
+			"coin": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*graphQLBalance).Coin
+			})},
+			"value": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLBalance).Value.String(), nil
+			}),
+			"bipValue": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLBalance).BipValue.String(), nil
+			}),
+		},
+	})
+
+	multisigType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Multisig",
+		Fields: graphql.Fields{
+			"threshold": &graphql.Field{Type: graphql.Int},
+			"weights":   &graphql.Field{Type: graphql.NewList(graphql.Int)},
+			"addresses": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		},
+	})
+
+	vestingAmount := func(amount func(item *vesting.Item, height uint64) *big.Int) *graphql.Field {
+		return graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+			gqlCtx, err := graphQLContextFrom(p)
+			if err != nil {
+				return nil, err
+			}
+			return amount(p.Source.(*vesting.Item), gqlCtx.height).String(), nil
+		})
+	}
+
+	vestingType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Vesting",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*vesting.Item).ID), nil
+			}},
+			"coin": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*vesting.Item).Coin
+			})},
+			"value": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*vesting.Item).Value.String(), nil
+			}),
+			"claimed": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*vesting.Item).Claimed.String(), nil
+			}),
+			"unlocked":  vestingAmount((*vesting.Item).Unlocked),
+			"claimable": vestingAmount((*vesting.Item).Claimable),
+			"startHeight": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*vesting.Item).StartHeight), nil
+			}},
+			"cliffHeight": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*vesting.Item).CliffHeight), nil
+			}},
+			"endHeight": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*vesting.Item).EndHeight), nil
+			}},
+			"revoker": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				item := p.Source.(*vesting.Item)
+				if !item.IsRevocable() {
+					return nil, nil
+				}
+				return item.Revoker.String(), nil
+			}),
+		},
+	})
+
+	candidateType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Candidate",
+		Fields: graphql.Fields{},
+	})
+
+	resolveCandidate := func(pubkey func(source interface{}) *types.Pubkey) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			gqlCtx, err := graphQLContextFrom(p)
+			if err != nil {
+				return nil, err
+			}
+			key := pubkey(p.Source)
+			if key == nil {
+				return nil, nil
+			}
+			gqlCtx.loadCandidates()
+			candidate := gqlCtx.cState.Candidates().GetCandidate(*key)
+			if candidate == nil {
+				return nil, nil
+			}
+			return candidate, nil
+		}
+	}
+
+	stakeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stake",
+		Fields: graphql.Fields{
+			"candidate": &graphql.Field{Type: candidateType, Resolve: resolveCandidate(func(source interface{}) *types.Pubkey {
+				return &source.(*graphQLStake).PubKey
+			})},
+			"publicKey": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLStake).PubKey.String(), nil
+			}),
+			"owner": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLStake).Owner.String(), nil
+			}),
+			"coin": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*graphQLStake).Coin
+			})},
+			"value": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLStake).Value.String(), nil
+			}),
+			"bipValue": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLStake).BipValue.String(), nil
+			}),
+		},
+	})
+
+	frozenFundType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "FrozenFund",
+		Fields: graphql.Fields{
+			"height": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*graphQLFrozenFund).Height), nil
+			}},
+			"address": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLFrozenFund).Address.String(), nil
+			}),
+			"candidateKey": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				if key := p.Source.(*graphQLFrozenFund).CandidateKey; key != nil {
+					return key.String(), nil
+				}
+				return nil, nil
+			}),
+			"coin": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*graphQLFrozenFund).Coin
+			})},
+			"value": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLFrozenFund).Value.String(), nil
+			}),
+			"moveToCandidateKey": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				if key := p.Source.(*graphQLFrozenFund).MoveToCandidate; key != nil {
+					return key.String(), nil
+				}
+				return nil, nil
+			}),
+		},
+	})
+
+	waitlistItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "WaitlistItem",
+		Fields: graphql.Fields{
+			"candidate": &graphql.Field{Type: candidateType, Resolve: resolveCandidate(func(source interface{}) *types.Pubkey {
+				return &source.(*graphQLWaitlistItem).PubKey
+			})},
+			"publicKey": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLWaitlistItem).PubKey.String(), nil
+			}),
+			"coin": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*graphQLWaitlistItem).Coin
+			})},
+			"value": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLWaitlistItem).Value.String(), nil
+			}),
+		},
+	})
+
+	candidateType.AddFieldConfig("publicKey", graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source.(*candidates.Candidate).PubKey.String(), nil
+	}))
+	candidateType.AddFieldConfig("id", &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return int(p.Source.(*candidates.Candidate).ID), nil
+	}})
+	candidateType.AddFieldConfig("rewardAddress", graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source.(*candidates.Candidate).RewardAddress.String(), nil
+	}))
+	candidateType.AddFieldConfig("ownerAddress", graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source.(*candidates.Candidate).OwnerAddress.String(), nil
+	}))
+	candidateType.AddFieldConfig("controlAddress", graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Source.(*candidates.Candidate).ControlAddress.String(), nil
+	}))
+	candidateType.AddFieldConfig("commission", &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return int(p.Source.(*candidates.Candidate).Commission), nil
+	}})
+	candidateType.AddFieldConfig("status", &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return int(p.Source.(*candidates.Candidate).Status), nil
+	}})
+	candidateType.AddFieldConfig("jailedUntil", &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return int(p.Source.(*candidates.Candidate).JailedUntil), nil
+	}})
+	candidateType.AddFieldConfig("totalStake", graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+		gqlCtx, err := graphQLContextFrom(p)
+		if err != nil {
+			return nil, err
+		}
+		return gqlCtx.cState.Candidates().GetTotalStake(p.Source.(*candidates.Candidate).PubKey).String(), nil
+	}))
+	candidateType.AddFieldConfig("selfBondRatio", &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		gqlCtx, err := graphQLContextFrom(p)
+		if err != nil {
+			return nil, err
+		}
+		pubkey := p.Source.(*candidates.Candidate).PubKey
+		gqlCtx.loadStakesOfCandidate(pubkey)
+		return int(gqlCtx.cState.Candidates().SelfBondRatio(pubkey)), nil
+	}})
+	candidateType.AddFieldConfig("pendingCommission", &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		gqlCtx, err := graphQLContextFrom(p)
+		if err != nil {
+			return nil, err
+		}
+		change := gqlCtx.cState.Candidates().GetCommissionChange(p.Source.(*candidates.Candidate).PubKey)
+		if change == nil {
+			return nil, nil
+		}
+		return int(change.Commission), nil
+	}})
+	candidateType.AddFieldConfig("pendingCommissionHeight", &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		gqlCtx, err := graphQLContextFrom(p)
+		if err != nil {
+			return nil, err
+		}
+		change := gqlCtx.cState.Candidates().GetCommissionChange(p.Source.(*candidates.Candidate).PubKey)
+		if change == nil {
+			return nil, nil
+		}
+		return strconv.FormatUint(change.Height, 10), nil
+	}})
+	candidateType.AddFieldConfig("validator", &graphql.Field{Type: graphql.Boolean, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		gqlCtx, err := graphQLContextFrom(p)
+		if err != nil {
+			return nil, err
+		}
+		gqlCtx.loadValidators()
+		return gqlCtx.cState.Validators().GetByPublicKey(p.Source.(*candidates.Candidate).PubKey) != nil, nil
+	}})
+	candidateType.AddFieldConfig("stakes", &graphql.Field{
+		Type: graphql.NewList(stakeType),
+		Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			gqlCtx, err := graphQLContextFrom(p)
+			if err != nil {
+				return nil, err
+			}
+			pubkey := p.Source.(*candidates.Candidate).PubKey
+			gqlCtx.loadStakesOfCandidate(pubkey)
+
+			limit := graphQLLimit(p)
+			stakes := gqlCtx.cState.Candidates().GetStakes(pubkey)
+			result := make([]*graphQLStake, 0, len(stakes))
+			for _, stake := range stakes {
+				if len(result) >= limit {
+					break
+				}
+				result = append(result, &graphQLStake{
+					PubKey:   pubkey,
+					Owner:    stake.Owner,
+					Coin:     stake.Coin,
+					Value:    stake.Value,
+					BipValue: stake.BipValue,
+				})
+			}
+			return result, nil
+		},
+	})
+
+	validatorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Validator",
+		Fields: graphql.Fields{
+			"publicKey": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*validators.Validator).PubKey.String(), nil
+			}),
+			"accumReward": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*validators.Validator).GetAccumReward().String(), nil
+			}),
+			"totalStake": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*validators.Validator).GetTotalBipStake().String(), nil
+			}),
+			"missedBlocks": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*validators.Validator).CountAbsentTimes(), nil
+			}},
+			"candidate": &graphql.Field{Type: candidateType, Resolve: resolveCandidate(func(source interface{}) *types.Pubkey {
+				return &source.(*validators.Validator).PubKey
+			})},
+		},
+	})
+
+	orderType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*swap.Limit).ID()), nil
+			}},
+			"coinSell": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*swap.Limit).Coin1
+			})},
+			"coinBuy": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(*swap.Limit).Coin0
+			})},
+			"wantSell": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*swap.Limit).WantSell.String(), nil
+			}),
+			"wantBuy": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*swap.Limit).WantBuy.String(), nil
+			}),
+			"price": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				order := p.Source.(*swap.Limit)
+				return swap.CalcPriceSellRat(order.WantSell, order.WantBuy).FloatString(precision), nil
+			}),
+			"owner": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*swap.Limit).Owner.String(), nil
+			}),
+			"height": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(*swap.Limit).Height), nil
+			}},
+		},
+	})
+
+	swapPoolType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SwapPool",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return int(p.Source.(swap.EditableChecker).GetID()), nil
+			}},
+			"coin0": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(swap.EditableChecker).Coin0()
+			})},
+			"coin1": &graphql.Field{Type: coinType, Resolve: resolveCoin(func(source interface{}) types.CoinID {
+				return source.(swap.EditableChecker).Coin1()
+			})},
+			"amount0": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				reserve0, _ := p.Source.(swap.EditableChecker).Reserves()
+				return reserve0.String(), nil
+			}),
+			"amount1": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				_, reserve1 := p.Source.(swap.EditableChecker).Reserves()
+				return reserve1.String(), nil
+			}),
+			"price": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				reserve0, reserve1 := p.Source.(swap.EditableChecker).Reserves()
+				return swap.CalcPriceSellRat(reserve1, reserve0).FloatString(precision), nil
+			}),
+			"liquidity": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				gqlCtx, err := graphQLContextFrom(p)
+				if err != nil {
+					return nil, err
+				}
+				liquidityCoin := gqlCtx.cState.Coins().GetCoinBySymbol(transaction.LiquidityCoinSymbol(p.Source.(swap.EditableChecker).GetID()), 0)
+				if liquidityCoin == nil {
+					return nil, nil
+				}
+				return liquidityCoin.Volume().String(), nil
+			}),
+			"orders": &graphql.Field{
+				Type:        graphql.NewList(orderType),
+				Description: "Limit orders of both directions, best price first",
+				Args:        graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if _, err := graphQLContextFrom(p); err != nil {
+						return nil, err
+					}
+					pool := p.Source.(swap.EditableChecker)
+					limit := graphQLLimit(p)
+					orders := append(pool.OrdersSell(uint32(limit)), pool.Reverse().OrdersSell(uint32(limit))...)
+					result := make([]*swap.Limit, 0, len(orders))
+					for _, order := range orders {
+						if len(result) >= limit {
+							break
+						}
+						result = append(result, graphQLOrder(order))
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Account",
+		Fields: graphql.Fields{
+			"address": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(types.Address).String(), nil
+			}),
+			"nonce": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				gqlCtx, err := graphQLContextFrom(p)
+				if err != nil {
+					return nil, err
+				}
+				return int(gqlCtx.cState.Accounts().GetNonce(p.Source.(types.Address))), nil
+			}},
+			"lockedStakeUntilBlock": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				gqlCtx, err := graphQLContextFrom(p)
+				if err != nil {
+					return nil, err
+				}
+				return int(gqlCtx.cState.Accounts().GetLockStakeUntilBlock(p.Source.(types.Address))), nil
+			}},
+			"balances": &graphql.Field{
+				Type: graphql.NewList(balanceType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					limit := graphQLLimit(p)
+					balances := gqlCtx.cState.Accounts().GetBalances(p.Source.(types.Address))
+					result := make([]*graphQLBalance, 0, len(balances))
+					for _, balance := range balances {
+						if len(result) >= limit {
+							break
+						}
+						result = append(result, &graphQLBalance{
+							Coin:     balance.Coin.ID,
+							Value:    balance.Value,
+							BipValue: customCoinBipBalance(balance.Value, gqlCtx.cState.Coins().GetCoin(balance.Coin.ID)),
+						})
+					}
+					return result, nil
+				},
+			},
+			"multisig": &graphql.Field{Type: multisigType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				gqlCtx, err := graphQLContextFrom(p)
+				if err != nil {
+					return nil, err
+				}
+				multisig := gqlCtx.service.getMultisig(gqlCtx.cState.Accounts().GetAccount(p.Source.(types.Address)))
+				if multisig == nil {
+					return nil, nil
+				}
+				return map[string]interface{}{
+					"threshold": multisig.Threshold,
+					"weights":   multisig.Weights,
+					"addresses": multisig.Addresses,
+				}, nil
+			}},
+			"stakes": &graphql.Field{
+				Type: graphql.NewList(stakeType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					address := p.Source.(types.Address)
+					gqlCtx.loadStakes()
+
+					limit := graphQLLimit(p)
+					var result []*graphQLStake
+					for _, candidate := range gqlCtx.cState.Candidates().GetCandidates() {
+						if timeoutStatus := gqlCtx.service.checkTimeout(p.Context, "graphql stakes of "+candidate.PubKey.String()); timeoutStatus != nil {
+							return nil, timeoutStatus.Err()
+						}
+						for _, stake := range gqlCtx.cState.Candidates().GetStakes(candidate.PubKey) {
+							if stake.Owner != address {
+								continue
+							}
+							if len(result) >= limit {
+								return result, nil
+							}
+							result = append(result, &graphQLStake{
+								PubKey:   candidate.PubKey,
+								Owner:    stake.Owner,
+								Coin:     stake.Coin,
+								Value:    stake.Value,
+								BipValue: stake.BipValue,
+							})
+						}
+					}
+					return result, nil
+				},
+			},
+			"frozenFunds": &graphql.Field{
+				Type: graphql.NewList(frozenFundType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					address := p.Source.(types.Address)
+					return gqlCtx.frozenFunds(p.Context, &address, nil, graphQLLimit(p)), nil
+				},
+			},
+			"waitlist": &graphql.Field{
+				Type: graphql.NewList(waitlistItemType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					return gqlCtx.waitlist(p.Source.(types.Address), nil, graphQLLimit(p)), nil
+				},
+			},
+			"vestings": &graphql.Field{
+				Type: graphql.NewList(vestingType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					model := gqlCtx.cState.Vesting().GetByAddress(p.Source.(types.Address))
+					if model == nil {
+						return []*vesting.Item{}, nil
+					}
+					limit := graphQLLimit(p)
+					if len(model.List) > limit {
+						return model.List[:limit], nil
+					}
+					return model.List, nil
+				},
+			},
+		},
+	})
+
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Event",
+		Fields: graphql.Fields{
+			"type": graphQLString(func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLEvent).Type, nil
+			}),
+			"data": &graphql.Field{Type: graphQLJSON, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphQLEvent).Data, nil
+			}},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"height": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Height of the state used by the query",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					return strconv.FormatUint(gqlCtx.height, 10), nil
+				},
+			},
+			"account": &graphql.Field{
+				Type: accountType,
+				Args: graphql.FieldConfigArgument{"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return graphQLAddress(p.Args["address"].(string))
+				},
+			},
+			"coin": &graphql.Field{
+				Type: coinType,
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.Int},
+					"symbol": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					var coin *coins.Model
+					if symbol, ok := p.Args["symbol"].(string); ok {
+						coin = gqlCtx.cState.Coins().GetCoinBySymbol(types.StrToCoinBaseSymbol(symbol), types.GetVersionFromSymbol(symbol))
+					} else if id, ok := p.Args["id"].(int); ok {
+						coin = gqlCtx.cState.Coins().GetCoin(types.CoinID(id))
+					} else {
+						return nil, errors.New("id or symbol is required")
+					}
+					if coin == nil {
+						return nil, nil
+					}
+					return coin, nil
+				},
+			},
+			"candidate": &graphql.Field{
+				Type: candidateType,
+				Args: graphql.FieldConfigArgument{"publicKey": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					pubkey, err := graphQLPubKey(p.Args["publicKey"].(string))
+					if err != nil {
+						return nil, err
+					}
+					return resolveCandidate(func(interface{}) *types.Pubkey { return &pubkey })(p)
+				},
+			},
+			"candidates": &graphql.Field{
+				Type: graphql.NewList(candidateType),
+				Args: graphql.FieldConfigArgument{
+					"status": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Filter by candidate status"},
+					"limit":  graphQLLimitArg(),
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					gqlCtx.loadCandidates()
+
+					limit := graphQLLimit(p)
+					statusFilter, byStatus := p.Args["status"].(int)
+					var result []*candidates.Candidate
+					for _, candidate := range gqlCtx.cState.Candidates().GetCandidates() {
+						if len(result) >= limit {
+							break
+						}
+						if byStatus && int(candidate.Status) != statusFilter {
+							continue
+						}
+						result = append(result, candidate)
+					}
+					return result, nil
+				},
+			},
+			"validators": &graphql.Field{
+				Type: graphql.NewList(validatorType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					gqlCtx.loadValidators()
+
+					limit := graphQLLimit(p)
+					var result []*validators.Validator
+					for _, validator := range gqlCtx.cState.Validators().GetValidators() {
+						if len(result) >= limit {
+							break
+						}
+						result = append(result, validator)
+					}
+					return result, nil
+				},
+			},
+			"swapPool": &graphql.Field{
+				Type: swapPoolType,
+				Args: graphql.FieldConfigArgument{
+					"coin0": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"coin1": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					swapper := gqlCtx.cState.Swap().GetSwapper(types.CoinID(p.Args["coin0"].(int)), types.CoinID(p.Args["coin1"].(int)))
+					if !swapper.Exists() {
+						return nil, nil
+					}
+					return swapper, nil
+				},
+			},
+			"swapPools": &graphql.Field{
+				Type: graphql.NewList(swapPoolType),
+				Args: graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					limit := graphQLLimit(p)
+					pools := gqlCtx.cState.Swap().SwapPools(p.Context)
+					if len(pools) > limit {
+						pools = pools[:limit]
+					}
+					return pools, nil
+				},
+			},
+			"order": &graphql.Field{
+				Type: orderType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					order := gqlCtx.cState.Swap().GetOrder(uint32(p.Args["id"].(int)))
+					if order == nil {
+						return nil, nil
+					}
+					return graphQLOrder(order), nil
+				},
+			},
+			"frozenFunds": &graphql.Field{
+				Type: graphql.NewList(frozenFundType),
+				Args: graphql.FieldConfigArgument{
+					"address": &graphql.ArgumentConfig{Type: graphql.String},
+					"coin":    &graphql.ArgumentConfig{Type: graphql.Int},
+					"limit":   graphQLLimitArg(),
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					var address *types.Address
+					if addr, ok := p.Args["address"].(string); ok {
+						a, err := graphQLAddress(addr)
+						if err != nil {
+							return nil, err
+						}
+						address = &a
+					}
+					var coin *types.CoinID
+					if id, ok := p.Args["coin"].(int); ok {
+						c := types.CoinID(id)
+						coin = &c
+					}
+					return gqlCtx.frozenFunds(p.Context, address, coin, graphQLLimit(p)), nil
+				},
+			},
+			"waitlist": &graphql.Field{
+				Type: graphql.NewList(waitlistItemType),
+				Args: graphql.FieldConfigArgument{
+					"address":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"publicKey": &graphql.ArgumentConfig{Type: graphql.String},
+					"limit":     graphQLLimitArg(),
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					address, err := graphQLAddress(p.Args["address"].(string))
+					if err != nil {
+						return nil, err
+					}
+					var pubkey *types.Pubkey
+					if key, ok := p.Args["publicKey"].(string); ok {
+						k, err := graphQLPubKey(key)
+						if err != nil {
+							return nil, err
+						}
+						pubkey = &k
+					}
+					return gqlCtx.waitlist(address, pubkey, graphQLLimit(p)), nil
+				},
+			},
+			"events": &graphql.Field{
+				Type:        graphql.NewList(eventType),
+				Description: "Events of the block at the query height",
+				Args:        graphql.FieldConfigArgument{"limit": graphQLLimitArg()},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					gqlCtx, err := graphQLContextFrom(p)
+					if err != nil {
+						return nil, err
+					}
+					loadEvents := gqlCtx.service.blockchain.GetEventsDB().LoadEvents(uint32(gqlCtx.height))
+					limit := graphQLLimit(p)
+					result := make([]*graphQLEvent, 0, len(loadEvents))
+					for _, event := range loadEvents {
+						if len(result) >= limit {
+							break
+						}
+						marshalJSON, err := tmjson.Marshal(event)
+						if err != nil {
+							return nil, err
+						}
+						var e struct {
+							Type  string      `json:"type"`
+							Value interface{} `json:"value"`
+						}
+						if err := json.Unmarshal(marshalJSON, &e); err != nil {
+							return nil, err
+						}
+						result = append(result, &graphQLEvent{Type: e.Type, Data: e.Value})
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// graphQLOrder returns order from the point of view of its owner: Coin1 is sold and Coin0 is bought
+func graphQLOrder(order *swap.Limit) *swap.Limit {
+	if order.IsBuy {
+		return order.Reverse()
+	}
+	return order
+}
+
+func (c *graphQLContext) frozenFunds(ctx context.Context, address *types.Address, coin *types.CoinID, limit int) []*graphQLFrozenFund {
+	c.loadCandidates()
+
+	var result []*graphQLFrozenFund
+	for _, funds := range c.cState.FrozenFunds().GetFrozenFundsAll(ctx, c.height, c.height+c.cState.Params().Get(params.UnbondPeriod)+1) {
+		if funds == nil {
+			continue
+		}
+		for _, fund := range funds.List {
+			if address != nil && fund.Address != *address {
+				continue
+			}
+			if coin != nil && fund.Coin != *coin {
+				continue
+			}
+			if len(result) >= limit {
+				return result
+			}
+			item := &graphQLFrozenFund{
+				Height:       funds.Height(),
+				Address:      fund.Address,
+				CandidateKey: fund.CandidateKey,
+				Coin:         fund.Coin,
+				Value:        fund.Value,
+			}
+			if fund.GetMoveToCandidateID() != 0 {
+				moveTo := c.cState.Candidates().PubKey(fund.GetMoveToCandidateID())
+				item.MoveToCandidate = &moveTo
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func (c *graphQLContext) waitlist(address types.Address, pubkey *types.Pubkey, limit int) []*graphQLWaitlistItem {
+	c.loadCandidates()
+
+	var result []*graphQLWaitlistItem
+	model := c.cState.WaitList().GetByAddress(address)
+	if model == nil {
+		return result
+	}
+	for _, item := range model.List {
+		key := c.cState.Candidates().PubKey(item.CandidateId)
+		if pubkey != nil && key != *pubkey {
+			continue
+		}
+		if len(result) >= limit {
+			break
+		}
+		result = append(result, &graphQLWaitlistItem{
+			PubKey: key,
+			Coin:   item.Coin,
+			Value:  item.Value,
+		})
+	}
+	return result
+}
+
+func graphQLAddress(address string) (types.Address, error) {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return types.Address{}, errors.New("invalid address")
+	}
+	decodeString, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return types.Address{}, errors.New("invalid address")
+	}
+	return types.BytesToAddress(decodeString), nil
+}
+
+func graphQLPubKey(pubkey string) (types.Pubkey, error) {
+	if !strings.HasPrefix(pubkey, "Mp") {
+		return types.Pubkey{}, errors.New("invalid public_key")
+	}
+	decodeString, err := hex.DecodeString(pubkey[2:])
+	if err != nil {
+		return types.Pubkey{}, errors.New("invalid public_key")
+	}
+	return types.BytesToPubkey(decodeString), nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const graphQLMaxDepth = 10
+
+// graphQLCost estimates the number of resolved values before execution.
+// Every field costs 1, the cost of a list field selection is multiplied by its limit.
+type graphQLCost struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+	maxCost   int
+}
+
+func checkGraphQLCost(schema graphql.Schema, document *ast.Document, variables map[string]interface{}, maxCost int) error {
+	if maxCost <= 0 {
+		return nil
+	}
+
+	c := &graphQLCost{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+		visiting:  map[string]bool{},
+		maxCost:   maxCost,
+	}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	total := 0
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		cost, err := c.selectionSet(schema.QueryType(), operation.SelectionSet, 1)
+		if err != nil {
+			return err
+		}
+		total += cost
+		if total > maxCost {
+			return c.exceeded()
+		}
+	}
+
+	return nil
+}
+
+func (c *graphQLCost) exceeded() error {
+	return fmt.Errorf("query cost exceeds limit %d", c.maxCost)
+}
+
+func (c *graphQLCost) selectionSet(parent graphql.Type, set *ast.SelectionSet, depth int) (int, error) {
+	if set == nil {
+		return 0, nil
+	}
+	if depth > graphQLMaxDepth {
+		return 0, fmt.Errorf("query depth exceeds limit %d", graphQLMaxDepth)
+	}
+
+	total := 0
+	for _, selection := range set.Selections {
+		var cost int
+		var err error
+		switch sel := selection.(type) {
+		case *ast.Field:
+			cost, err = c.field(parent, sel, depth)
+		case *ast.InlineFragment:
+			typ := parent
+			if sel.TypeCondition != nil {
+				typ = c.schema.Type(sel.TypeCondition.Name.Value)
+			}
+			cost, err = c.selectionSet(typ, sel.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, ok := c.fragments[name]
+			if !ok {
+				continue
+			}
+			if c.visiting[name] {
+				return 0, fmt.Errorf("fragment %q is used recursively", name)
+			}
+			c.visiting[name] = true
+			cost, err = c.selectionSet(c.schema.Type(fragment.TypeCondition.Name.Value), fragment.SelectionSet, depth)
+			delete(c.visiting, name)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		total += cost
+		if total > c.maxCost {
+			return 0, c.exceeded()
+		}
+	}
+
+	return total, nil
+}
+
+func (c *graphQLCost) field(parent graphql.Type, field *ast.Field, depth int) (int, error) {
+	var fieldType graphql.Type
+	if object, ok := parent.(*graphql.Object); ok {
+		if definition, ok := object.Fields()[field.Name.Value]; ok {
+			fieldType = definition.Type
+		}
+	}
+
+	named, _ := graphql.GetNamed(fieldType).(graphql.Type)
+	child, err := c.selectionSet(named, field.SelectionSet, depth+1)
+	if err != nil {
+		return 0, err
+	}
+
+	if !isGraphQLList(fieldType) {
+		return 1 + child, nil
+	}
+
+	limit := c.limit(field)
+	if limit > c.maxCost {
+		limit = c.maxCost + 1
+	}
+	if child == 0 {
+		child = 1
+	}
+
+	return 1 + limit*child, nil
+}
+
+// limit returns value of the limit argument of list field
+func (c *graphQLCost) limit(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			limit, err := strconv.Atoi(value.Value)
+			if err != nil {
+				return c.maxCost + 1
+			}
+			return nonNegative(limit)
+		case *ast.Variable:
+			switch v := c.variables[value.Name.Value].(type) {
+			case float64:
+				if v > float64(c.maxCost) {
+					return c.maxCost + 1
+				}
+				return nonNegative(int(v))
+			case int:
+				return nonNegative(v)
+			}
+		}
+	}
+
+	return graphQLDefaultLimit
+}
+
+func nonNegative(value int) int {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+func isGraphQLList(ttype graphql.Type) bool {
+	if nonNull, ok := ttype.(*graphql.NonNull); ok {
+		ttype = nonNull.OfType
+	}
+	_, ok := ttype.(*graphql.List)
+	return ok
+}
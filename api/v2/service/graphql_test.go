@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+func TestService_GraphQL(t *testing.T) {
+	t.Parallel()
+	cState := getTestState(t)
+
+	addr := types.Address{1}
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(100)))
+	cState.Accounts.SetNonce(addr, 3)
+	cState.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, types.Pubkey{1}, 10, 0, 0)
+	cState.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, types.Pubkey{2}, 10, 0, 0)
+
+	ctx := withTestState(t, cState)
+	s := &Service{minterCfg: &config.Config{BaseConfig: config.BaseConfig{APIv2GraphQLMaxCost: 100}}}
+
+	tests := []struct {
+		query string
+		data  string
+		err   string
+	}{
+		{
+			query: `{ height }`,
+			data:  `map[height:1]`,
+		},
+		{
+			query: `{ account(address: "` + addr.String() + `") { nonce balances(limit: 10) { value bipValue coin { id symbol } } } }`,
+			data:  `map[account:map[balances:[map[bipValue:100000000000000000000 coin:map[id:0 symbol:` + types.GetBaseCoin().String() + `] value:100000000000000000000]] nonce:3]]`,
+		},
+		{
+			query: `{ coin(id: 10) { symbol } }`,
+			data:  `map[coin:<nil>]`,
+		},
+		{
+			query: `{ candidate(publicKey: "` + types.Pubkey{1}.String() + `") { publicKey commission } }`,
+			data:  `map[candidate:map[commission:10 publicKey:` + types.Pubkey{1}.String() + `]]`,
+		},
+		{
+			query: `{ candidates(limit: 1) { commission } }`,
+			data:  `map[candidates:[map[commission:10]]]`,
+		},
+		{
+			query: `{ candidates { publicKey } }`,
+			err:   "query cost exceeds limit 100",
+		},
+		{
+			query: `{ account(address: "0x01") { nonce } }`,
+			err:   "invalid address",
+		},
+	}
+	for _, test := range tests {
+		res, err := s.GraphQL(ctx, &GraphQLRequest{Query: test.query, Height: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.err != "" {
+			if len(res.Errors) == 0 || !strings.Contains(res.Errors[0].Message, test.err) {
+				t.Errorf("query %s returned errors %v, want %q", test.query, res.Errors, test.err)
+			}
+			continue
+		}
+		if len(res.Errors) != 0 {
+			t.Errorf("query %s returned errors %v", test.query, res.Errors)
+			continue
+		}
+		if data := fmt.Sprint(res.Data); data != test.data {
+			t.Errorf("query %s returned %s, want %s", test.query, data, test.data)
+		}
+	}
+}
+
+func TestCheckGraphQLCost(t *testing.T) {
+	t.Parallel()
+	schema, err := graphQLSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query     string
+		variables map[string]interface{}
+		maxCost   int
+		err       string
+	}{
+		{query: `{ height }`, maxCost: 1},
+		{query: `{ height coin(id: 0) { symbol } }`, maxCost: 2, err: "query cost exceeds limit 2"},
+		{query: `{ height coin(id: 0) { symbol } }`, maxCost: 3},
+		{query: `{ candidates(limit: 10) { publicKey } }`, maxCost: 11},
+		{query: `{ candidates(limit: 10) { publicKey } }`, maxCost: 10, err: "query cost exceeds limit 10"},
+		{query: `{ candidates { publicKey commission } }`, maxCost: 201},
+		{query: `{ candidates { publicKey commission } }`, maxCost: 200, err: "query cost exceeds limit 200"},
+		{query: `query($limit: Int) { candidates(limit: $limit) { publicKey } }`, variables: map[string]interface{}{"limit": float64(5)}, maxCost: 6},
+		{query: `query($limit: Int) { candidates(limit: $limit) { publicKey } }`, variables: map[string]interface{}{"limit": float64(1e12)}, maxCost: 1000, err: "query cost exceeds limit 1000"},
+		{query: `{ candidates(limit: -5) { publicKey } }`, maxCost: 1},
+		{query: `{ ...a } fragment a on Query { ...a }`, maxCost: 10, err: `fragment "a" is used recursively`},
+		{query: `{ candidates(limit: 1000000) { publicKey } }`, maxCost: 0},
+	}
+	for _, test := range tests {
+		document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(test.query)})})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = checkGraphQLCost(schema, document, test.variables, test.maxCost)
+		if test.err == "" && err != nil {
+			t.Errorf("query %s with max cost %d returned %s", test.query, test.maxCost, err)
+		}
+		if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("query %s with max cost %d returned %v, want %q", test.query, test.maxCost, err, test.err)
+		}
+	}
+}
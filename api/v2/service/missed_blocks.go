@@ -15,7 +15,7 @@ func (s *Service) MissedBlocks(ctx context.Context, req *pb.MissedBlocksRequest)
 		return nil, status.Error(codes.InvalidArgument, "public key don't has prefix 'Mp'")
 	}
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
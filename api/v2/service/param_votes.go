@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ParamVotesRequest contains the height at which voted values become effective
+type ParamVotesRequest struct {
+	TargetHeight uint64 `json:"target_height,string"`
+	Height       uint64 `json:"height,string,omitempty"`
+}
+
+// ParamVote is a value of the parameter and public keys of validators voted for it,
+// votes for reward pools have the name with the "pool." prefix, the commission as the value and the address
+type ParamVote struct {
+	Name       string   `json:"name"`
+	Value      uint64   `json:"value,string"`
+	Address    string   `json:"address,omitempty"`
+	PublicKeys []string `json:"public_keys"`
+}
+
+// ParamCurrent is the current value of the parameter
+type ParamCurrent struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value,string"`
+}
+
+// RewardPoolCurrent is the current address and commission of the reward pool
+type RewardPoolCurrent struct {
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Commission uint32 `json:"commission,string"`
+}
+
+// ParamVotesResponse is current values of parameters and reward pools and votes for the target height
+type ParamVotesResponse struct {
+	Params      []*ParamCurrent      `json:"params"`
+	RewardPools []*RewardPoolCurrent `json:"reward_pools"`
+	Votes       []*ParamVote         `json:"votes"`
+}
+
+// ParamVotes returns votes of validators for new values of network parameters.
+func (s *Service) ParamVotes(ctx context.Context, req *ParamVotesRequest) (*ParamVotesResponse, error) {
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	current := make([]*ParamCurrent, 0, len(params.Names()))
+	for _, name := range params.Names() {
+		current = append(current, &ParamCurrent{
+			Name:  name,
+			Value: cState.Params().Get(name),
+		})
+	}
+
+	pools := cState.Params().RewardPools()
+	rewardPools := make([]*RewardPoolCurrent, 0, len(pools))
+	for _, pool := range pools {
+		rewardPools = append(rewardPools, &RewardPoolCurrent{
+			Name:       pool.Name,
+			Address:    pool.Address.String(),
+			Commission: pool.Commission,
+		})
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	votes := cState.Params().GetVotes(req.TargetHeight)
+	resp := make([]*ParamVote, 0, len(votes))
+	for _, vote := range votes {
+		pubKeys := make([]string, 0, len(vote.Votes))
+		for _, pubkey := range vote.Votes {
+			pubKeys = append(pubKeys, pubkey.String())
+		}
+		paramVote := &ParamVote{
+			Name:       vote.Name,
+			Value:      vote.Value,
+			PublicKeys: pubKeys,
+		}
+		if _, ok := params.PoolName(vote.Name); ok {
+			paramVote.Address = vote.Address.String()
+		}
+		resp = append(resp, paramVote)
+	}
+
+	return &ParamVotesResponse{
+		Params:      current,
+		RewardPools: rewardPools,
+		Votes:       resp,
+	}, nil
+}
@@ -12,7 +12,7 @@ import (
 
 // PriceCommission returns current tx commissions
 func (s *Service) PriceCommission(ctx context.Context, req *pb.PriceCommissionRequest) (*pb.PriceCommissionResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProposalsRequest contains multisig address in the "Mx..." format
+type ProposalsRequest struct {
+	Multisig string `json:"multisig"`
+	Height   uint64 `json:"height,string,omitempty"`
+}
+
+// ProposalCoin is a coin to pay commission of the proposed tx
+type ProposalCoin struct {
+	ID     uint64 `json:"id,string"`
+	Symbol string `json:"symbol"`
+}
+
+// ProposalResponse is a pending proposal with weights of its votes
+type ProposalResponse struct {
+	ID               uint32          `json:"id,string"`
+	Proposer         string          `json:"proposer"`
+	GasCoin          ProposalCoin    `json:"gas_coin"`
+	Type             uint64          `json:"type,string"`
+	Data             json.RawMessage `json:"data,omitempty"`
+	ExpireHeight     uint64          `json:"expire_height,string"`
+	Approvals        []string        `json:"approvals"`
+	Rejections       []string        `json:"rejections"`
+	ApprovalsWeight  uint32          `json:"approvals_weight,string"`
+	RejectionsWeight uint32          `json:"rejections_weight,string"`
+}
+
+// ProposalsResponse is a list of pending proposals of multisig
+type ProposalsResponse struct {
+	Threshold uint32              `json:"threshold,string"`
+	Proposals []*ProposalResponse `json:"proposals"`
+}
+
+// Proposals returns pending proposals of multisig with accumulated weights of approvals and rejections
+func (s *Service) Proposals(ctx context.Context, req *ProposalsRequest) (*ProposalsResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Multisig), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(req.Multisig[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	address := types.BytesToAddress(decodeString)
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	account := cState.Accounts().GetAccount(address)
+	if !account.IsMultisig() {
+		return nil, status.Error(codes.NotFound, "multisig not found")
+	}
+	multisig := account.Multisig()
+
+	res := &ProposalsResponse{Threshold: multisig.Threshold, Proposals: []*ProposalResponse{}}
+	for _, model := range cState.Proposals().GetByMultisig(address) {
+		proposal := &ProposalResponse{
+			ID:       model.ID,
+			Proposer: model.Proposer.String(),
+			GasCoin: ProposalCoin{
+				ID:     uint64(model.GasCoin),
+				Symbol: cState.Coins().GetCoin(model.GasCoin).GetFullSymbol(),
+			},
+			Type:         uint64(model.TxType),
+			ExpireHeight: model.ExpireHeight,
+			Approvals:    []string{},
+			Rejections:   []string{},
+		}
+		for _, addr := range model.Approvals {
+			proposal.Approvals = append(proposal.Approvals, addr.String())
+			proposal.ApprovalsWeight += multisig.GetWeight(addr)
+		}
+		for _, addr := range model.Rejections {
+			proposal.Rejections = append(proposal.Rejections, addr.String())
+			proposal.RejectionsWeight += multisig.GetWeight(addr)
+		}
+
+		if d, ok := transaction.GetData(transaction.TxType(model.TxType)); ok && rlp.DecodeBytes(model.TxData, d) == nil {
+			proposal.Data, _ = encodeInnerTx(d, transaction.TxType(model.TxType), cState.Coins())
+		}
+
+		res.Proposals = append(res.Proposals, proposal)
+	}
+
+	return res, nil
+}
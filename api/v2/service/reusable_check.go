@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReusableCheckRequest contains hash of the reusable check and the optional address of redeemer in the "Mx..." format
+type ReusableCheckRequest struct {
+	Hash    string `json:"hash"`
+	Address string `json:"address,omitempty"`
+	Height  uint64 `json:"height,string,omitempty"`
+}
+
+// ReusableCheckResponse is the state of the reusable check
+type ReusableCheckResponse struct {
+	Value      string `json:"value"`
+	Redeemed   string `json:"redeemed"`
+	Remaining  string `json:"remaining"`
+	Revoked    bool   `json:"revoked"`
+	RedeemedBy string `json:"redeemed_by,omitempty"`
+}
+
+// ReusableCheck returns the redeemed and the remaining value of the reusable check
+func (s *Service) ReusableCheck(ctx context.Context, req *ReusableCheckRequest) (*ReusableCheckResponse, error) {
+	decodeHash, err := hex.DecodeString(strings.TrimPrefix(req.Hash, "0x"))
+	if err != nil || len(decodeHash) != types.HashLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid hash")
+	}
+	hash := types.BytesToHash(decodeHash)
+
+	var address *types.Address
+	if req.Address != "" {
+		if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+
+		decodeString, err := hex.DecodeString(req.Address[2:])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+
+		addr := types.BytesToAddress(decodeString)
+		address = &addr
+	}
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	check := cState.Checks().GetReusableCheck(hash)
+	if check == nil {
+		return nil, status.Error(codes.NotFound, "check is neither redeemed nor revoked")
+	}
+
+	res := &ReusableCheckResponse{
+		Value:     check.Value.String(),
+		Redeemed:  check.Redeemed.String(),
+		Remaining: check.Remaining().String(),
+		Revoked:   check.Revoked,
+	}
+	if address != nil {
+		res.RedeemedBy = cState.Checks().GetRedeemedBy(hash, *address).String()
+	}
+
+	return res, nil
+}
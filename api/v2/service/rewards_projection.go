@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/minter"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const daysPerYear = 365
+
+// RewardsProjectionRequest is the hypothetical delegation of the value of the coin to the candidate in the "Mp..." format
+type RewardsProjectionRequest struct {
+	PublicKey string `json:"public_key"`
+	Coin      uint64 `json:"coin,string"`
+	Value     string `json:"value"`
+	Height    uint64 `json:"height,string,omitempty"`
+}
+
+// RewardsProjectionResponse is projected rewards of the delegation in BIP by the current block reward, stakes and commissions.
+// Candidate shares are fractions of the validators total stake, self-bond ratios are in basis points.
+// Rewards are zero if the candidate is not a validator
+type RewardsProjectionResponse struct {
+	BlockReward          string `json:"block_reward"`
+	Validator            bool   `json:"validator"`
+	Commission           uint64 `json:"commission,string"`
+	PoolsCommission      uint64 `json:"pools_commission,string"`
+	BipValue             string `json:"bip_value"`
+	CandidateStake       string `json:"candidate_stake"`
+	NewCandidateStake    string `json:"new_candidate_stake"`
+	CandidateShare       string `json:"candidate_share"`
+	NewCandidateShare    string `json:"new_candidate_share"`
+	DelegationShare      string `json:"delegation_share"`
+	SelfBondRatio        uint64 `json:"self_bond_ratio,string"`
+	NewSelfBondRatio     uint64 `json:"new_self_bond_ratio,string"`
+	CandidateBlockReward string `json:"candidate_block_reward"`
+	BlockRewardOfStake   string `json:"block_reward_of_stake"`
+	DailyReward          string `json:"daily_reward"`
+	AnnualReward         string `json:"annual_reward"`
+	APR                  string `json:"apr"`
+}
+
+// RewardsProjection returns projected per block, daily and annual rewards and APR of the delegation to the candidate.
+// Rewards of transactions commissions are not included.
+func (s *Service) RewardsProjection(ctx context.Context, req *RewardsProjectionRequest) (*RewardsProjectionResponse, error) {
+	if !strings.HasPrefix(req.PublicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+
+	decodeString, err := hex.DecodeString(req.PublicKey[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pubkey := types.BytesToPubkey(decodeString)
+
+	value, ok := big.NewInt(0).SetString(req.Value, 10)
+	if !ok || value.Sign() != 1 {
+		return nil, status.Error(codes.InvalidArgument, "invalid value")
+	}
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	coinID := types.CoinID(req.Coin)
+	coin := cState.Coins().GetCoin(coinID)
+	if coin == nil {
+		return nil, status.Error(codes.NotFound, "Coin not found")
+	}
+	if !coin.BaseOrHasReserve() {
+		return nil, status.Error(codes.InvalidArgument, "coin has no reserve")
+	}
+
+	if req.Height != 0 {
+		cState.Candidates().LoadCandidates()
+		cState.Validators().LoadValidators()
+		if !coinID.IsBaseCoin() {
+			cState.Candidates().LoadStakes()
+		}
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	if candidate == nil {
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	if req.Height != 0 && coinID.IsBaseCoin() {
+		cState.Candidates().LoadStakesOfCandidate(pubkey)
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	blockReward := s.rewards.GetRewardForBlock(height)
+	if h := s.blockchain.GetVersionHeight(minter.V3); h != 0 && height >= h {
+		blockReward, _ = cState.App().Reward()
+	}
+
+	var poolsCommission uint64
+	for _, pool := range cState.Params().RewardPools() {
+		poolsCommission += uint64(pool.Commission)
+	}
+
+	bipValue := cState.Candidates().CalculateBipValue(coinID, value)
+	candidateStake := cState.Candidates().GetTotalStake(pubkey)
+	newCandidateStake := big.NewInt(0).Add(candidateStake, bipValue)
+
+	res := &RewardsProjectionResponse{
+		BlockReward:          blockReward.String(),
+		Commission:           uint64(candidate.Commission),
+		PoolsCommission:      poolsCommission,
+		BipValue:             bipValue.String(),
+		CandidateStake:       candidateStake.String(),
+		NewCandidateStake:    newCandidateStake.String(),
+		CandidateShare:       "0",
+		NewCandidateShare:    "0",
+		DelegationShare:      fraction(bipValue, newCandidateStake),
+		SelfBondRatio:        cState.Candidates().SelfBondRatio(pubkey),
+		CandidateBlockReward: "0",
+		BlockRewardOfStake:   "0",
+		DailyReward:          "0",
+		AnnualReward:         "0",
+		APR:                  "0",
+	}
+
+	if newCandidateStake.Sign() == 1 {
+		selfStake := cState.Candidates().GetSelfBipStake(pubkey)
+		res.NewSelfBondRatio = big.NewInt(0).Div(big.NewInt(0).Mul(selfStake, big.NewInt(10000)), newCandidateStake).Uint64()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	if cState.Validators().GetByPublicKey(pubkey) == nil {
+		return res, nil
+	}
+	res.Validator = true
+
+	projectValidatorRewards(res, blockReward, candidateStake, cState.Validators().TotalStakes(), bipValue, poolsCommission, uint64(candidate.Commission))
+
+	return res, nil
+}
+
+// projectValidatorRewards sets shares and rewards of the delegation of bipValue to the validator with candidateStake of validators totalStake
+func projectValidatorRewards(res *RewardsProjectionResponse, blockReward, candidateStake, totalStake, bipValue *big.Int, poolsCommission, commission uint64) {
+	newCandidateStake := big.NewInt(0).Add(candidateStake, bipValue)
+	newTotalStake := big.NewInt(0).Add(totalStake, bipValue)
+	res.CandidateShare = fraction(candidateStake, totalStake)
+	res.NewCandidateShare = fraction(newCandidateStake, newTotalStake)
+
+	// the reward of the validator by its share of the validators total stake
+	candidateReward := big.NewInt(0).Mul(blockReward, newCandidateStake)
+	candidateReward.Div(candidateReward, newTotalStake)
+	res.CandidateBlockReward = candidateReward.String()
+
+	// the reward of delegators after reward pools and validator commissions
+	delegatorsReward := big.NewInt(0).Set(candidateReward)
+	delegatorsReward.Sub(delegatorsReward, big.NewInt(0).Div(big.NewInt(0).Mul(candidateReward, big.NewInt(int64(poolsCommission))), big.NewInt(100)))
+	delegatorsReward.Sub(delegatorsReward, big.NewInt(0).Div(big.NewInt(0).Mul(delegatorsReward, big.NewInt(int64(commission))), big.NewInt(100)))
+
+	stakeReward := big.NewInt(0).Mul(delegatorsReward, bipValue)
+	stakeReward.Div(stakeReward, newCandidateStake)
+	res.BlockRewardOfStake = stakeReward.String()
+
+	dailyReward := big.NewInt(0).Mul(stakeReward, big.NewInt(types.BlocksPerDay))
+	res.DailyReward = dailyReward.String()
+
+	annualReward := big.NewInt(0).Mul(dailyReward, big.NewInt(daysPerYear))
+	res.AnnualReward = annualReward.String()
+
+	if bipValue.Sign() == 1 {
+		apr := big.NewFloat(0).Quo(new(big.Float).SetInt(annualReward), new(big.Float).SetInt(bipValue))
+		res.APR = apr.Mul(apr, big.NewFloat(100)).Text('f', 2)
+	}
+}
+
+func fraction(value, total *big.Int) string {
+	if total.Sign() != 1 {
+		return "0"
+	}
+	return big.NewFloat(0).Quo(new(big.Float).SetInt(value), new(big.Float).SetInt(total)).Text('f', 6)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProjectValidatorRewards(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		blockReward, candidateStake, totalStake, bipValue string
+		poolsCommission, commission                       uint64
+
+		candidateShare, newCandidateShare        string
+		candidateBlockReward, blockRewardOfStake string
+		dailyReward, annualReward, apr           string
+	}{
+		{
+			blockReward: "1000", candidateStake: "900", totalStake: "1900", bipValue: "100", poolsCommission: 10, commission: 10,
+			candidateShare: "0.473684", newCandidateShare: "0.500000",
+			candidateBlockReward: "500", blockRewardOfStake: "40",
+			dailyReward: "691200", annualReward: "252288000", apr: "252288000.00",
+		},
+		{
+			blockReward: "100", candidateStake: "0", totalStake: "1000", bipValue: "1000",
+			candidateShare: "0.000000", newCandidateShare: "0.500000",
+			candidateBlockReward: "50", blockRewardOfStake: "50",
+			dailyReward: "864000", annualReward: "315360000", apr: "31536000.00",
+		},
+		{
+			blockReward: "7", candidateStake: "3", totalStake: "10", bipValue: "2", poolsCommission: 10, commission: 5,
+			candidateShare: "0.300000", newCandidateShare: "0.416667",
+			candidateBlockReward: "2", blockRewardOfStake: "0",
+			dailyReward: "0", annualReward: "0", apr: "0.00",
+		},
+		{
+			blockReward: "100", candidateStake: "0", totalStake: "0", bipValue: "10",
+			candidateShare: "0", newCandidateShare: "1.000000",
+			candidateBlockReward: "100", blockRewardOfStake: "100",
+			dailyReward: "1728000", annualReward: "630720000", apr: "6307200000.00",
+		},
+		// mainnet scale: 100 BIP per block, 1M BIP delegated to the validator with 200M BIP of 4B BIP staked,
+		// APR = 100 * (1 - 0.2) * (1 - 0.1) * 17280 * 365 / 4001M * 100 = 11.35
+		{
+			blockReward: "100000000000000000000", candidateStake: "200000000000000000000000000", totalStake: "4000000000000000000000000000", bipValue: "1000000000000000000000000", poolsCommission: 20, commission: 10,
+			candidateShare: "0.050000", newCandidateShare: "0.050237",
+			candidateBlockReward: "5023744063984003999", blockRewardOfStake: "17995501124718820",
+			dailyReward: "310962259435141209600", annualReward: "113501224693826541504000", apr: "11.35",
+		},
+	}
+	for i, test := range tests {
+		res := &RewardsProjectionResponse{APR: "0"}
+		projectValidatorRewards(res, helpers.StringToBigInt(test.blockReward), helpers.StringToBigInt(test.candidateStake), helpers.StringToBigInt(test.totalStake), helpers.StringToBigInt(test.bipValue), test.poolsCommission, test.commission)
+
+		for _, field := range []struct{ name, got, want string }{
+			{"candidate share", res.CandidateShare, test.candidateShare},
+			{"new candidate share", res.NewCandidateShare, test.newCandidateShare},
+			{"candidate block reward", res.CandidateBlockReward, test.candidateBlockReward},
+			{"block reward of stake", res.BlockRewardOfStake, test.blockRewardOfStake},
+			{"daily reward", res.DailyReward, test.dailyReward},
+			{"annual reward", res.AnnualReward, test.annualReward},
+			{"apr", res.APR, test.apr},
+		} {
+			if field.got != field.want {
+				t.Errorf("projection %d has %s %s, want %s", i, field.name, field.got, field.want)
+			}
+		}
+	}
+}
+
+func TestService_RewardsProjection_invalid(t *testing.T) {
+	t.Parallel()
+	cState := getTestState(t)
+	ctx := withTestState(t, cState)
+
+	tests := []struct {
+		req  *RewardsProjectionRequest
+		code codes.Code
+	}{
+		{req: &RewardsProjectionRequest{PublicKey: "Mx01", Value: "1", Height: 1}, code: codes.InvalidArgument},
+		{req: &RewardsProjectionRequest{PublicKey: types.Pubkey{1}.String(), Value: "0", Height: 1}, code: codes.InvalidArgument},
+		{req: &RewardsProjectionRequest{PublicKey: types.Pubkey{1}.String(), Value: "1", Coin: 10, Height: 1}, code: codes.NotFound},
+		{req: &RewardsProjectionRequest{PublicKey: types.Pubkey{1}.String(), Value: "1", Height: 1}, code: codes.NotFound},
+	}
+	for i, test := range tests {
+		if _, err := new(Service).RewardsProjection(ctx, test.req); status.Code(err) != test.code {
+			t.Errorf("request %d returned %v, want %s", i, err, test.code)
+		}
+	}
+}
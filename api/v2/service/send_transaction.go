@@ -58,6 +58,7 @@ func (s *Service) broadcastTxSync(ctx context.Context, tx types.Tx) (*ResultBroa
 		if err.Error() == mempool.ErrTxInCache.Error() {
 			return nil, status.New(codes.AlreadyExists, err.Error())
 		}
+		s.blockchain.TxBroadcastFailed(tx.Hash(), err)
 		return nil, status.New(codes.FailedPrecondition, err.Error())
 	}
 
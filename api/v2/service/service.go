@@ -8,6 +8,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/MinterTeam/minter-go-node/coreV2/minter"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	"github.com/MinterTeam/node-grpc-gateway/api_pb"
 	tmNode "github.com/tendermint/tendermint/node"
@@ -61,6 +62,28 @@ func (s *Service) Version() string {
 	return s.version
 }
 
+type stateContextKey struct{}
+
+// withState pins the state snapshot used by all handlers called with the returned context
+func withState(ctx context.Context, cState *state.CheckState) context.Context {
+	return context.WithValue(ctx, stateContextKey{}, cState)
+}
+
+type minGasPriceContextKey struct{}
+
+// withMinGasPrice pins the min gas price returned to requests with the returned context
+func withMinGasPrice(ctx context.Context, minGasPrice uint32) context.Context {
+	return context.WithValue(ctx, minGasPriceContextKey{}, minGasPrice)
+}
+
+// getStateForHeight returns the state pinned in ctx or immutable state for given height
+func (s *Service) getStateForHeight(ctx context.Context, height uint64) (*state.CheckState, error) {
+	if cState, ok := ctx.Value(stateContextKey{}).(*state.CheckState); ok {
+		return cState, nil
+	}
+	return s.blockchain.GetStateForHeight(height)
+}
+
 func (s *Service) createError(statusErr *status.Status, data string) error {
 	if len(data) == 0 {
 		return statusErr.Err()
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	db "github.com/tendermint/tm-db"
+)
+
+func getTestState(t *testing.T) *state.State {
+	s, err := state.NewState(0, db.NewMemDB(), &events.MockEvents{}, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// withTestState commits the state and pins it to the context of requests
+func withTestState(t *testing.T, s *state.State) context.Context {
+	if _, err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	return withState(context.Background(), state.NewCheckState(s))
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/slashes"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SlashesRequest contains address of delegator in the "Mx..." format
+type SlashesRequest struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height,string,omitempty"`
+}
+
+// SlashCoin is a slashed coin
+type SlashCoin struct {
+	ID     uint64 `json:"id,string"`
+	Symbol string `json:"symbol"`
+}
+
+// SlashRecord is a part of the stake slashed at the height
+type SlashRecord struct {
+	Height     uint64    `json:"height,string"`
+	Infraction string    `json:"infraction"`
+	Coin       SlashCoin `json:"coin"`
+	Amount     string    `json:"amount"`
+}
+
+// SlashTotal is the total amount of the coin slashed
+type SlashTotal struct {
+	Coin   SlashCoin `json:"coin"`
+	Amount string    `json:"amount"`
+}
+
+// SlashesOfCandidate is slashes of stakes of the delegator in the candidate
+type SlashesOfCandidate struct {
+	PublicKey string         `json:"public_key"`
+	Total     []*SlashTotal  `json:"total"`
+	Records   []*SlashRecord `json:"records"`
+}
+
+// SlashesResponse is the history of slashes of stakes of the delegator grouped by candidates
+type SlashesResponse struct {
+	Candidates []*SlashesOfCandidate `json:"candidates"`
+}
+
+// Slashes returns the history of slashes of stakes of the delegator.
+func (s *Service) Slashes(ctx context.Context, req *SlashesRequest) (*SlashesResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(req.Address[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	address := types.BytesToAddress(decodeString)
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &SlashesResponse{Candidates: []*SlashesOfCandidate{}}
+	ledger := cState.Slashes().GetLedger(address)
+	if ledger == nil {
+		return res, nil
+	}
+
+	byCandidate := map[uint32]*SlashesOfCandidate{}
+	totals := map[uint32]map[types.CoinID]*big.Int{}
+	for _, record := range ledger.Records {
+		item, ok := byCandidate[record.CandidateID]
+		if !ok {
+			item = &SlashesOfCandidate{
+				PublicKey: cState.Candidates().PubKey(record.CandidateID).String(),
+				Total:     []*SlashTotal{},
+				Records:   []*SlashRecord{},
+			}
+			byCandidate[record.CandidateID] = item
+			totals[record.CandidateID] = map[types.CoinID]*big.Int{}
+			res.Candidates = append(res.Candidates, item)
+		}
+
+		item.Records = append(item.Records, &SlashRecord{
+			Height:     record.Height,
+			Infraction: slashes.InfractionName(record.Infraction),
+			Coin:       slashCoin(cState, record.Coin),
+			Amount:     record.Amount.String(),
+		})
+
+		total, ok := totals[record.CandidateID][record.Coin]
+		if !ok {
+			total = big.NewInt(0)
+			totals[record.CandidateID][record.Coin] = total
+			item.Total = append(item.Total, &SlashTotal{Coin: slashCoin(cState, record.Coin)})
+		}
+		total.Add(total, record.Amount)
+	}
+
+	for id, item := range byCandidate {
+		for _, total := range item.Total {
+			total.Amount = totals[id][types.CoinID(total.Coin.ID)].String()
+		}
+	}
+
+	return res, nil
+}
+
+// CandidateSlashesRequest contains public key of candidate in the "Mp..." format
+type CandidateSlashesRequest struct {
+	PublicKey string `json:"public_key"`
+	Height    uint64 `json:"height,string,omitempty"`
+}
+
+// DowntimeInfraction is a downtime offence of the candidate which is not forgotten yet
+type DowntimeInfraction struct {
+	Height uint64 `json:"height,string"`
+	Level  uint64 `json:"level,string"`
+}
+
+// CandidateSlashesResponse is lifetime infractions and slashes of the candidate and its current uptime
+type CandidateSlashesResponse struct {
+	DoubleSigns    uint64        `json:"double_signs,string"`
+	Downtimes      uint64        `json:"downtimes,string"`
+	LastHeight     uint64        `json:"last_height,string,omitempty"`
+	LastInfraction string        `json:"last_infraction,omitempty"`
+	Slashed        []*SlashTotal `json:"slashed"`
+	Validator      bool          `json:"validator"`
+	MissedBlocks   uint64        `json:"missed_blocks,string"`
+	Window         uint64        `json:"window,string"`
+	Uptime         string        `json:"uptime"`
+
+	DowntimeInfractions []*DowntimeInfraction `json:"downtime_infractions"`
+	NextDowntimeLevel   uint64                `json:"next_downtime_level,string"`
+}
+
+// CandidateSlashes returns lifetime infractions and slashes of the candidate and its uptime over the absent window.
+func (s *Service) CandidateSlashes(ctx context.Context, req *CandidateSlashesRequest) (*CandidateSlashesResponse, error) {
+	if !strings.HasPrefix(req.PublicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+
+	decodeString, err := hex.DecodeString(req.PublicKey[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pubkey := types.BytesToPubkey(decodeString)
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if req.Height != 0 {
+		cState.Candidates().LoadCandidates()
+		cState.Validators().LoadValidators()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	if candidate == nil {
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	res := &CandidateSlashesResponse{
+		Slashed:             []*SlashTotal{},
+		Uptime:              "0",
+		DowntimeInfractions: []*DowntimeInfraction{},
+		NextDowntimeLevel:   uint64(cState.Candidates().DowntimeLevel(pubkey, height+1)),
+	}
+	for _, infraction := range cState.Candidates().GetInfractions(pubkey) {
+		res.DowntimeInfractions = append(res.DowntimeInfractions, &DowntimeInfraction{
+			Height: infraction.Height,
+			Level:  uint64(infraction.Level),
+		})
+	}
+	if summary := cState.Slashes().GetSummary(candidate.ID); summary != nil {
+		res.DoubleSigns = uint64(summary.DoubleSigns)
+		res.Downtimes = uint64(summary.Downtimes)
+		res.LastHeight = summary.LastHeight
+		if summary.LastHeight != 0 {
+			res.LastInfraction = slashes.InfractionName(summary.LastInfraction)
+		}
+		for _, slashed := range summary.Slashed {
+			res.Slashed = append(res.Slashed, &SlashTotal{
+				Coin:   slashCoin(cState, slashed.Coin),
+				Amount: slashed.Amount.String(),
+			})
+		}
+	}
+
+	validator := cState.Validators().GetByPublicKey(pubkey)
+	if validator == nil || validator.AbsentTimes == nil || validator.AbsentTimes.Size() == 0 {
+		return res, nil
+	}
+
+	res.Validator = true
+	res.Window = uint64(validator.AbsentTimes.Size())
+	res.MissedBlocks = uint64(validator.CountAbsentTimes())
+	res.Uptime = big.NewFloat(0).Quo(
+		big.NewFloat(float64(res.Window-res.MissedBlocks)),
+		big.NewFloat(float64(res.Window)),
+	).Text('f', 4)
+
+	return res, nil
+}
+
+func slashCoin(cState *state.CheckState, id types.CoinID) SlashCoin {
+	coin := SlashCoin{ID: uint64(id)}
+	if model := cState.Coins().GetCoin(id); model != nil {
+		coin.Symbol = model.GetFullSymbol()
+	}
+	return coin
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/slashes"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+func TestService_Slashes(t *testing.T) {
+	t.Parallel()
+	cState := getTestState(t)
+
+	delegator := types.Address{1}
+	first, second := types.Pubkey{1}, types.Pubkey{2}
+	cState.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, first, 10, 0, 0)
+	cState.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, second, 10, 0, 0)
+	firstID, secondID := cState.Candidates.ID(first), cState.Candidates.ID(second)
+
+	cState.Slashes.AddSlash(10, slashes.InfractionDoubleSign, firstID, delegator, 0, big.NewInt(50))
+	cState.Slashes.AddSlash(20, slashes.InfractionDowntime, secondID, delegator, 0, big.NewInt(3))
+	cState.Slashes.AddSlash(30, slashes.InfractionDowntime, firstID, delegator, 0, big.NewInt(7))
+
+	ctx := withTestState(t, cState)
+
+	res, err := new(Service).Slashes(ctx, &SlashesRequest{Address: delegator.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		publicKey   string
+		total       string
+		heights     []uint64
+		infractions []string
+	}{
+		{publicKey: first.String(), total: "57", heights: []uint64{10, 30}, infractions: []string{"double_sign", "downtime"}},
+		{publicKey: second.String(), total: "3", heights: []uint64{20}, infractions: []string{"downtime"}},
+	}
+	if len(res.Candidates) != len(tests) {
+		t.Fatalf("slashes of %d candidates, want %d", len(res.Candidates), len(tests))
+	}
+	for i, test := range tests {
+		item := res.Candidates[i]
+		if item.PublicKey != test.publicKey {
+			t.Errorf("candidate %d is %s, want %s", i, item.PublicKey, test.publicKey)
+		}
+		if len(item.Total) != 1 || item.Total[0].Amount != test.total || item.Total[0].Coin.Symbol != types.GetBaseCoin().String() {
+			t.Errorf("total of %s is %+v, want %s", test.publicKey, item.Total, test.total)
+		}
+		if len(item.Records) != len(test.heights) {
+			t.Fatalf("%d records of %s, want %d", len(item.Records), test.publicKey, len(test.heights))
+		}
+		for j, record := range item.Records {
+			if record.Height != test.heights[j] || record.Infraction != test.infractions[j] {
+				t.Errorf("record %d of %s is %+v", j, test.publicKey, record)
+			}
+		}
+	}
+
+	res, err = new(Service).Slashes(ctx, &SlashesRequest{Address: types.Address{2}.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Candidates) != 0 {
+		t.Fatal("slashes of not slashed delegator are returned")
+	}
+
+	if _, err := new(Service).Slashes(ctx, &SlashesRequest{Address: "Mp01"}); err == nil {
+		t.Fatal("invalid address is accepted")
+	}
+}
+
+func TestService_CandidateSlashes(t *testing.T) {
+	t.Parallel()
+	cState := getTestState(t)
+
+	pubkey := types.Pubkey{1}
+	cState.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, pubkey, 10, 0, 0)
+	id := cState.Candidates.ID(pubkey)
+
+	cState.Slashes.AddSlash(10, slashes.InfractionDoubleSign, id, types.Address{1}, 0, big.NewInt(50))
+	cState.Slashes.AddInfraction(20, slashes.InfractionDowntime, id)
+	cState.Slashes.AddSlash(30, slashes.InfractionDowntime, id, types.Address{2}, 0, big.NewInt(7))
+
+	ctx := withTestState(t, cState)
+
+	res, err := new(Service).CandidateSlashes(ctx, &CandidateSlashesRequest{PublicKey: pubkey.String(), Height: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.DoubleSigns != 1 || res.Downtimes != 2 || res.LastHeight != 30 || res.LastInfraction != "downtime" {
+		t.Fatalf("invalid summary %+v", res)
+	}
+	if len(res.Slashed) != 1 || res.Slashed[0].Amount != "57" {
+		t.Fatalf("invalid slashed %+v", res.Slashed)
+	}
+	if res.Validator || res.Uptime != "0" {
+		t.Fatalf("candidate is reported as validator: %+v", res)
+	}
+
+	if _, err := new(Service).CandidateSlashes(ctx, &CandidateSlashesRequest{PublicKey: types.Pubkey{2}.String(), Height: 1}); err == nil {
+		t.Fatal("slashes of not existing candidate are returned")
+	}
+}
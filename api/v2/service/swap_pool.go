@@ -23,7 +23,7 @@ func (s *Service) LimitOrdersOfPool(ctx context.Context, req *pb.LimitOrdersOfPo
 		return nil, status.Error(codes.InvalidArgument, "equal coins id")
 	}
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -78,7 +78,7 @@ func (s *Service) LimitOrdersOfPool(ctx context.Context, req *pb.LimitOrdersOfPo
 }
 
 func (s *Service) LimitOrder(ctx context.Context, req *pb.LimitOrderRequest) (*pb.LimitOrderResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -119,7 +119,7 @@ func (s *Service) LimitOrder(ctx context.Context, req *pb.LimitOrderRequest) (*p
 }
 
 func (s *Service) LimitOrders(ctx context.Context, req *pb.LimitOrdersRequest) (*pb.LimitOrdersResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -178,7 +178,7 @@ func (s *Service) SwapPool(ctx context.Context, req *pb.SwapPoolRequest) (*pb.Sw
 		return nil, timeoutStatus.Err()
 	}
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -220,7 +220,7 @@ func (s *Service) SwapPoolProvider(ctx context.Context, req *pb.SwapPoolProvider
 	}
 	address := types.BytesToAddress(decodeString)
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -257,7 +257,7 @@ func (s *Service) SwapPoolProvider(ctx context.Context, req *pb.SwapPoolProvider
 }
 
 func (s *Service) SwapPools(ctx context.Context, req *pb.SwapPoolsRequest) (*pb.SwapPoolsResponse, error) {
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -340,7 +340,7 @@ func (s *Service) BestTrade(ctx context.Context, req *pb.BestTradeRequest) (*pb.
 		return nil, status.Error(codes.InvalidArgument, fmt.Errorf("cannot decode %s into big.Int", amount).Error())
 	}
 
-	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	cState, err := s.getStateForHeight(ctx, req.Height)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TriggerOrdersRequest contains optional address of owner in the "Mx..." format
+type TriggerOrdersRequest struct {
+	Address string `json:"address,omitempty"`
+	Height  uint64 `json:"height,string,omitempty"`
+}
+
+// TriggerOrderCoin is a coin of trigger order
+type TriggerOrderCoin struct {
+	ID     uint64 `json:"id,string"`
+	Symbol string `json:"symbol"`
+}
+
+// TriggerOrderResponse is a stop-loss or take-profit order waiting for its trigger price
+type TriggerOrderResponse struct {
+	ID                uint32           `json:"id,string"`
+	Owner             string           `json:"owner"`
+	CoinToSell        TriggerOrderCoin `json:"coin_to_sell"`
+	ValueToSell       string           `json:"value_to_sell"`
+	CoinToBuy         TriggerOrderCoin `json:"coin_to_buy"`
+	TriggerValueToBuy string           `json:"trigger_value_to_buy"`
+	MinimumValueToBuy string           `json:"minimum_value_to_buy"`
+	TriggerPrice      string           `json:"trigger_price"`
+	StopLoss          bool             `json:"stop_loss"`
+	Height            uint64           `json:"height,string"`
+}
+
+// TriggerOrdersResponse is a list of trigger orders in the order of their creation
+type TriggerOrdersResponse struct {
+	Orders []*TriggerOrderResponse `json:"orders"`
+}
+
+// TriggerOrders returns trigger orders of owner or all trigger orders if the address is empty
+func (s *Service) TriggerOrders(ctx context.Context, req *TriggerOrdersRequest) (*TriggerOrdersResponse, error) {
+	var address *types.Address
+	if req.Address != "" {
+		if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+
+		decodeString, err := hex.DecodeString(req.Address[2:])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+
+		owner := types.BytesToAddress(decodeString)
+		address = &owner
+	}
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &TriggerOrdersResponse{Orders: []*TriggerOrderResponse{}}
+	for _, order := range cState.Swap().GetTriggerOrders() {
+		if address != nil && order.Owner != *address {
+			continue
+		}
+
+		res.Orders = append(res.Orders, &TriggerOrderResponse{
+			ID:    order.ID(),
+			Owner: order.Owner.String(),
+			CoinToSell: TriggerOrderCoin{
+				ID:     uint64(order.Coin1),
+				Symbol: cState.Coins().GetCoin(order.Coin1).GetFullSymbol(),
+			},
+			ValueToSell: order.WantSell.String(),
+			CoinToBuy: TriggerOrderCoin{
+				ID:     uint64(order.Coin0),
+				Symbol: cState.Coins().GetCoin(order.Coin0).GetFullSymbol(),
+			},
+			TriggerValueToBuy: order.TriggerWantBuy.String(),
+			MinimumValueToBuy: order.WantBuy.String(),
+			TriggerPrice:      order.TriggerPrice().FloatString(precision),
+			StopLoss:          order.StopLoss,
+			Height:            order.Height,
+		})
+	}
+
+	return res, nil
+}
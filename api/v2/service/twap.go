@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TWAPRequest contains coins of the pool and the number of blocks of the window ending at the height
+type TWAPRequest struct {
+	Coin0  uint64 `json:"coin0,string"`
+	Coin1  uint64 `json:"coin1,string"`
+	Window uint64 `json:"window,string"`
+	Height uint64 `json:"height,string,omitempty"`
+}
+
+// TWAPResponse is the time-weighted average price of coin0 in coin1 and the price of coin1 in coin0
+type TWAPResponse struct {
+	Price        string `json:"price"`
+	PriceInverse string `json:"price_inverse"`
+	FromHeight   uint64 `json:"from_height,string"`
+	ToHeight     uint64 `json:"to_height,string"`
+}
+
+// TWAP returns the time-weighted average prices of the pool at the end of blocks of the window
+func (s *Service) TWAP(ctx context.Context, req *TWAPRequest) (*TWAPResponse, error) {
+	if req.Coin0 == req.Coin1 {
+		return nil, status.Error(codes.InvalidArgument, "equal coins id")
+	}
+
+	if req.Window == 0 || req.Window > swap.ObservationsWindow {
+		return nil, status.Errorf(codes.InvalidArgument, "window must be from 1 to %d blocks", swap.ObservationsWindow)
+	}
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+	if req.Window >= height {
+		return nil, status.Error(codes.InvalidArgument, "window is longer than the chain")
+	}
+
+	fromHeight := height - req.Window
+	price, err := cState.Swap().TWAP(types.CoinID(req.Coin0), types.CoinID(req.Coin1), fromHeight, height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	priceInverse, err := cState.Swap().TWAP(types.CoinID(req.Coin1), types.CoinID(req.Coin0), fromHeight, height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &TWAPResponse{
+		Price:        price.FloatString(precision),
+		PriceInverse: priceInverse.FloatString(precision),
+		FromHeight:   fromHeight,
+		ToHeight:     height,
+	}, nil
+}
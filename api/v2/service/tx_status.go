@@ -0,0 +1,202 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/minter"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	txStatusMaxHashes    = 100
+	txStatusPollInterval = time.Second
+)
+
+// TxStatusRequest contains hash of transaction in the "Mt..." format
+type TxStatusRequest struct {
+	Hash string `json:"hash"`
+}
+
+// TxStatusStreamRequest contains hashes of transactions in the "Mt..." format
+type TxStatusStreamRequest struct {
+	Hashes []string `json:"hashes"`
+}
+
+// TxStatusResponse is a status of one transaction.
+// Included and failed in a block transactions are final, a dropped transaction is failed without height in a block
+// and has the reason of leaving mempool.
+type TxStatusResponse struct {
+	Hash           string                 `json:"hash"`
+	Status         string                 `json:"status"`
+	Final          bool                   `json:"final"`
+	Height         uint64                 `json:"height,string,omitempty"`
+	Code           uint32                 `json:"code"`
+	Log            string                 `json:"log,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+	EvictionReason string                 `json:"eviction_reason,omitempty"`
+	EvictionHeight uint64                 `json:"eviction_height,string,omitempty"`
+}
+
+// TxStatus returns status of transaction: unknown, mempool, included or failed
+func (s *Service) TxStatus(ctx context.Context, req *TxStatusRequest) (*TxStatusResponse, error) {
+	hashes, err := decodeTxHashes([]string{req.Hash})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.txStatuses(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return statuses[0], nil
+}
+
+// TxStatusStream sends status of every transaction and then its changes, until all transactions are final
+func (s *Service) TxStatusStream(ctx context.Context, req *TxStatusStreamRequest, send func(*TxStatusResponse) error) error {
+	hashes, err := decodeTxHashes(req.Hashes)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.minterCfg.WSConnectionDuration)
+	defer cancel()
+
+	last := make([]*TxStatusResponse, len(hashes))
+	for {
+		statuses, err := s.txStatuses(ctx, hashes)
+		if err != nil {
+			return err
+		}
+
+		final := true
+		for i, res := range statuses {
+			if last[i] == nil || last[i].Status != res.Status || last[i].EvictionReason != res.EvictionReason {
+				if err := send(res); err != nil {
+					return err
+				}
+				last[i] = res
+			}
+			final = final && res.Final
+		}
+		if final {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil
+			}
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(txStatusPollInterval):
+		}
+	}
+}
+
+func decodeTxHashes(hashes []string) ([][]byte, error) {
+	if len(hashes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "hashes are empty")
+	}
+	if len(hashes) > txStatusMaxHashes {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("max number of hashes is %d", txStatusMaxHashes))
+	}
+
+	decoded := make([][]byte, 0, len(hashes))
+	for _, hash := range hashes {
+		if !strings.HasPrefix(strings.Title(hash), "Mt") {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid hash %s", hash))
+		}
+		b, err := hex.DecodeString(hash[2:])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		decoded = append(decoded, b)
+	}
+
+	return decoded, nil
+}
+
+func (s *Service) txStatuses(ctx context.Context, hashes [][]byte) ([]*TxStatusResponse, error) {
+	var mempool map[string]struct{}
+	statuses := make([]*TxStatusResponse, 0, len(hashes))
+	for _, hash := range hashes {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		res := &TxStatusResponse{
+			Hash:   "Mt" + strings.ToLower(hex.EncodeToString(hash)),
+			Status: minter.TxStatusUnknown,
+		}
+		statuses = append(statuses, res)
+
+		if tx, err := s.client.Tx(ctx, hash, false); err == nil {
+			res.setIncluded(uint64(tx.Height), tx.TxResult.Code, tx.TxResult.Log, tx.TxResult.Info)
+			continue
+		}
+
+		if mempool == nil {
+			mempool = map[string]struct{}{}
+			for _, tx := range s.tmNode.Mempool().ReapMaxTxs(-1) {
+				mempool[string(tx.Hash())] = struct{}{}
+			}
+		}
+		if _, ok := mempool[string(hash)]; ok {
+			res.Status = minter.TxStatusMempool
+			continue
+		}
+
+		// the record is read after the mempool, delivered transactions are recorded before they leave it
+		record, ok := s.blockchain.RecentTx(hash)
+		if !ok {
+			continue
+		}
+		switch {
+		case record.Reason != "":
+			res.setEvicted(record.Reason, record.Height, record.Code, record.Log, record.Info)
+		case record.Status == minter.TxStatusMempool:
+			res.setEvicted(minter.EvictionDropped, record.Height, 0, "", "")
+		default:
+			// not indexed yet
+			res.setIncluded(record.Height, record.Code, record.Log, record.Info)
+		}
+	}
+
+	return statuses, nil
+}
+
+func (res *TxStatusResponse) setIncluded(height uint64, code uint32, log, info string) {
+	res.Status = minter.TxStatusIncluded
+	if code != 0 {
+		res.Status = minter.TxStatusFailed
+	}
+	res.Final = true
+	res.Height = height
+	res.setResult(code, log, info)
+}
+
+func (res *TxStatusResponse) setEvicted(reason string, height uint64, code uint32, log, info string) {
+	res.Status = minter.TxStatusFailed
+	res.EvictionReason = reason
+	res.EvictionHeight = height
+	res.setResult(code, log, info)
+}
+
+// setResult decodes details of code.* errors from info
+func (res *TxStatusResponse) setResult(code uint32, log, info string) {
+	res.Code = code
+	res.Log = log
+	if len(info) == 0 {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewBufferString(info))
+	decoder.UseNumber()
+	_ = decoder.Decode(&res.Data)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VestingsRequest contains address of beneficiary in the "Mx..." format
+type VestingsRequest struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height,string,omitempty"`
+}
+
+// VestingCoin is a coin of vesting
+type VestingCoin struct {
+	ID     uint64 `json:"id,string"`
+	Symbol string `json:"symbol"`
+}
+
+// VestingResponse is a vesting schedule with amounts at the requested height
+type VestingResponse struct {
+	ID          uint32      `json:"id,string"`
+	Coin        VestingCoin `json:"coin"`
+	Value       string      `json:"value"`
+	Claimed     string      `json:"claimed"`
+	Unlocked    string      `json:"unlocked"`
+	Claimable   string      `json:"claimable"`
+	StartHeight uint64      `json:"start_height,string"`
+	CliffHeight uint64      `json:"cliff_height,string"`
+	EndHeight   uint64      `json:"end_height,string"`
+	Revoker     string      `json:"revoker,omitempty"`
+}
+
+// VestingsResponse is a list of vesting schedules of beneficiary
+type VestingsResponse struct {
+	Vestings []*VestingResponse `json:"vestings"`
+}
+
+// Vestings returns vesting schedules of beneficiary
+func (s *Service) Vestings(ctx context.Context, req *VestingsRequest) (*VestingsResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(req.Address[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	address := types.BytesToAddress(decodeString)
+
+	cState, err := s.getStateForHeight(ctx, req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	res := &VestingsResponse{Vestings: []*VestingResponse{}}
+	model := cState.Vesting().GetByAddress(address)
+	if model == nil {
+		return res, nil
+	}
+
+	for _, item := range model.List {
+		vesting := &VestingResponse{
+			ID: item.ID,
+			Coin: VestingCoin{
+				ID:     uint64(item.Coin),
+				Symbol: cState.Coins().GetCoin(item.Coin).GetFullSymbol(),
+			},
+			Value:       item.Value.String(),
+			Claimed:     item.Claimed.String(),
+			Unlocked:    item.Unlocked(height).String(),
+			Claimable:   item.Claimable(height).String(),
+			StartHeight: item.StartHeight,
+			CliffHeight: item.CliffHeight,
+			EndHeight:   item.EndHeight,
+		}
+		if item.IsRevocable() {
+			vesting.Revoker = item.Revoker.String()
+		}
+		res.Vestings = append(res.Vestings, vesting)
+	}
+
+	return res, nil
+}
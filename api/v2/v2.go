@@ -115,6 +115,121 @@ func Run(srv *service.Service, addrGRPC, addrAPI string, logger log.Logger) erro
 		}
 		http.StripPrefix("/v2", handlers.CompressHandler(allowCORS(wsproxy.WebsocketProxy(gwmux)))).ServeHTTP(writer, request)
 	})
+	mux.Handle("/v2/batch", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.BatchRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.Batch(ctx, req)
+	}))))
+	mux.Handle("/v2/tx_status", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.TxStatusRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.TxStatus(ctx, req)
+	}))))
+	mux.Handle("/v2/tx_status_stream", allowCORS(txStatusStreamHandler(srv)))
+	mux.Handle("/v2/vestings", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.VestingsRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.Vestings(ctx, req)
+	}))))
+	mux.Handle("/v2/trigger_orders", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.TriggerOrdersRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.TriggerOrders(ctx, req)
+	}))))
+	mux.Handle("/v2/twap", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.TWAPRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.TWAP(ctx, req)
+	}))))
+	mux.Handle("/v2/frozen_items", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.FrozenItemsRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.FrozenItems(ctx, req)
+	}))))
+	mux.Handle("/v2/param_votes", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.ParamVotesRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.ParamVotes(ctx, req)
+	}))))
+	mux.Handle("/v2/slashes", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.SlashesRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.Slashes(ctx, req)
+	}))))
+	mux.Handle("/v2/candidate_slashes", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.CandidateSlashesRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.CandidateSlashes(ctx, req)
+	}))))
+	mux.Handle("/v2/candidate_info", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.CandidateInfoRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.CandidateInfo(ctx, req)
+	}))))
+	mux.Handle("/v2/auto_route", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.AutoRouteRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.AutoRoute(ctx, req)
+	}))))
+	mux.Handle("/v2/rewards_projection", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.RewardsProjectionRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.RewardsProjection(ctx, req)
+	}))))
+	mux.Handle("/v2/proposals", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.ProposalsRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.Proposals(ctx, req)
+	}))))
+	mux.Handle("/v2/reusable_check", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.ReusableCheckRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.ReusableCheck(ctx, req)
+	}))))
+	mux.Handle("/v2/check_status", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+		req := new(service.CheckStatusRequest)
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return srv.CheckStatus(ctx, req)
+	}))))
+	if srv.EnabledGraphQL() {
+		mux.Handle("/v2/graphql", handlers.CompressHandler(allowCORS(jsonHandler(srv.TimeoutDuration(), func(ctx context.Context, body []byte) (interface{}, error) {
+			req := new(service.GraphQLRequest)
+			if err := json.Unmarshal(body, req); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			return srv.GraphQL(ctx, req)
+		}))))
+	}
 
 	group.Go(func() error {
 		return http.ListenAndServe(addrAPI, mux)
@@ -217,6 +332,101 @@ func httpError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Mar
 	}
 }
 
+// jsonHandler serves API v2 methods not described in the gRPC gateway, request and response are plain JSON
+func jsonHandler(timeout time.Duration, call func(ctx context.Context, body []byte) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSONError(w, status.Error(codes.Unimplemented, "method not allowed, use POST"))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSONError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		res, err := call(ctx, body)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			grpclog.Infof("Failed to write response: %v", err)
+		}
+	})
+}
+
+// txStatusStreamHandler writes statuses of transactions as newline delimited JSON until all of them are final
+func txStatusStreamHandler(srv *service.Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSONError(w, status.Error(codes.Unimplemented, "method not allowed, use POST"))
+			return
+		}
+
+		req := new(service.TxStatusStreamRequest)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeJSONError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		flusher, _ := w.(http.Flusher)
+		encoder := json.NewEncoder(w)
+		started := false
+		err := srv.TxStatusStream(r.Context(), req, func(res *service.TxStatusResponse) error {
+			if !started {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				started = true
+			}
+			if err := encoder.Encode(res); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
+		})
+		if err != nil && !started {
+			writeJSONError(w, err)
+		}
+	})
+}
+
+func writeJSONError(w http.ResponseWriter, err error) {
+	s, ok := status.FromError(err)
+	if !ok {
+		s = status.New(codes.Unknown, err.Error())
+	}
+
+	codeString, data := parseStatus(s)
+	delete(data, "code")
+
+	buf, err := protojson.Marshal(&gw.ErrorBody{
+		Error: &gw.ErrorBody_Error{
+			Code:    codeString,
+			Message: s.Message(),
+			Data:    data,
+		},
+	})
+	if err != nil {
+		grpclog.Infof("Failed to marshal error message %q: %v", s, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(s.Code()))
+	if _, err := w.Write(buf); err != nil {
+		grpclog.Infof("Failed to write response: %v", err)
+	}
+}
+
 func requestExtractorFields() grpc_ctxtags.Option {
 	return grpc_ctxtags.WithFieldExtractorForInitialReq(func(fullMethod string, req interface{}) map[string]interface{} {
 		retMap := make(map[string]interface{})
@@ -118,6 +118,7 @@ func runNode(cmd *cobra.Command) error {
 
 	if cfg.Instrumentation.Prometheus {
 		go app.SetStatisticData(statistics.New()).Statistic(cmd.Context())
+		go app.SetEconomicsData(statistics.NewEconomics(cfg.MetricsMaxValidators, cfg.MetricsMaxPools, cfg.MetricsMaxCoins, app.GetStateForHeight)).Collect(cmd.Context())
 	}
 
 	return app.WaitStop()
@@ -118,6 +118,7 @@ type Config struct {
 	Consensus       *tmConfig.ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *tmConfig.TxIndexConfig         `mapstructure:"tx_index"`
 	Instrumentation *tmConfig.InstrumentationConfig `mapstructure:"instrumentation"`
+	Notifier        *NotifierConfig                 `mapstructure:"notifier"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -131,6 +132,7 @@ func defaultConfig() *Config {
 		Consensus:       tmConfig.DefaultConsensusConfig(),
 		TxIndex:         tmConfig.DefaultTxIndexConfig(),
 		Instrumentation: tmConfig.DefaultInstrumentationConfig(),
+		Notifier:        DefaultNotifierConfig(),
 	}
 }
 
@@ -175,6 +177,46 @@ func GetTmConfig(cfg *Config) *tmConfig.Config {
 	}
 }
 
+// -----------------------------------------------------------------------------
+// NotifierConfig
+
+// NotifierConfig defines webhook notifications about chain events relevant for node operators
+type NotifierConfig struct {
+	// URLs to POST notifications to, empty list disables notifier
+	URLs []string `mapstructure:"urls"`
+
+	// Key of HMAC-SHA256 signature of the request body sent in X-Minter-Signature header
+	Secret string `mapstructure:"secret"`
+
+	// Public keys of own validators for jail, slash and validator_dropped notifications
+	PubKeys []string `mapstructure:"pub_keys"`
+
+	// Notifications to send: jail, slash, validator_dropped, halt_vote, update_network, update_commissions
+	Events []string `mapstructure:"events"`
+
+	// Maximum number of delivery attempts of one notification
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// Maximum delay between delivery attempts
+	MaxRetryInterval time.Duration `mapstructure:"max_retry_interval"`
+}
+
+// DefaultNotifierConfig returns a default configuration of webhook notifications
+func DefaultNotifierConfig() *NotifierConfig {
+	return &NotifierConfig{
+		URLs:             []string{},
+		PubKeys:          []string{},
+		Events:           []string{"jail", "slash", "validator_dropped", "halt_vote", "update_network", "update_commissions"},
+		MaxAttempts:      20,
+		MaxRetryInterval: 10 * time.Minute,
+	}
+}
+
+// Enabled returns true if at least one URL is configured
+func (cfg *NotifierConfig) Enabled() bool {
+	return cfg != nil && len(cfg.URLs) != 0
+}
+
 // -----------------------------------------------------------------------------
 // BaseConfig
 
@@ -252,6 +294,27 @@ type BaseConfig struct {
 	// APIv2Prometheus
 	APIv2Prometheus bool `mapstructure:"api_v2_prometheus"`
 
+	// Maximum number of requests in one API v2 batch
+	APIv2BatchMaxSize int `mapstructure:"api_v2_batch_max_size"`
+
+	// Enable GraphQL endpoint of API v2
+	APIv2GraphQL bool `mapstructure:"api_v2_graphql"`
+
+	// Maximum cost of one GraphQL query, 0 disables the check
+	APIv2GraphQLMaxCost int `mapstructure:"api_v2_graphql_max_cost"`
+
+	// Maximum number of validators labeled in chain metrics, validators with the biggest stake are chosen
+	MetricsMaxValidators int `mapstructure:"metrics_max_validators"`
+
+	// Maximum number of swap pools labeled in chain metrics, pools with the biggest base coin reserve are chosen
+	MetricsMaxPools int `mapstructure:"metrics_max_pools"`
+
+	// Maximum number of coins labeled in commission metrics, other coins are summed with label "other"
+	MetricsMaxCoins int `mapstructure:"metrics_max_coins"`
+
+	// Number of recently seen transaction hashes kept with the reason of leaving mempool
+	TxStatusCacheSize int `mapstructure:"tx_status_cache_size"`
+
 	// WebSocket connection duration
 	WSConnectionDuration time.Duration `mapstructure:"ws_connection_duration"`
 
@@ -294,6 +357,13 @@ func DefaultBaseConfig() BaseConfig {
 		APIv2TimeoutDuration:    10 * time.Second,
 		APIv2Logger:             false,
 		APIv2Prometheus:         false,
+		APIv2BatchMaxSize:       20,
+		APIv2GraphQL:            false,
+		APIv2GraphQLMaxCost:     10000,
+		MetricsMaxValidators:    64,
+		MetricsMaxPools:         20,
+		MetricsMaxCoins:         20,
+		TxStatusCacheSize:       10000,
 		WSConnectionDuration:    time.Minute,
 		ValidatorMode:           false,
 		KeepLastStates:          120,
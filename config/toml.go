@@ -80,6 +80,23 @@ api_v2_logger = "{{ .BaseConfig.APIv2Logger }}"
 
 api_v2_prometheus = "{{ .BaseConfig.APIv2Prometheus }}"
 
+# Maximum number of requests in one API v2 batch
+api_v2_batch_max_size = {{ .BaseConfig.APIv2BatchMaxSize }}
+
+# Enable GraphQL endpoint /v2/graphql
+api_v2_graphql = {{ .BaseConfig.APIv2GraphQL }}
+
+# Maximum cost of one GraphQL query, every field costs 1 and list fields are multiplied by their limit
+api_v2_graphql_max_cost = {{ .BaseConfig.APIv2GraphQLMaxCost }}
+
+# Limits of labels in chain metrics published with instrumentation.prometheus
+metrics_max_validators = {{ .BaseConfig.MetricsMaxValidators }}
+metrics_max_pools = {{ .BaseConfig.MetricsMaxPools }}
+metrics_max_coins = {{ .BaseConfig.MetricsMaxCoins }}
+
+# Number of recently seen transactions remembered for TxStatus, including the reason they left mempool
+tx_status_cache_size = {{ .BaseConfig.TxStatusCacheSize }}
+
 # WebSocket connection duration
 ws_connection_duration = "{{ .BaseConfig.WSConnectionDuration }}"
 
@@ -266,4 +283,23 @@ max_open_connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "minter"
+
+##### webhook notifications #####
+[notifier]
+
+# URLs to POST signed JSON notifications to, empty list disables notifier
+urls = [{{range $element := .Notifier.URLs}} "{{$element}}", {{end}}]
+
+# Key of HMAC-SHA256 signature of the request body, sent in hex in X-Minter-Signature header
+secret = "{{ .Notifier.Secret }}"
+
+# Public keys of own validators for jail, slash and validator_dropped notifications
+pub_keys = [{{range $element := .Notifier.PubKeys}} "{{$element}}", {{end}}]
+
+# Notifications to send: jail, slash, validator_dropped, halt_vote, update_network, update_commissions
+events = [{{range $element := .Notifier.Events}} "{{$element}}", {{end}}]
+
+# Undelivered notifications are kept in data/notifier and retried with exponential backoff
+max_attempts = {{ .Notifier.MaxAttempts }}
+max_retry_interval = "{{ .Notifier.MaxRetryInterval }}"
 `
@@ -47,14 +47,16 @@ func (check *Check) Sender() (types.Address, error) {
 
 // LockPubKey returns bytes of public key, which is used for proving check's recipient rights
 func (check *Check) LockPubKey() ([]byte, error) {
-	sig := check.Lock.Bytes()
+	return lockPubKey(check.Lock, check.HashWithoutLock())
+}
+
+func lockPubKey(lock *big.Int, hash types.Hash) ([]byte, error) {
+	sig := lock.Bytes()
 
 	if len(sig) < 65 {
 		sig = append(make([]byte, 65-len(sig)), sig...)
 	}
 
-	hash := check.HashWithoutLock()
-
 	pub, err := crypto.Ecrecover(hash[:], sig)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,115 @@
+package check
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+// ReusableCheck is a check which can be redeemed by parts by many receivers until the total of Value is redeemed.
+// The remaining value of the check is kept in the state, so the issuer is able to revoke the check.
+//
+// Nonce - unique "id" of the check.
+// Coin Symbol - symbol of coin.
+// Value - total amount of coins.
+// MaxPerRedeemer - amount of coins one receiver can redeem in total, zero means no limit.
+// GasCoin - symbol of a coin to pay fee.
+// DueBlock - defines last block height in which the check can be used.
+// Lock - secret to prevent hijacking.
+// V, R, S - signature of issuer.
+type ReusableCheck struct {
+	Nonce          []byte
+	ChainID        types.ChainID
+	DueBlock       uint64
+	Coin           types.CoinID
+	Value          *big.Int
+	MaxPerRedeemer *big.Int
+	GasCoin        types.CoinID
+	Lock           *big.Int
+	V              *big.Int
+	R              *big.Int
+	S              *big.Int
+}
+
+// Sender returns sender's address of a ReusableCheck, recovered from signature
+func (check *ReusableCheck) Sender() (types.Address, error) {
+	return recoverPlain(check.Hash(), check.R, check.S, check.V)
+}
+
+// LockPubKey returns bytes of public key, which is used for proving check's recipient rights
+func (check *ReusableCheck) LockPubKey() ([]byte, error) {
+	return lockPubKey(check.Lock, check.HashWithoutLock())
+}
+
+// HashWithoutLock returns a types.Hash to be used in process of signing and checking Lock
+func (check *ReusableCheck) HashWithoutLock() types.Hash {
+	return rlpHash([]interface{}{
+		check.Nonce,
+		check.ChainID,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.MaxPerRedeemer,
+		check.GasCoin,
+	})
+}
+
+// Hash returns a types.Hash to be used in process of signing a ReusableCheck by sender
+func (check *ReusableCheck) Hash() types.Hash {
+	return rlpHash([]interface{}{
+		check.Nonce,
+		check.ChainID,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.MaxPerRedeemer,
+		check.GasCoin,
+		check.Lock,
+	})
+}
+
+// Sign signs the check with given private key, returns error
+func (check *ReusableCheck) Sign(prv *ecdsa.PrivateKey) error {
+	h := check.Hash()
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return err
+	}
+
+	check.R = new(big.Int).SetBytes(sig[:32])
+	check.S = new(big.Int).SetBytes(sig[32:64])
+	check.V = new(big.Int).SetBytes([]byte{sig[64] + 27})
+
+	return nil
+}
+
+func (check *ReusableCheck) String() string {
+	sender, _ := check.Sender()
+
+	return fmt.Sprintf("Reusable check sender: %s nonce: %x, dueBlock: %d, value: %s %s, max per redeemer: %s", sender.String(), check.Nonce,
+		check.DueBlock, check.Value.String(), check.Coin.String(), check.MaxPerRedeemer.String())
+}
+
+// DecodeReusableFromBytes decodes reusable check from bytes
+func DecodeReusableFromBytes(buf []byte) (*ReusableCheck, error) {
+	var check ReusableCheck
+	err := rlp.DecodeBytes(buf, &check)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.S == nil || check.R == nil || check.V == nil {
+		return nil, errors.New("incorrect tx signature")
+	}
+
+	if check.Value == nil || check.MaxPerRedeemer == nil {
+		return nil, errors.New("incorrect check value")
+	}
+
+	return &check, nil
+}
@@ -1,6 +1,7 @@
 package code
 
 import (
+	"encoding/json"
 	"strconv"
 )
 
@@ -66,6 +67,7 @@ const (
 	TooBigStake           uint32 = 415
 	UnbondBlocked         uint32 = 416
 	EqualPubKey           uint32 = 417
+	CommissionPending     uint32 = 418
 
 	// check
 	CheckInvalidLock uint32 = 501
@@ -107,6 +109,64 @@ const (
 	CoinIsNotToken  uint32 = 800
 	CoinNotMintable uint32 = 801
 	CoinNotBurnable uint32 = 802
+
+	// vesting
+	VestingNotExists        uint32 = 900
+	WrongVestingSchedule    uint32 = 901
+	IsNotRevokerOfVesting   uint32 = 902
+	NothingToClaimOfVesting uint32 = 903
+	WrongVestingValue       uint32 = 904
+
+	// batch
+	BatchTxFailed uint32 = 1000
+
+	// multisig proposals
+	ProposalNotExists         uint32 = 1100
+	IsNotSignerOfMultisig     uint32 = 1101
+	ProposalAlreadyVoted      uint32 = 1102
+	WrongProposalExpireHeight uint32 = 1103
+	ProposalTxFailed          uint32 = 1104
+
+	// htlc
+	HTLCNotExists     uint32 = 1200
+	WrongHTLCPreimage uint32 = 1201
+	HTLCExpired       uint32 = 1202
+	HTLCNotExpired    uint32 = 1203
+	WrongHTLCTimeout  uint32 = 1204
+	IsNotSenderOfHTLC uint32 = 1205
+	WrongHTLCValue    uint32 = 1206
+
+	// sponsored txs
+	TxCanNotBeSponsored uint32 = 1300
+	SponsorshipExpired  uint32 = 1301
+
+	// reusable checks
+	ReusableCheckRevoked  uint32 = 1400
+	WrongRedeemValue      uint32 = 1401
+	RedeemerLimitExceeded uint32 = 1402
+	IsNotIssuerOfCheck    uint32 = 1403
+
+	// check escrows
+	CheckEscrowed         uint32 = 1500
+	CheckEscrowNotExpired uint32 = 1501
+	CheckCancelled        uint32 = 1502
+
+	// limit order options
+	WrongOrderExpireHeight uint32 = 1600
+	WrongOrderMode         uint32 = 1601
+	OrderWouldCross        uint32 = 1602
+	OrderNotMatched        uint32 = 1603
+
+	// trigger orders
+	WrongTriggerPrice uint32 = 1700
+
+	// unbond cancellation
+	UnbondNotFound uint32 = 1800
+
+	// parameter votes
+	WrongParamName  uint32 = 1900
+	WrongParamValue uint32 = 1901
+	WrongRewardPool uint32 = 1902
 )
 
 func NewInsufficientLiquidityBalance(liquidity, amount0, coin0, amount1, coin1, requestedLiquidity string) *insufficientLiquidityBalance {
@@ -940,3 +1000,415 @@ func NewEqualPubKey(pubKey string) *equalPubKey {
 		PublicKey: pubKey,
 	}
 }
+
+type vestingNotExists struct {
+	Code        string `json:"code,omitempty"`
+	Beneficiary string `json:"beneficiary"`
+	ID          string `json:"id"`
+}
+
+func NewVestingNotExists(beneficiary string, id uint32) *vestingNotExists {
+	return &vestingNotExists{
+		Code:        strconv.Itoa(int(VestingNotExists)),
+		Beneficiary: beneficiary,
+		ID:          strconv.Itoa(int(id)),
+	}
+}
+
+type wrongVestingSchedule struct {
+	Code        string `json:"code,omitempty"`
+	StartHeight string `json:"start_height"`
+	CliffHeight string `json:"cliff_height"`
+	EndHeight   string `json:"end_height"`
+}
+
+func NewWrongVestingSchedule(start, cliff, end string) *wrongVestingSchedule {
+	return &wrongVestingSchedule{
+		Code:        strconv.Itoa(int(WrongVestingSchedule)),
+		StartHeight: start,
+		CliffHeight: cliff,
+		EndHeight:   end,
+	}
+}
+
+type isNotRevokerOfVesting struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id"`
+	Revoker string `json:"revoker"`
+}
+
+func NewIsNotRevokerOfVesting(id uint32, revoker string) *isNotRevokerOfVesting {
+	return &isNotRevokerOfVesting{
+		Code:    strconv.Itoa(int(IsNotRevokerOfVesting)),
+		ID:      strconv.Itoa(int(id)),
+		Revoker: revoker,
+	}
+}
+
+type nothingToClaimOfVesting struct {
+	Code        string `json:"code,omitempty"`
+	ID          string `json:"id"`
+	CliffHeight string `json:"cliff_height"`
+}
+
+func NewNothingToClaimOfVesting(id uint32, cliff string) *nothingToClaimOfVesting {
+	return &nothingToClaimOfVesting{
+		Code:        strconv.Itoa(int(NothingToClaimOfVesting)),
+		ID:          strconv.Itoa(int(id)),
+		CliffHeight: cliff,
+	}
+}
+
+type batchTxFailed struct {
+	Code    string          `json:"code,omitempty"`
+	Index   string          `json:"index"`
+	TxType  string          `json:"tx_type"`
+	TxCode  string          `json:"tx_code"`
+	TxError json.RawMessage `json:"tx_error,omitempty"`
+}
+
+func NewBatchTxFailed(index int, txType string, txCode uint32, txError string) *batchTxFailed {
+	return &batchTxFailed{
+		Code:    strconv.Itoa(int(BatchTxFailed)),
+		Index:   strconv.Itoa(index),
+		TxType:  txType,
+		TxCode:  strconv.Itoa(int(txCode)),
+		TxError: json.RawMessage(txError),
+	}
+}
+
+type proposalNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id"`
+}
+
+func NewProposalNotExists(id uint32) *proposalNotExists {
+	return &proposalNotExists{
+		Code: strconv.Itoa(int(ProposalNotExists)),
+		ID:   strconv.Itoa(int(id)),
+	}
+}
+
+type isNotSignerOfMultisig struct {
+	Code     string `json:"code,omitempty"`
+	Multisig string `json:"multisig"`
+	Address  string `json:"address"`
+}
+
+func NewIsNotSignerOfMultisig(multisig, address string) *isNotSignerOfMultisig {
+	return &isNotSignerOfMultisig{
+		Code:     strconv.Itoa(int(IsNotSignerOfMultisig)),
+		Multisig: multisig,
+		Address:  address,
+	}
+}
+
+type proposalAlreadyVoted struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id"`
+	Address string `json:"address"`
+}
+
+func NewProposalAlreadyVoted(id uint32, address string) *proposalAlreadyVoted {
+	return &proposalAlreadyVoted{
+		Code:    strconv.Itoa(int(ProposalAlreadyVoted)),
+		ID:      strconv.Itoa(int(id)),
+		Address: address,
+	}
+}
+
+type wrongProposalExpireHeight struct {
+	Code          string `json:"code,omitempty"`
+	ExpireHeight  string `json:"expire_height"`
+	CurrentHeight string `json:"current_height"`
+	MaxHeight     string `json:"max_height"`
+}
+
+func NewWrongProposalExpireHeight(expireHeight, currentHeight, maxHeight string) *wrongProposalExpireHeight {
+	return &wrongProposalExpireHeight{
+		Code:          strconv.Itoa(int(WrongProposalExpireHeight)),
+		ExpireHeight:  expireHeight,
+		CurrentHeight: currentHeight,
+		MaxHeight:     maxHeight,
+	}
+}
+
+type proposalTxFailed struct {
+	Code    string          `json:"code,omitempty"`
+	ID      string          `json:"id"`
+	TxType  string          `json:"tx_type"`
+	TxCode  string          `json:"tx_code"`
+	TxError json.RawMessage `json:"tx_error,omitempty"`
+}
+
+func NewProposalTxFailed(id uint32, txType string, txCode uint32, txError string) *proposalTxFailed {
+	return &proposalTxFailed{
+		Code:    strconv.Itoa(int(ProposalTxFailed)),
+		ID:      strconv.Itoa(int(id)),
+		TxType:  txType,
+		TxCode:  strconv.Itoa(int(txCode)),
+		TxError: json.RawMessage(txError),
+	}
+}
+
+type htlcNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id"`
+}
+
+func NewHTLCNotExists(id uint32) *htlcNotExists {
+	return &htlcNotExists{
+		Code: strconv.Itoa(int(HTLCNotExists)),
+		ID:   strconv.Itoa(int(id)),
+	}
+}
+
+type wrongHTLCPreimage struct {
+	Code     string `json:"code,omitempty"`
+	ID       string `json:"id"`
+	Hashlock string `json:"hashlock"`
+}
+
+func NewWrongHTLCPreimage(id uint32, hashlock string) *wrongHTLCPreimage {
+	return &wrongHTLCPreimage{
+		Code:     strconv.Itoa(int(WrongHTLCPreimage)),
+		ID:       strconv.Itoa(int(id)),
+		Hashlock: hashlock,
+	}
+}
+
+type htlcTimeout struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id"`
+	Timeout string `json:"timeout"`
+}
+
+func NewHTLCExpired(id uint32, timeout string) *htlcTimeout {
+	return &htlcTimeout{
+		Code:    strconv.Itoa(int(HTLCExpired)),
+		ID:      strconv.Itoa(int(id)),
+		Timeout: timeout,
+	}
+}
+
+func NewHTLCNotExpired(id uint32, timeout string) *htlcTimeout {
+	return &htlcTimeout{
+		Code:    strconv.Itoa(int(HTLCNotExpired)),
+		ID:      strconv.Itoa(int(id)),
+		Timeout: timeout,
+	}
+}
+
+type wrongHTLCTimeout struct {
+	Code          string `json:"code,omitempty"`
+	Timeout       string `json:"timeout"`
+	CurrentHeight string `json:"current_height"`
+	MaxHeight     string `json:"max_height"`
+}
+
+func NewWrongHTLCTimeout(timeout, currentHeight, maxHeight string) *wrongHTLCTimeout {
+	return &wrongHTLCTimeout{
+		Code:          strconv.Itoa(int(WrongHTLCTimeout)),
+		Timeout:       timeout,
+		CurrentHeight: currentHeight,
+		MaxHeight:     maxHeight,
+	}
+}
+
+type isNotSenderOfHTLC struct {
+	Code   string `json:"code,omitempty"`
+	ID     string `json:"id"`
+	Sender string `json:"sender"`
+}
+
+func NewIsNotSenderOfHTLC(id uint32, sender string) *isNotSenderOfHTLC {
+	return &isNotSenderOfHTLC{
+		Code:   strconv.Itoa(int(IsNotSenderOfHTLC)),
+		ID:     strconv.Itoa(int(id)),
+		Sender: sender,
+	}
+}
+
+type txCanNotBeSponsored struct {
+	Code   string `json:"code,omitempty"`
+	TxType string `json:"tx_type"`
+}
+
+func NewTxCanNotBeSponsored(txType string) *txCanNotBeSponsored {
+	return &txCanNotBeSponsored{
+		Code:   strconv.Itoa(int(TxCanNotBeSponsored)),
+		TxType: txType,
+	}
+}
+
+type reusableCheckRevoked struct {
+	Code string `json:"code,omitempty"`
+	Hash string `json:"hash"`
+}
+
+func NewReusableCheckRevoked(hash string) *reusableCheckRevoked {
+	return &reusableCheckRevoked{Code: strconv.Itoa(int(ReusableCheckRevoked)), Hash: hash}
+}
+
+type wrongRedeemValue struct {
+	Code      string `json:"code,omitempty"`
+	Value     string `json:"value"`
+	Remaining string `json:"remaining"`
+}
+
+func NewWrongRedeemValue(value, remaining string) *wrongRedeemValue {
+	return &wrongRedeemValue{Code: strconv.Itoa(int(WrongRedeemValue)), Value: value, Remaining: remaining}
+}
+
+type redeemerLimitExceeded struct {
+	Code           string `json:"code,omitempty"`
+	Value          string `json:"value"`
+	Redeemed       string `json:"redeemed"`
+	MaxPerRedeemer string `json:"max_per_redeemer"`
+}
+
+func NewRedeemerLimitExceeded(value, redeemed, maxPerRedeemer string) *redeemerLimitExceeded {
+	return &redeemerLimitExceeded{Code: strconv.Itoa(int(RedeemerLimitExceeded)), Value: value, Redeemed: redeemed, MaxPerRedeemer: maxPerRedeemer}
+}
+
+type isNotIssuerOfCheck struct {
+	Code   string `json:"code,omitempty"`
+	Hash   string `json:"hash"`
+	Sender string `json:"sender"`
+}
+
+func NewIsNotIssuerOfCheck(hash, sender string) *isNotIssuerOfCheck {
+	return &isNotIssuerOfCheck{Code: strconv.Itoa(int(IsNotIssuerOfCheck)), Hash: hash, Sender: sender}
+}
+
+type checkEscrow struct {
+	Code     string `json:"code,omitempty"`
+	Hash     string `json:"hash"`
+	DueBlock string `json:"due_block,omitempty"`
+}
+
+func NewCheckEscrowed(hash string) *checkEscrow {
+	return &checkEscrow{Code: strconv.Itoa(int(CheckEscrowed)), Hash: hash}
+}
+
+func NewCheckEscrowNotExpired(hash, dueBlock string) *checkEscrow {
+	return &checkEscrow{Code: strconv.Itoa(int(CheckEscrowNotExpired)), Hash: hash, DueBlock: dueBlock}
+}
+
+type checkCancelled struct {
+	Code string `json:"code,omitempty"`
+	Hash string `json:"hash"`
+}
+
+func NewCheckCancelled(hash string) *checkCancelled {
+	return &checkCancelled{Code: strconv.Itoa(int(CheckCancelled)), Hash: hash}
+}
+
+type wrongOrderExpireHeight struct {
+	Code         string `json:"code,omitempty"`
+	ExpireHeight string `json:"expire_height"`
+	MinHeight    string `json:"min_height"`
+	MaxHeight    string `json:"max_height"`
+}
+
+func NewWrongOrderExpireHeight(expireHeight, minHeight, maxHeight string) *wrongOrderExpireHeight {
+	return &wrongOrderExpireHeight{Code: strconv.Itoa(int(WrongOrderExpireHeight)), ExpireHeight: expireHeight, MinHeight: minHeight, MaxHeight: maxHeight}
+}
+
+type wrongOrderMode struct {
+	Code string `json:"code,omitempty"`
+	Mode string `json:"mode"`
+}
+
+func NewWrongOrderMode(mode string) *wrongOrderMode {
+	return &wrongOrderMode{Code: strconv.Itoa(int(WrongOrderMode)), Mode: mode}
+}
+
+type orderPriceCross struct {
+	Code       string `json:"code,omitempty"`
+	PoolPrice  string `json:"pool_price"`
+	OrderPrice string `json:"order_price"`
+}
+
+func NewOrderWouldCross(poolPrice, orderPrice string) *orderPriceCross {
+	return &orderPriceCross{Code: strconv.Itoa(int(OrderWouldCross)), PoolPrice: poolPrice, OrderPrice: orderPrice}
+}
+
+func NewOrderNotMatched(poolPrice, orderPrice string) *orderPriceCross {
+	return &orderPriceCross{Code: strconv.Itoa(int(OrderNotMatched)), PoolPrice: poolPrice, OrderPrice: orderPrice}
+}
+
+type wrongTriggerPrice struct {
+	Code         string `json:"code,omitempty"`
+	PoolPrice    string `json:"pool_price"`
+	TriggerPrice string `json:"trigger_price"`
+}
+
+func NewWrongTriggerPrice(poolPrice, triggerPrice string) *wrongTriggerPrice {
+	return &wrongTriggerPrice{Code: strconv.Itoa(int(WrongTriggerPrice)), PoolPrice: poolPrice, TriggerPrice: triggerPrice}
+}
+
+type unbondNotFound struct {
+	Code      string `json:"code,omitempty"`
+	Height    string `json:"height"`
+	Address   string `json:"address"`
+	PublicKey string `json:"public_key"`
+	CoinID    string `json:"coin_id"`
+	Value     string `json:"value"`
+}
+
+func NewUnbondNotFound(height, address, publicKey, coinID, value string) *unbondNotFound {
+	return &unbondNotFound{Code: strconv.Itoa(int(UnbondNotFound)), Height: height, Address: address, PublicKey: publicKey, CoinID: coinID, Value: value}
+}
+
+type wrongParamName struct {
+	Code string `json:"code,omitempty"`
+	Name string `json:"name"`
+}
+
+func NewWrongParamName(name string) *wrongParamName {
+	return &wrongParamName{Code: strconv.Itoa(int(WrongParamName)), Name: name}
+}
+
+type wrongParamValue struct {
+	Code  string `json:"code,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Min   string `json:"min"`
+	Max   string `json:"max"`
+}
+
+func NewWrongParamValue(name, value, min, max string) *wrongParamValue {
+	return &wrongParamValue{Code: strconv.Itoa(int(WrongParamValue)), Name: name, Value: value, Min: min, Max: max}
+}
+
+type wrongRewardPool struct {
+	Code       string `json:"code,omitempty"`
+	Name       string `json:"name"`
+	Commission string `json:"commission"`
+}
+
+func NewWrongRewardPool(name, commission string) *wrongRewardPool {
+	return &wrongRewardPool{Code: strconv.Itoa(int(WrongRewardPool)), Name: name, Commission: commission}
+}
+
+type commissionPending struct {
+	Code       string `json:"code,omitempty"`
+	PublicKey  string `json:"public_key"`
+	Commission string `json:"commission"`
+	Height     string `json:"height"`
+}
+
+func NewCommissionPending(pubKey, commission, height string) *commissionPending {
+	return &commissionPending{Code: strconv.Itoa(int(CommissionPending)), PublicKey: pubKey, Commission: commission, Height: height}
+}
+
+type sponsorshipExpired struct {
+	Code         string `json:"code,omitempty"`
+	ValidUntil   string `json:"valid_until,omitempty"`
+	CurrentBlock string `json:"current_block,omitempty"`
+}
+
+func NewSponsorshipExpired(validUntil string, currentBlock string) *sponsorshipExpired {
+	return &sponsorshipExpired{Code: strconv.Itoa(int(SponsorshipExpired)), ValidUntil: validUntil, CurrentBlock: currentBlock}
+}
@@ -10,3 +10,6 @@ var (
 	Address    = types.HexToAddress("Mx0000000000000000000000000000000000000000")
 	Commission = 10 // in %
 )
+
+// Pool is the name of the reward pool of Developers, its address and commission are changed by votes of validators
+const Pool = "developers"
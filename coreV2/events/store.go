@@ -18,6 +18,7 @@ func init() {
 	tmjson.RegisterType(&kick{}, "kick")
 	tmjson.RegisterType(&move{}, "move")
 	tmjson.RegisterType(&orderExpired{}, "orderExpired")
+	tmjson.RegisterType(&triggerOrder{}, "triggerOrder")
 	tmjson.RegisterType(&unlock{}, "unlock")
 
 	tmjson.RegisterType(&RewardEvent{}, TypeRewardEvent)
@@ -28,10 +29,14 @@ func init() {
 	tmjson.RegisterType(&StakeKickEvent{}, TypeStakeKickEvent)
 	tmjson.RegisterType(&UpdateNetworkEvent{}, TypeUpdateNetworkEvent)
 	tmjson.RegisterType(&UpdateCommissionsEvent{}, TypeUpdateCommissionsEvent)
+	tmjson.RegisterType(&UpdateParamEvent{}, TypeUpdateParamEvent)
 	tmjson.RegisterType(&OrderExpiredEvent{}, TypeOrderExpiredEvent)
+	tmjson.RegisterType(&TriggerOrderEvent{}, TypeTriggerOrderEvent)
 	tmjson.RegisterType(&RemoveCandidateEvent{}, TypeRemoveCandidateEvent)
 	tmjson.RegisterType(&UpdatedBlockRewardEvent{}, TypeUpdatedBlockRewardEvent)
 	tmjson.RegisterType(&UnlockEvent{}, TypeUnlockEvent)
+	tmjson.RegisterType(&LowSelfBondEvent{}, TypeLowSelfBondEvent)
+	tmjson.RegisterType(&CandidateCommissionChangedEvent{}, TypeCandidateCommissionChangedEvent)
 }
 
 // IEventsDB is an interface of Events
@@ -18,9 +18,14 @@ const (
 	TypeStakeMoveEvent          = "minter/StakeMoveEvent"
 	TypeUpdateNetworkEvent      = "minter/UpdateNetworkEvent"
 	TypeUpdateCommissionsEvent  = "minter/UpdateCommissionsEvent"
+	TypeUpdateParamEvent        = "minter/UpdateParamEvent"
 	TypeOrderExpiredEvent       = "minter/OrderExpiredEvent"
+	TypeTriggerOrderEvent       = "minter/TriggerOrderEvent"
 	TypeRemoveCandidateEvent    = "minter/RemoveCandidateEvent"
 	TypeUpdatedBlockRewardEvent = "minter/UpdatedBlockRewardEvent"
+	TypeLowSelfBondEvent        = "minter/LowSelfBondEvent"
+
+	TypeCandidateCommissionChangedEvent = "minter/CandidateCommissionChangedEvent"
 )
 
 type Stake interface {
@@ -63,6 +68,7 @@ const (
 	RoleDelegator
 	RoleDAO
 	RoleDevelopers
+	RolePool
 )
 
 func (r Role) String() string {
@@ -75,6 +81,8 @@ func (r Role) String() string {
 		return "DAO"
 	case RoleDevelopers:
 		return "Developers"
+	case RolePool:
+		return "Pool"
 	}
 
 	panic(fmt.Sprintf("undefined role: %d", r))
@@ -90,6 +98,8 @@ func NewRole(r string) Role {
 		return RoleDAO
 	case "Developers":
 		return RoleDevelopers
+	case "Pool":
+		return RolePool
 	}
 
 	panic("undefined role: " + r)
@@ -101,6 +111,7 @@ type reward struct {
 	Amount    []byte
 	PubKeyID  uint16
 	ForCoin   uint32
+	Restaked  bool `json:",omitempty"`
 	//CandidateID uint32
 }
 
@@ -111,6 +122,7 @@ func (r *reward) compile(pubKey *types.Pubkey, address [20]byte) Event {
 	event.Role = r.Role.String()
 	event.Amount = big.NewInt(0).SetBytes(r.Amount).String()
 	event.ForCoin = uint64(r.ForCoin)
+	event.Restaked = r.Restaked
 	//event.ValidatorID = r.CandidateID
 	return event
 }
@@ -130,6 +142,7 @@ type RewardEvent struct {
 	//ValidatorID     uint32        `json:"-"`
 	ValidatorPubKey types.Pubkey `json:"validator_pub_key"`
 	ForCoin         uint64       `json:"for_coin"`
+	Restaked        bool         `json:"restaked,omitempty"`
 }
 
 func (re *RewardEvent) Type() string {
@@ -160,6 +173,7 @@ func (re *RewardEvent) convert(pubKeyID uint16, addressID uint32) compact {
 	result.Amount = bi.Bytes()
 	result.PubKeyID = pubKeyID
 	result.ForCoin = uint32(re.ForCoin)
+	result.Restaked = re.Restaked
 	//result.CandidateID = re.ValidatorID
 	return result
 }
@@ -232,12 +246,14 @@ func (se *SlashEvent) convert(pubKeyID uint16, addressID uint32) compact {
 type jail struct {
 	PubKeyID    uint16
 	JailedUntil uint64
+	Level       uint32 `json:",omitempty"`
 }
 
 func (s *jail) compile(pubKey types.Pubkey) Event {
 	event := new(JailEvent)
 	event.ValidatorPubKey = pubKey
 	event.JailedUntil = s.JailedUntil
+	event.Level = uint64(s.Level)
 	return event
 }
 
@@ -294,10 +310,70 @@ func (oe *OrderExpiredEvent) convert(addressID uint32) compact {
 	return result
 }
 
+type triggerOrder struct {
+	AddressID   uint32
+	ID          uint32
+	CoinToSell  uint32
+	ValueToSell []byte
+	CoinToBuy   uint32
+	ValueToBuy  []byte
+}
+
+func (e *triggerOrder) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *triggerOrder) compile(address [20]byte) Event {
+	event := new(TriggerOrderEvent)
+	event.ID = uint64(e.ID)
+	event.Address = address
+	event.CoinToSell = uint64(e.CoinToSell)
+	event.ValueToSell = big.NewInt(0).SetBytes(e.ValueToSell).String()
+	event.CoinToBuy = uint64(e.CoinToBuy)
+	event.ValueToBuy = big.NewInt(0).SetBytes(e.ValueToBuy).String()
+	return event
+}
+
+// TriggerOrderEvent is emitted when the stop-loss or take-profit order is executed through the pool
+type TriggerOrderEvent struct {
+	ID          uint64        `json:"id"`
+	Address     types.Address `json:"address"`
+	CoinToSell  uint64        `json:"coin_to_sell"`
+	ValueToSell string        `json:"value_to_sell"`
+	CoinToBuy   uint64        `json:"coin_to_buy"`
+	ValueToBuy  string        `json:"value_to_buy"`
+}
+
+func (te *TriggerOrderEvent) AddressString() string {
+	return te.Address.String()
+}
+
+func (te *TriggerOrderEvent) address() types.Address {
+	return te.Address
+}
+
+func (te *TriggerOrderEvent) Type() string {
+	return TypeTriggerOrderEvent
+}
+
+func (te *TriggerOrderEvent) convert(addressID uint32) compact {
+	result := new(triggerOrder)
+	result.ID = uint32(te.ID)
+	result.AddressID = addressID
+	result.CoinToSell = uint32(te.CoinToSell)
+	valueToSell, _ := big.NewInt(0).SetString(te.ValueToSell, 10)
+	result.ValueToSell = valueToSell.Bytes()
+	result.CoinToBuy = uint32(te.CoinToBuy)
+	valueToBuy, _ := big.NewInt(0).SetString(te.ValueToBuy, 10)
+	result.ValueToBuy = valueToBuy.Bytes()
+	return result
+}
+
 type JailEvent struct {
 	//ValidatorID     uint32       `json:"validator_id"`
 	ValidatorPubKey types.Pubkey `json:"validator_pub_key"`
 	JailedUntil     uint64       `json:"jailed_until"`
+	Level           uint64       `json:"level,omitempty"`
 }
 
 func (je *JailEvent) Type() string {
@@ -316,6 +392,7 @@ func (je *JailEvent) convert(pubKeyID uint16) compact {
 	result := new(jail)
 	result.PubKeyID = pubKeyID
 	result.JailedUntil = je.JailedUntil
+	result.Level = uint32(je.Level)
 	return result
 }
 
@@ -622,6 +699,16 @@ func (un *UpdateNetworkEvent) Type() string {
 	return TypeUpdateNetworkEvent
 }
 
+type UpdateParamEvent struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Address string `json:"address,omitempty"`
+}
+
+func (up *UpdateParamEvent) Type() string {
+	return TypeUpdateParamEvent
+}
+
 type removeCandidate struct {
 	PubKeyID uint16
 }
@@ -659,6 +746,29 @@ func (ue *RemoveCandidateEvent) convert(pubKeyID uint16) compact {
 	return result
 }
 
+// LowSelfBondEvent is emitted when the candidate is skipped for the validator set because
+// the share of stakes of its owner is below the minimal one, ratios are in basis points
+type LowSelfBondEvent struct {
+	CandidatePubKey  types.Pubkey `json:"candidate_pub_key"`
+	SelfBondRatio    uint64       `json:"self_bond_ratio,string"`
+	MinSelfBondRatio uint64       `json:"min_self_bond_ratio,string"`
+}
+
+func (le *LowSelfBondEvent) Type() string {
+	return TypeLowSelfBondEvent
+}
+
+// CandidateCommissionChangedEvent is emitted when the announced commission of the candidate becomes effective
+type CandidateCommissionChangedEvent struct {
+	CandidatePubKey types.Pubkey `json:"candidate_pub_key"`
+	OldCommission   uint64       `json:"old_commission,string"`
+	Commission      uint64       `json:"commission,string"`
+}
+
+func (ce *CandidateCommissionChangedEvent) Type() string {
+	return TypeCandidateCommissionChangedEvent
+}
+
 type UpdatedBlockRewardEvent struct {
 	Value                   string `json:"value"`
 	ValueLockedStakeRewards string `json:"value_locked_stake_rewards"`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
@@ -13,6 +14,8 @@ import (
 	"math"
 	"math/big"
 	"os"
+	"sort"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,6 +26,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/notifier"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
@@ -34,6 +38,7 @@ import (
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmNode "github.com/tendermint/tendermint/node"
 	rpc "github.com/tendermint/tendermint/rpc/client/local"
+	db "github.com/tendermint/tm-db"
 )
 
 // Statuses of validators
@@ -59,6 +64,9 @@ type Blockchain struct {
 
 	executor      transaction.ExecutorTx
 	statisticData *statistics.Data
+	economicsData *statistics.Economics
+	notifier      *notifier.Notifier
+	recentTxs     *recentTxs
 
 	appDB        *appdb.AppDB
 	eventsDB     eventsdb.IEventsDB
@@ -118,9 +126,8 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 	} else {
 		eventsDB = &eventsdb.MockEvents{}
 	}
-	const updateStakesAndPayRewards = 720
 	if updateStakePeriod == 0 {
-		updateStakePeriod = updateStakesAndPayRewards
+		updateStakePeriod = types.GetUpdateStakesPeriod()
 	}
 	if expiredOrdersPeriod == 0 {
 		expiredOrdersPeriod = types.GetExpireOrdersPeriod()
@@ -128,6 +135,19 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 	if logger == nil {
 		logger = l.NewLogger(cfg)
 	}
+	var notify *notifier.Notifier
+	if cfg.Notifier.Enabled() {
+		outbox, err := db.NewGoLevelDB("data/notifier", storages.GetMinterHome())
+		if err != nil {
+			panic(err)
+		}
+		notify, err = notifier.NewNotifier(cfg.Notifier, outbox, logger)
+		if err != nil {
+			panic(err)
+		}
+		eventsDB = notify.WrapEvents(eventsDB)
+		go notify.Run(ctx)
+	}
 	app := &Blockchain{
 		logger: logger,
 
@@ -136,6 +156,8 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 		appDB:                           applicationDB,
 		storages:                        storages,
 		eventsDB:                        eventsDB,
+		notifier:                        notify,
+		recentTxs:                       newRecentTxs(cfg.TxStatusCacheSize),
 		currentMempool:                  &sync.Map{},
 		cfg:                             cfg,
 		stopChan:                        ctx,
@@ -148,6 +170,7 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 			V310: {}, // hotfix
 			V320: {},
 			V330: {},
+			V340: {}, // new transactions, params and oracle
 		},
 		executor: GetExecutor(V3),
 	}
@@ -163,6 +186,8 @@ func graceForUpdate(height uint64) *upgrades.GracePeriod {
 
 func GetExecutor(v string) transaction.ExecutorTx {
 	switch v {
+	case V340:
+		return transaction.NewExecutorV340(transaction.GetDataV340)
 	//case V3:
 	//	return transaction.NewExecutorV3(transaction.GetDataV3)
 	//case v260, v261, v262:
@@ -181,6 +206,7 @@ const ( // known update versions
 	V310 = "v310" // hotfix
 	V320 = "v320" // hotfix
 	V330 = "v330" // hotfix
+	V340 = "v340" // new transactions, params and oracle
 )
 
 func (blockchain *Blockchain) initState() {
@@ -213,6 +239,17 @@ func (blockchain *Blockchain) initState() {
 		blockchain.executor = GetExecutor(v.Name)
 	}
 
+	blockchain.applyParams()
+}
+
+// applyParams sets the periods of the blockchain changed by votes of validators
+func (blockchain *Blockchain) applyParams() {
+	if period, ok := blockchain.stateDeliver.Params.Lookup(params.UpdateStakesPeriod); ok {
+		blockchain.updateStakesAndPayRewardsPeriod = period
+	}
+	if period, ok := blockchain.stateDeliver.Params.Lookup(params.ExpireOrdersPeriod); ok {
+		blockchain.expiredOrdersPeriod = period
+	}
 }
 
 // InitChain initialize blockchain with validators and other info. Only called once.
@@ -237,6 +274,7 @@ func (blockchain *Blockchain) InitChain(req abciTypes.RequestInitChain) abciType
 	if err := blockchain.stateDeliver.Import(genesisState, genesisState.Version); err != nil {
 		panic(err)
 	}
+	blockchain.applyParams()
 	if err := blockchain.stateDeliver.Check(); err != nil {
 		panic(err)
 	}
@@ -304,6 +342,10 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 	blockchain.lockValidators.Lock()
 	blockchain.validatorsStatuses = map[types.TmAddress]int8{}
 	// give penalty to absent validators
+	SetValidatorAbsent := blockchain.stateDeliver.Validators.SetValidatorAbsent
+	if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
+		SetValidatorAbsent = blockchain.stateDeliver.Validators.SetValidatorAbsentV2
+	}
 	for _, v := range req.LastCommitInfo.Votes {
 		var address types.TmAddress
 		copy(address[:], v.Validator.Address)
@@ -312,7 +354,7 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			blockchain.stateDeliver.Validators.SetValidatorPresent(height, address)
 			blockchain.validatorsStatuses[address] = ValidatorPresent
 		} else {
-			blockchain.stateDeliver.Validators.SetValidatorAbsent(height, address, blockchain.grace)
+			SetValidatorAbsent(height, address, blockchain.grace)
 			blockchain.validatorsStatuses[address] = ValidatorAbsent
 		}
 	}
@@ -344,9 +386,16 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			continue
 		}
 
-		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+types.GetUnbondPeriod(), candidate.ID)
+		PunishFrozenFunds := blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID
+		PunishByzantineCandidate := blockchain.stateDeliver.Candidates.PunishByzantineCandidate
+		if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
+			PunishFrozenFunds = blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithIDV2
+			PunishByzantineCandidate = blockchain.stateDeliver.Candidates.PunishByzantineCandidateV2
+		}
+
+		PunishFrozenFunds(height, height+blockchain.stateDeliver.Params.Get(params.UnbondPeriod), candidate.ID)
 		blockchain.stateDeliver.Validators.PunishByzantineValidator(address)
-		blockchain.stateDeliver.Candidates.PunishByzantineCandidate(height, address)
+		PunishByzantineCandidate(height, address)
 	}
 
 	// apply frozen funds (used for unbond stakes)
@@ -388,6 +437,10 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 	}
 
 	blockchain.stateDeliver.Halts.Delete(height)
+	blockchain.stateDeliver.Proposals.DeleteExpired(height)
+	if blockchain.stateDeliver.SwapV2 != nil {
+		blockchain.stateDeliver.SwapV2.ExpireOrdersByHeight(height)
+	}
 
 	return abciTypes.ResponseBeginBlock{}
 }
@@ -445,11 +498,24 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 		blockchain.stateDeliver.App.AddTotalSlashed(remainder)
 	}
 
+	// apply announced commissions of candidates
+	blockchain.stateDeliver.Candidates.ApplyCommissionChanges(height)
+
+	// execute stop-loss and take-profit orders after all swaps of the block
+	if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
+		blockchain.stateDeliver.SwapV2.ExecuteTriggerOrders()
+	}
+
 	// expire orders
 	if height > blockchain.expiredOrdersPeriod && height%blockchain.updateStakesAndPayRewardsPeriod == blockchain.updateStakesAndPayRewardsPeriod/2 {
 		blockchain.stateDeliver.Swapper().ExpireOrders(height - blockchain.expiredOrdersPeriod)
 	}
 
+	// observe prices of changed pools
+	if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
+		blockchain.stateDeliver.SwapV2.Observe(height)
+	}
+
 	// pay rewards
 	var moreRewards = big.NewInt(0)
 	if height%blockchain.updateStakesAndPayRewardsPeriod == 0 {
@@ -461,6 +527,9 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 				log.Println("fixEmission", blockchain.appDB.Emission())
 			}
 			PayRewards = blockchain.stateDeliver.Validators.PayRewardsV5Fix
+			if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height > h {
+				PayRewards = blockchain.stateDeliver.Validators.PayRewardsV6
+			}
 		} else if h := blockchain.appDB.GetVersionHeight(V320); h > 0 && height > h {
 			PayRewards = blockchain.stateDeliver.Validators.PayRewardsV5Bug
 		} else if h := blockchain.appDB.GetVersionHeight(V310); h > 0 && height > h {
@@ -555,6 +624,45 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 		blockchain.stateDeliver.Updates.Delete(height)
 	}
 
+	{
+		values := blockchain.isUpdateParamsBlock(height)
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := values[name]
+			if pool, ok := params.PoolName(name); ok {
+				pools, err := params.WithRewardPool(blockchain.stateDeliver.Params.RewardPools(), types.RewardPool{
+					Name:       pool,
+					Address:    value.Address,
+					Commission: uint32(value.Value),
+				})
+				if err != nil {
+					blockchain.logger.Info("reward pool is not changed", "pool", pool, "err", err)
+					continue
+				}
+				blockchain.stateDeliver.Params.SetRewardPools(pools)
+				blockchain.eventsDB.AddEvent(&eventsdb.UpdateParamEvent{
+					Name:    name,
+					Value:   strconv.FormatUint(value.Value, 10),
+					Address: value.Address.String(),
+				})
+				continue
+			}
+			blockchain.stateDeliver.Params.Set(name, value.Value)
+			blockchain.eventsDB.AddEvent(&eventsdb.UpdateParamEvent{
+				Name:  name,
+				Value: strconv.FormatUint(value.Value, 10),
+			})
+		}
+		if len(values) != 0 {
+			blockchain.applyParams()
+		}
+		blockchain.stateDeliver.Params.Delete(height)
+	}
+
 	hasChangedPublicKeys := false
 	if blockchain.stateDeliver.Candidates.IsChangedPublicKeys() {
 		blockchain.stateDeliver.Candidates.ResetIsChangedPublicKeys()
@@ -597,6 +705,9 @@ func (blockchain *Blockchain) Info(_ abciTypes.RequestInfo) (resInfo abciTypes.R
 // DeliverTx deliver a tx for full processing
 func (blockchain *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeliverTx {
 	response := blockchain.executor.RunTx(blockchain.stateDeliver, req.Tx, blockchain.rewards, blockchain.Height()+1, &sync.Map{}, 0, blockchain.cfg.ValidatorMode)
+	blockchain.pushTxEconomics(req.Tx, response)
+	blockchain.notifyTx(req.Tx, response)
+	blockchain.recentTxs.deliver(req.Tx, blockchain.Height()+1, response.Code, response.Log, response.Info)
 
 	return abciTypes.ResponseDeliverTx{
 		Code:      response.Code,
@@ -617,6 +728,7 @@ func (blockchain *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciType
 // CheckTx validates a tx for the mempool
 func (blockchain *Blockchain) CheckTx(req abciTypes.RequestCheckTx) abciTypes.ResponseCheckTx {
 	response := blockchain.executor.RunTx(blockchain.CurrentState(), req.Tx, nil, blockchain.Height()+1, blockchain.currentMempool, blockchain.MinGasPrice(), true)
+	blockchain.recentTxs.check(req.Tx, req.Type == abciTypes.CheckTxType_Recheck, blockchain.Height(), response.Code, response.Log, response.Info)
 
 	return abciTypes.ResponseCheckTx{
 		Code:      response.Code,
@@ -675,6 +787,16 @@ func (blockchain *Blockchain) Commit() abciTypes.ResponseCommit {
 		blockchain.appDB.SavePrice()
 	}
 
+	blockchain.pushCommitEconomics(height)
+
+	if blockchain.notifier != nil {
+		if err := blockchain.notifier.Commit(height); err != nil {
+			blockchain.logger.Error("failed to save notifications", "height", height, "err", err)
+		}
+	}
+
+	blockchain.recentTxs.commit()
+
 	// Clear mempool
 	blockchain.currentMempool = &sync.Map{}
 
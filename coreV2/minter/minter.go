@@ -6,14 +6,18 @@ import (
 	"log"
 	"math/big"
 	"os"
+	"strconv"
 	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	validators2 "github.com/MinterTeam/minter-go-node/coreV2/state/validators"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/coreV2/validators"
 	"github.com/syndtr/goleveldb/leveldb/filter"
@@ -127,6 +131,18 @@ func (blockchain *Blockchain) updateValidators() []abciTypes.ValidatorUpdate {
 		newValidators = append(newValidators, abciTypes.Ed25519ValidatorUpdate(newCandidate.PubKey.Bytes(), power))
 	}
 
+	if blockchain.notifier != nil {
+		newPubKeys := make(map[types.Pubkey]struct{}, len(newCandidates))
+		for _, candidate := range newCandidates {
+			newPubKeys[candidate.PubKey] = struct{}{}
+		}
+		for _, validator := range blockchain.stateDeliver.Validators.GetValidators() {
+			if _, ok := newPubKeys[validator.PubKey]; !ok {
+				blockchain.notifier.ValidatorDropped(validator.PubKey)
+			}
+		}
+	}
+
 	// update validators in state
 	blockchain.stateDeliver.Validators.SetNewValidators(newCandidates)
 
@@ -199,6 +215,11 @@ func (blockchain *Blockchain) Height() uint64 {
 	return atomic.LoadUint64(&blockchain.height)
 }
 
+// LastCommittedHeight returns height of the last committed state
+func (blockchain *Blockchain) LastCommittedHeight() uint64 {
+	return blockchain.appDB.GetLastHeight()
+}
+
 // SetTmNode sets Tendermint node
 func (blockchain *Blockchain) SetTmNode(node *tmNode.Node) {
 	blockchain.tmNode = node
@@ -275,6 +296,98 @@ func (blockchain *Blockchain) StatisticData() *statistics.Data {
 	return blockchain.statisticData
 }
 
+// SetEconomicsData used for collection chain level metrics of committed blocks
+func (blockchain *Blockchain) SetEconomicsData(economicsData *statistics.Economics) *statistics.Economics {
+	blockchain.economicsData = economicsData
+	return blockchain.economicsData
+}
+
+// EconomicsData used for collection chain level metrics of committed blocks
+func (blockchain *Blockchain) EconomicsData() *statistics.Economics {
+	return blockchain.economicsData
+}
+
+// pushTxEconomics accumulates type, result and commission of delivered tx for chain metrics
+func (blockchain *Blockchain) pushTxEconomics(rawTx []byte, response transaction.Response) {
+	if blockchain.economicsData == nil {
+		return
+	}
+
+	var txType string
+	var commissionCoin types.CoinID
+	var commission *big.Int
+	for _, tag := range response.Tags {
+		switch string(tag.Key) {
+		case "tx.type":
+			txType = "0x" + string(tag.Value)
+		case "tx.commission_coin":
+			id, err := strconv.ParseUint(string(tag.Value), 10, 32)
+			if err == nil {
+				commissionCoin = types.CoinID(id)
+			}
+		case "tx.commission_amount", "tx.fail_fee":
+			commission, _ = big.NewInt(0).SetString(string(tag.Value), 10)
+		}
+	}
+
+	if txType == "" {
+		tx, err := blockchain.executor.DecodeFromBytesWithoutSig(rawTx)
+		if err != nil {
+			return
+		}
+		txType = tx.Type.String()
+	}
+
+	blockchain.economicsData.PushTx(txType, response.Code != code.OK, commissionCoin, commission)
+}
+
+// pushCommitEconomics publishes chain metrics of committed block
+func (blockchain *Blockchain) pushCommitEconomics(height uint64) {
+	if blockchain.economicsData == nil {
+		return
+	}
+
+	vals := blockchain.stateDeliver.Validators.GetValidators()
+	validators := make([]*statistics.ValidatorInfo, 0, len(vals))
+	for _, val := range vals {
+		validators = append(validators, &statistics.ValidatorInfo{
+			PubKey:       val.PubKey,
+			TotalStake:   val.GetTotalBipStake(),
+			AccumReward:  val.GetAccumReward(),
+			MissedBlocks: val.CountAbsentTimes(),
+		})
+	}
+
+	mempoolSize := 0
+	if blockchain.tmNode != nil {
+		mempoolSize = blockchain.tmNode.Mempool().Size()
+	}
+
+	blockchain.economicsData.PushCommit(&statistics.CommitRequest{
+		Height:      height,
+		RewardPool:  big.NewInt(0).Set(blockchain.rewards),
+		Emission:    blockchain.GetEmission(),
+		TotalStake:  blockchain.stateDeliver.Candidates.TotalStakes(),
+		MempoolSize: mempoolSize,
+		Validators:  validators,
+	})
+}
+
+// notifyTx passes delivered SetHaltBlock votes to the notifier
+func (blockchain *Blockchain) notifyTx(rawTx []byte, response transaction.Response) {
+	if blockchain.notifier == nil || response.Code != code.OK {
+		return
+	}
+
+	tx, err := blockchain.executor.DecodeFromBytesWithoutSig(rawTx)
+	if err != nil || tx.Type != transaction.TypeSetHaltBlock {
+		return
+	}
+
+	data := tx.GetDecodedData().(*transaction.SetHaltBlockData)
+	blockchain.notifier.HaltBlockVote(data.PubKey, data.Height)
+}
+
 // GetValidatorStatus returns given validator's status
 func (blockchain *Blockchain) GetValidatorStatus(address types.TmAddress) int8 {
 	blockchain.lockValidators.RLock()
@@ -416,6 +529,40 @@ func (blockchain *Blockchain) isUpdateNetworkBlockV2(height uint64) (string, boo
 	return "", false
 }
 
+func (blockchain *Blockchain) isUpdateParamsBlock(height uint64) map[string]*params.Model {
+	votes := blockchain.stateDeliver.Params.GetVotes(height)
+	if len(votes) == 0 {
+		return nil
+	}
+	// calculate total power of validators for every parameter
+	maxVotingResults := map[string]*big.Float{}
+	values := map[string]*params.Model{}
+	for _, v := range votes {
+		totalVotedPower := big.NewInt(0)
+		for _, vote := range v.Votes {
+			if power, ok := blockchain.validatorsPowers[vote]; ok {
+				totalVotedPower.Add(totalVotedPower, power)
+			}
+		}
+		votingResult := new(big.Float).Quo(
+			new(big.Float).SetInt(totalVotedPower),
+			new(big.Float).SetInt(blockchain.totalPower),
+		)
+
+		if maxVotingResult, ok := maxVotingResults[v.Name]; !ok || maxVotingResult.Cmp(votingResult) == -1 {
+			maxVotingResults[v.Name] = votingResult
+			values[v.Name] = v
+		}
+	}
+	for name, maxVotingResult := range maxVotingResults {
+		if maxVotingResult.Cmp(big.NewFloat(votingPowerConsensus)) != 1 {
+			delete(values, name)
+		}
+	}
+
+	return values
+}
+
 func GetDbOpts(memLimit int) *opt.Options {
 	if memLimit < 1024 {
 		panic(fmt.Sprintf("Not enough memory given to StateDB. Expected >1024M, given %d", memLimit))
@@ -0,0 +1,156 @@
+package minter
+
+import (
+	"sync"
+
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// Statuses of transactions
+const (
+	TxStatusUnknown  = "unknown"
+	TxStatusMempool  = "mempool"
+	TxStatusIncluded = "included"
+	TxStatusFailed   = "failed"
+)
+
+// Reasons of leaving mempool without being included in a block
+const (
+	EvictionRejected        = "rejected"         // check on receiving has failed
+	EvictionRecheckFailed   = "recheck_failed"   // check after a committed block has failed
+	EvictionBroadcastFailed = "broadcast_failed" // mempool has refused the transaction, e.g. it is full
+	EvictionDropped         = "dropped"          // transaction has left mempool without a failed check, e.g. by ttl
+)
+
+// TxRecord is the last known state of a recently seen transaction
+type TxRecord struct {
+	Status string
+	Height uint64
+	Code   uint32
+	Log    string
+	Info   string
+	// Reason is set for failed transactions which have not been included in a block
+	Reason string
+}
+
+// recentTxs remembers the last records of recently seen transactions, the oldest ones are forgotten first
+type recentTxs struct {
+	lock    sync.RWMutex
+	size    int
+	records map[string]*TxRecord
+	order   []string
+	next    int
+
+	// pending contains delivered transactions of the current block
+	pending map[string]*TxRecord
+}
+
+func newRecentTxs(size int) *recentTxs {
+	if size <= 0 {
+		return nil
+	}
+	return &recentTxs{
+		size:    size,
+		records: make(map[string]*TxRecord, size),
+		order:   make([]string, 0, size),
+		pending: map[string]*TxRecord{},
+	}
+}
+
+func (r *recentTxs) set(hash string, record *TxRecord) {
+	if _, ok := r.records[hash]; !ok {
+		if len(r.order) < r.size {
+			r.order = append(r.order, hash)
+		} else {
+			delete(r.records, r.order[r.next])
+			r.order[r.next] = hash
+			r.next = (r.next + 1) % r.size
+		}
+	}
+	r.records[hash] = record
+}
+
+func (r *recentTxs) check(tx []byte, recheck bool, height uint64, code uint32, log, info string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	hash := string(tmTypes.Tx(tx).Hash())
+	if code == 0 {
+		if !recheck {
+			r.set(hash, &TxRecord{Status: TxStatusMempool, Height: height})
+		}
+		return
+	}
+
+	reason := EvictionRejected
+	if recheck {
+		reason = EvictionRecheckFailed
+	}
+	r.set(hash, &TxRecord{Status: TxStatusFailed, Height: height, Code: code, Log: log, Info: info, Reason: reason})
+}
+
+func (r *recentTxs) broadcastFailed(hash []byte, height uint64, log string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.set(string(hash), &TxRecord{Status: TxStatusFailed, Height: height, Log: log, Reason: EvictionBroadcastFailed})
+}
+
+func (r *recentTxs) deliver(tx []byte, height uint64, code uint32, log, info string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status := TxStatusIncluded
+	if code != 0 {
+		status = TxStatusFailed
+	}
+	r.pending[string(tmTypes.Tx(tx).Hash())] = &TxRecord{Status: status, Height: height, Code: code, Log: log, Info: info}
+}
+
+// commit applies delivered transactions, it is called before mempool update so included transactions
+// are never reported as dropped
+func (r *recentTxs) commit() {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for hash, record := range r.pending {
+		r.set(hash, record)
+	}
+	r.pending = map[string]*TxRecord{}
+}
+
+func (r *recentTxs) get(hash []byte) (TxRecord, bool) {
+	if r == nil {
+		return TxRecord{}, false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	record, ok := r.records[string(hash)]
+	if !ok {
+		return TxRecord{}, false
+	}
+	return *record, true
+}
+
+// RecentTx returns the last known record of recently seen transaction
+func (blockchain *Blockchain) RecentTx(hash []byte) (TxRecord, bool) {
+	return blockchain.recentTxs.get(hash)
+}
+
+// TxBroadcastFailed remembers transaction refused by mempool before CheckTx
+func (blockchain *Blockchain) TxBroadcastFailed(hash []byte, err error) {
+	blockchain.recentTxs.broadcastFailed(hash, blockchain.Height(), err.Error())
+}
@@ -0,0 +1,40 @@
+package minter
+
+import (
+	"testing"
+
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+func TestRecentTxs(t *testing.T) {
+	r := newRecentTxs(2)
+
+	included, failed, evicted := []byte{1}, []byte{2}, []byte{3}
+	r.check(included, false, 1, 0, "", "")
+	r.check(failed, false, 1, 0, "", "")
+	if record, _ := r.get(tmTypes.Tx(included).Hash()); record.Status != TxStatusMempool {
+		t.Fatalf("want status %s, got %s", TxStatusMempool, record.Status)
+	}
+
+	r.deliver(included, 2, 0, "", "")
+	r.deliver(failed, 2, 107, "insufficient funds", `{"code":"107"}`)
+	if record, _ := r.get(tmTypes.Tx(included).Hash()); record.Status != TxStatusMempool {
+		t.Fatal("delivered transaction is recorded before commit")
+	}
+	r.commit()
+	if record, _ := r.get(tmTypes.Tx(included).Hash()); record.Status != TxStatusIncluded || record.Height != 2 {
+		t.Errorf("unexpected record of included transaction %+v", record)
+	}
+	if record, _ := r.get(tmTypes.Tx(failed).Hash()); record.Status != TxStatusFailed || record.Reason != "" || record.Code != 107 {
+		t.Errorf("unexpected record of failed transaction %+v", record)
+	}
+
+	r.check(evicted, true, 2, 101, "nonce", "")
+	record, ok := r.get(tmTypes.Tx(evicted).Hash())
+	if !ok || record.Status != TxStatusFailed || record.Reason != EvictionRecheckFailed {
+		t.Errorf("unexpected record of evicted transaction %+v", record)
+	}
+	if _, ok := r.get(tmTypes.Tx(included).Hash()); ok {
+		t.Error("the oldest record is not forgotten")
+	}
+}
@@ -0,0 +1,391 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/config"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	db "github.com/tendermint/tm-db"
+)
+
+// Notification kinds
+const (
+	KindJail              = "jail"
+	KindSlash             = "slash"
+	KindValidatorDropped  = "validator_dropped"
+	KindHaltVote          = "halt_vote"
+	KindUpdateNetwork     = "update_network"
+	KindUpdateCommissions = "update_commissions"
+)
+
+// SignatureHeader contains hex encoded HMAC-SHA256 of the request body
+const SignatureHeader = "X-Minter-Signature"
+
+const (
+	minRetryInterval = time.Second
+	requestTimeout   = 10 * time.Second
+)
+
+// Notification is a JSON body of the webhook request
+type Notification struct {
+	ID     string      `json:"id"`
+	Kind   string      `json:"kind"`
+	Height uint64      `json:"height"`
+	Data   interface{} `json:"data"`
+}
+
+// SlashData is a sum of slashed stakes of a validator in one block
+type SlashData struct {
+	ValidatorPubKey types.Pubkey      `json:"validator_pub_key"`
+	Stakes          int               `json:"stakes"`
+	Amounts         map[uint64]string `json:"amounts"`
+}
+
+// ValidatorDroppedData is sent when own validator is not in the new validators set
+type ValidatorDroppedData struct {
+	ValidatorPubKey types.Pubkey `json:"validator_pub_key"`
+}
+
+// HaltVoteData is sent for every delivered SetHaltBlock transaction
+type HaltVoteData struct {
+	PubKey     types.Pubkey `json:"pub_key"`
+	HaltHeight uint64       `json:"halt_height"`
+}
+
+type outboxItem struct {
+	Body        json.RawMessage `json:"body"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+}
+
+// Notifier sends notifications of committed blocks to webhooks,
+// undelivered notifications are kept in the outbox db and survive restarts
+type Notifier struct {
+	cfg     *config.NotifierConfig
+	outbox  db.DB
+	client  *http.Client
+	logger  tmlog.Logger
+	pubKeys map[types.Pubkey]struct{}
+	kinds   map[string]struct{}
+	wake    chan struct{}
+
+	lock    sync.Mutex
+	pending []*Notification
+	slashes map[types.Pubkey]*SlashData
+}
+
+// NewNotifier returns notifier with given outbox, public keys of the config must be valid
+func NewNotifier(cfg *config.NotifierConfig, outbox db.DB, logger tmlog.Logger) (*Notifier, error) {
+	pubKeys := make(map[types.Pubkey]struct{}, len(cfg.PubKeys))
+	for _, key := range cfg.PubKeys {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(key, "Mp"))
+		if err != nil || !strings.HasPrefix(key, "Mp") || len(decoded) != len(types.Pubkey{}) {
+			return nil, fmt.Errorf("notifier: invalid public key %q", key)
+		}
+		pubKeys[types.BytesToPubkey(decoded)] = struct{}{}
+	}
+
+	kinds := make(map[string]struct{}, len(cfg.Events))
+	for _, kind := range cfg.Events {
+		kinds[kind] = struct{}{}
+	}
+
+	return &Notifier{
+		cfg:     cfg,
+		outbox:  outbox,
+		client:  &http.Client{Timeout: requestTimeout},
+		logger:  logger,
+		pubKeys: pubKeys,
+		kinds:   kinds,
+		wake:    make(chan struct{}, 1),
+		slashes: map[types.Pubkey]*SlashData{},
+	}, nil
+}
+
+func (n *Notifier) enabled(kind string) bool {
+	_, ok := n.kinds[kind]
+	return ok
+}
+
+func (n *Notifier) isOwn(pubKey types.Pubkey) bool {
+	_, ok := n.pubKeys[pubKey]
+	return ok
+}
+
+func (n *Notifier) add(kind string, data interface{}) {
+	n.pending = append(n.pending, &Notification{Kind: kind, Data: data})
+}
+
+// AddEvent selects notifications from events of the current block
+func (n *Notifier) AddEvent(event eventsdb.Event) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	switch e := event.(type) {
+	case *eventsdb.JailEvent:
+		if n.enabled(KindJail) && n.isOwn(e.ValidatorPubKey) {
+			n.add(KindJail, e)
+		}
+	case *eventsdb.SlashEvent:
+		if !n.enabled(KindSlash) || !n.isOwn(e.ValidatorPubKey) {
+			return
+		}
+		slash, ok := n.slashes[e.ValidatorPubKey]
+		if !ok {
+			slash = &SlashData{ValidatorPubKey: e.ValidatorPubKey, Amounts: map[uint64]string{}}
+			n.slashes[e.ValidatorPubKey] = slash
+			n.add(KindSlash, slash)
+		}
+		slash.Stakes++
+		amount, _ := big.NewInt(0).SetString(e.Amount, 10)
+		if amount == nil {
+			return
+		}
+		if sum, ok := big.NewInt(0).SetString(slash.Amounts[e.Coin], 10); ok {
+			amount.Add(amount, sum)
+		}
+		slash.Amounts[e.Coin] = amount.String()
+	case *eventsdb.UpdateNetworkEvent:
+		if n.enabled(KindUpdateNetwork) {
+			n.add(KindUpdateNetwork, e)
+		}
+	case *eventsdb.UpdateCommissionsEvent:
+		if n.enabled(KindUpdateCommissions) {
+			n.add(KindUpdateCommissions, e)
+		}
+	}
+}
+
+// ValidatorDropped is called for every validator removed from the set
+func (n *Notifier) ValidatorDropped(pubKey types.Pubkey) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.enabled(KindValidatorDropped) && n.isOwn(pubKey) {
+		n.add(KindValidatorDropped, &ValidatorDroppedData{ValidatorPubKey: pubKey})
+	}
+}
+
+// HaltBlockVote is called for every delivered SetHaltBlock transaction
+func (n *Notifier) HaltBlockVote(pubKey types.Pubkey, haltHeight uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.enabled(KindHaltVote) {
+		n.add(KindHaltVote, &HaltVoteData{PubKey: pubKey, HaltHeight: haltHeight})
+	}
+}
+
+// Commit moves notifications of the committed block to the outbox.
+// Keys depend on height and index only, so replay of a block does not duplicate undelivered notifications.
+func (n *Notifier) Commit(height uint64) error {
+	n.lock.Lock()
+	pending := n.pending
+	n.pending = nil
+	n.slashes = map[types.Pubkey]*SlashData{}
+	n.lock.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	batch := n.outbox.NewBatch()
+	defer batch.Close()
+	for i, notification := range pending {
+		notification.ID = fmt.Sprintf("%d-%d", height, i)
+		notification.Height = height
+
+		body, err := json.Marshal(notification)
+		if err != nil {
+			return err
+		}
+		item, err := json.Marshal(&outboxItem{Body: body})
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(outboxKey(height, i), item); err != nil {
+			return err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func outboxKey(height uint64, index int) []byte {
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint64(key, height)
+	binary.BigEndian.PutUint32(key[8:], uint32(index))
+	return key
+}
+
+// Run delivers notifications from the outbox until ctx is done
+func (n *Notifier) Run(ctx context.Context) {
+	for {
+		wait := n.deliver(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-n.wake:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// deliver tries to send due notifications and returns delay until the next due one
+func (n *Notifier) deliver(ctx context.Context) time.Duration {
+	wait := n.cfg.MaxRetryInterval
+	if wait <= 0 {
+		wait = time.Minute
+	}
+
+	type entry struct {
+		key  []byte
+		item *outboxItem
+	}
+	var entries []entry
+	iterator, err := n.outbox.Iterator(nil, nil)
+	if err != nil {
+		n.logger.Error("notifier: failed to read outbox", "err", err)
+		return wait
+	}
+	for ; iterator.Valid(); iterator.Next() {
+		item := new(outboxItem)
+		if err := json.Unmarshal(iterator.Value(), item); err != nil {
+			n.logger.Error("notifier: failed to decode outbox item", "err", err)
+			continue
+		}
+		entries = append(entries, entry{key: append([]byte(nil), iterator.Key()...), item: item})
+	}
+	_ = iterator.Close()
+
+	now := time.Now()
+	for _, e := range entries {
+		select {
+		case <-ctx.Done():
+			return wait
+		default:
+		}
+
+		if e.item.NextAttempt.After(now) {
+			if until := e.item.NextAttempt.Sub(now); until < wait {
+				wait = until
+			}
+			continue
+		}
+
+		err := n.send(ctx, e.item.Body)
+		if err == nil {
+			n.remove(e.key)
+			continue
+		}
+		n.logger.Info("notifier: delivery failed", "attempt", e.item.Attempts+1, "err", err)
+
+		e.item.Attempts++
+		if n.cfg.MaxAttempts > 0 && e.item.Attempts >= n.cfg.MaxAttempts {
+			n.logger.Error("notifier: notification dropped after max attempts", "body", string(e.item.Body))
+			n.remove(e.key)
+			continue
+		}
+
+		backoff := n.backoff(e.item.Attempts)
+		e.item.NextAttempt = now.Add(backoff)
+		if backoff < wait {
+			wait = backoff
+		}
+		value, err := json.Marshal(e.item)
+		if err != nil {
+			continue
+		}
+		if err := n.outbox.SetSync(e.key, value); err != nil {
+			n.logger.Error("notifier: failed to update outbox", "err", err)
+		}
+	}
+
+	return wait
+}
+
+func (n *Notifier) remove(key []byte) {
+	if err := n.outbox.DeleteSync(key); err != nil {
+		n.logger.Error("notifier: failed to delete from outbox", "err", err)
+	}
+}
+
+// backoff returns exponential delay for given number of failed attempts
+func (n *Notifier) backoff(attempts int) time.Duration {
+	delay := minRetryInterval
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if n.cfg.MaxRetryInterval > 0 && delay >= n.cfg.MaxRetryInterval {
+			return n.cfg.MaxRetryInterval
+		}
+	}
+	return delay
+}
+
+// send posts body to all URLs, notification is delivered when every URL has responded with 2xx
+func (n *Notifier) send(ctx context.Context, body []byte) error {
+	signature := Sign([]byte(n.cfg.Secret), body)
+	for _, url := range n.cfg.URLs {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(SignatureHeader, signature)
+
+		resp, err := n.client.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("%s responded with status %d", url, resp.StatusCode)
+		}
+	}
+	return nil
+}
+
+// Sign returns hex encoded HMAC-SHA256 of body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WrapEvents returns events db which passes added events to the notifier
+func (n *Notifier) WrapEvents(events eventsdb.IEventsDB) eventsdb.IEventsDB {
+	return &notifyEvents{IEventsDB: events, notifier: n}
+}
+
+type notifyEvents struct {
+	eventsdb.IEventsDB
+	notifier *Notifier
+}
+
+func (e *notifyEvents) AddEvent(event eventsdb.Event) {
+	e.IEventsDB.AddEvent(event)
+	e.notifier.AddEvent(event)
+}
@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/config"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/tendermint/tendermint/libs/log"
+	db "github.com/tendermint/tm-db"
+)
+
+type webhook struct {
+	sync.Mutex
+	fails         int
+	notifications []*Notification
+	signatures    []string
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.fails > 0 {
+		w.fails--
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	notification := new(Notification)
+	_ = json.Unmarshal(body, notification)
+	w.notifications = append(w.notifications, notification)
+	w.signatures = append(w.signatures, r.Header.Get(SignatureHeader))
+	if Sign([]byte("secret"), body) != r.Header.Get(SignatureHeader) {
+		rw.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func (w *webhook) received() []*Notification {
+	w.Lock()
+	defer w.Unlock()
+	return append([]*Notification(nil), w.notifications...)
+}
+
+func newTestNotifier(t *testing.T, url string, outbox db.DB) *Notifier {
+	cfg := config.DefaultNotifierConfig()
+	cfg.URLs = []string{url}
+	cfg.Secret = "secret"
+	cfg.PubKeys = []string{types.Pubkey{1}.String()}
+	cfg.MaxRetryInterval = 10 * time.Millisecond
+
+	n, err := NewNotifier(cfg, outbox, log.NewNopLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestNotifier_Commit(t *testing.T) {
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	n := newTestNotifier(t, server.URL, db.NewMemDB())
+	events := n.WrapEvents(&eventsdb.MockEvents{})
+
+	events.AddEvent(&eventsdb.JailEvent{ValidatorPubKey: types.Pubkey{1}, JailedUntil: 100})
+	events.AddEvent(&eventsdb.JailEvent{ValidatorPubKey: types.Pubkey{2}, JailedUntil: 100})
+	events.AddEvent(&eventsdb.SlashEvent{ValidatorPubKey: types.Pubkey{1}, Coin: 0, Amount: "10"})
+	events.AddEvent(&eventsdb.SlashEvent{ValidatorPubKey: types.Pubkey{1}, Coin: 0, Amount: "5"})
+	events.AddEvent(&eventsdb.RewardEvent{ValidatorPubKey: types.Pubkey{1}, Amount: "1"})
+	n.ValidatorDropped(types.Pubkey{2})
+	n.ValidatorDropped(types.Pubkey{1})
+	n.HaltBlockVote(types.Pubkey{3}, 200)
+
+	if len(hook.received()) != 0 {
+		t.Fatal("notifications are sent before commit")
+	}
+	if err := n.Commit(10); err != nil {
+		t.Fatal(err)
+	}
+	n.deliver(context.Background())
+
+	received := hook.received()
+	if len(received) != 4 {
+		t.Fatalf("want 4 notifications, got %d", len(received))
+	}
+	kinds := []string{KindJail, KindSlash, KindValidatorDropped, KindHaltVote}
+	for i, notification := range received {
+		if notification.Kind != kinds[i] {
+			t.Errorf("notification %d: want kind %s, got %s", i, kinds[i], notification.Kind)
+		}
+		if notification.Height != 10 {
+			t.Errorf("notification %d: want height 10, got %d", i, notification.Height)
+		}
+	}
+	slash := received[1].Data.(map[string]interface{})
+	if slash["stakes"].(float64) != 2 || slash["amounts"].(map[string]interface{})["0"] != "15" {
+		t.Errorf("unexpected slash data %v", slash)
+	}
+
+	iterator, _ := n.outbox.Iterator(nil, nil)
+	defer iterator.Close()
+	if iterator.Valid() {
+		t.Error("delivered notifications are left in outbox")
+	}
+}
+
+func TestNotifier_Retry(t *testing.T) {
+	hook := &webhook{fails: 2}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	outbox := db.NewMemDB()
+	n := newTestNotifier(t, server.URL, outbox)
+	n.AddEvent(&eventsdb.UpdateNetworkEvent{Version: "v340"})
+	if err := n.Commit(5); err != nil {
+		t.Fatal(err)
+	}
+
+	n.deliver(context.Background())
+	if len(hook.received()) != 0 {
+		t.Fatal("notification is delivered on failed request")
+	}
+
+	// restart with the same outbox
+	n = newTestNotifier(t, server.URL, outbox)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go n.Run(ctx)
+
+	deadline := time.After(5 * time.Second)
+	for len(hook.received()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("notification is not delivered after retries")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	received := hook.received()
+	if received[0].Kind != KindUpdateNetwork || received[0].ID != "5-0" {
+		t.Errorf("unexpected notification %+v", received[0])
+	}
+}
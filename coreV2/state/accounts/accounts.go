@@ -71,6 +71,35 @@ func (a *Accounts) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 	a.db.Store(immutableTree)
 }
 
+// Snapshot returns the function which reverts all changes of accounts made after the call
+func (a *Accounts) Snapshot() (revert func()) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	list := make(map[types.Address]*Model, len(a.list))
+	reverts := make([]func(), 0, len(a.list))
+	for address, account := range a.list {
+		list[address] = account
+		reverts = append(reverts, account.snapshot())
+	}
+	dirty := make(map[types.Address]struct{}, len(a.dirty))
+	for address := range a.dirty {
+		dirty[address] = struct{}{}
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		a.lock.Lock()
+		defer a.lock.Unlock()
+
+		a.list = list
+		a.dirty = dirty
+	}
+}
+
 func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 	accounts := a.getOrderedDirtyAccounts()
 	for _, address := range accounts {
@@ -66,6 +66,36 @@ func (m *Multisig) GetWeight(address types.Address) uint32 {
 	return 0
 }
 
+func (model *Model) snapshot() (revert func()) {
+	model.lock.RLock()
+	defer model.lock.RUnlock()
+
+	nonce, lockStakeUntilBlock := model.Nonce, model.LockStakeUntilBlock
+	threshold, weights, addresses := model.MultisigData.Threshold, model.MultisigData.Weights, model.MultisigData.Addresses
+	coins := append([]types.CoinID(nil), model.coins...)
+	balances := make(map[types.CoinID]*big.Int, len(model.balances))
+	for coin, balance := range model.balances {
+		balances[coin] = big.NewInt(0).Set(balance)
+	}
+	dirtyBalances := make(map[types.CoinID]struct{}, len(model.dirtyBalances))
+	for coin := range model.dirtyBalances {
+		dirtyBalances[coin] = struct{}{}
+	}
+	hasDirtyCoins, isDirty, isNew := model.hasDirtyCoins, model.isDirty, model.isNew
+
+	return func() {
+		model.lock.Lock()
+		defer model.lock.Unlock()
+
+		model.Nonce, model.LockStakeUntilBlock = nonce, lockStakeUntilBlock
+		model.MultisigData = Multisig{Threshold: threshold, Weights: weights, Addresses: addresses}
+		model.coins = coins
+		model.balances = balances
+		model.dirtyBalances = dirtyBalances
+		model.hasDirtyCoins, model.isDirty, model.isNew = hasDirtyCoins, isDirty, isNew
+	}
+}
+
 func (model *Model) setNonce(nonce uint64) {
 	model.lock.Lock()
 	defer model.lock.Unlock()
@@ -78,6 +78,37 @@ func (a *App) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// Snapshot returns the function which reverts all changes of app made after the call
+func (a *App) Snapshot() (revert func()) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	model, isDirty := a.model, a.isDirty
+	if model == nil {
+		return func() {
+			a.mx.Lock()
+			defer a.mx.Unlock()
+
+			a.model, a.isDirty = nil, isDirty
+		}
+	}
+
+	model.mx.RLock()
+	totalSlashed, coinsCount, maxGas, reward, rewardSafe := model.TotalSlashed, model.CoinsCount, model.MaxGas, model.Reward0, model.RewardSafe
+	model.mx.RUnlock()
+
+	return func() {
+		model.mx.Lock()
+		model.TotalSlashed, model.CoinsCount, model.MaxGas, model.Reward0, model.RewardSafe = totalSlashed, coinsCount, maxGas, reward, rewardSafe
+		model.mx.Unlock()
+
+		a.mx.Lock()
+		defer a.mx.Unlock()
+
+		a.model, a.isDirty = model, isDirty
+	}
+}
+
 func (a *App) GetMaxGas() uint64 {
 	model := a.getOrNew()
 
@@ -13,6 +13,8 @@ type Bus struct {
 	events      eventsdb.IEventsDB
 	checker     Checker
 	validators  Validators
+	params      Params
+	slashes     Slashes
 }
 
 func NewBus() *Bus {
@@ -98,3 +100,19 @@ func (b *Bus) SetChecker(checker Checker) {
 func (b *Bus) Checker() Checker {
 	return b.checker
 }
+
+func (b *Bus) SetParams(params Params) {
+	b.params = params
+}
+
+func (b *Bus) Params() Params {
+	return b.params
+}
+
+func (b *Bus) SetSlashes(slashes Slashes) {
+	b.slashes = slashes
+}
+
+func (b *Bus) Slashes() Slashes {
+	return b.slashes
+}
@@ -8,11 +8,14 @@ import (
 type Candidates interface {
 	GetStakes(types.Pubkey) []*Stake
 	Punish(uint64, types.TmAddress)
+	PunishDowntime(uint64, types.TmAddress)
 	ID(types.Pubkey) uint32
 	SetOffline(types.Pubkey)
 	GetCandidate(types.Pubkey) *Candidate
 	GetCandidateByTendermintAddress(types.TmAddress) *Candidate
 	TotalStakes() *big.Int
+	IsAutoCompound(types.Pubkey, types.Address) bool
+	IsDelegatorStakeAllowed(types.Address, types.Pubkey, types.CoinID, *big.Int) (low, big bool)
 }
 
 type Stake struct {
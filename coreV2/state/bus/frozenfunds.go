@@ -15,4 +15,5 @@ type FrozenFund struct {
 
 type FrozenFunds interface {
 	AddFrozenFund(uint64, types.Address, *types.Pubkey, uint32, types.CoinID, *big.Int)
+	AddFrozenMove(uint64, types.Address, *types.Pubkey, uint32, types.CoinID, *big.Int, uint32)
 }
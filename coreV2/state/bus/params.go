@@ -0,0 +1,13 @@
+package bus
+
+import "github.com/MinterTeam/minter-go-node/coreV2/types"
+
+type Params interface {
+	UnbondPeriod() uint64
+	JailPeriod() uint64
+	ValidatorMaxAbsentWindow() uint64
+	DowntimeEpoch() uint64
+	MinSelfBondRatio() uint64
+	AutoRoutePeriod() uint64
+	RewardPools() []types.RewardPool
+}
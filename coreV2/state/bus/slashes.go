@@ -0,0 +1,13 @@
+package bus
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+type Slashes interface {
+	AddDoubleSignSlash(height uint64, candidateID uint32, address types.Address, coin types.CoinID, amount *big.Int)
+	AddDowntime(height uint64, candidateID uint32)
+	AddDowntimeSlash(height uint64, candidateID uint32, address types.Address, coin types.CoinID, amount *big.Int)
+}
@@ -14,5 +14,6 @@ type WaitListItem struct {
 type WaitList interface {
 	AddToWaitList(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int)
 	Delete(address types.Address, pubkey types.Pubkey, coin types.CoinID)
+	AutoRoute(address types.Address) (candidateID uint32, enabled bool)
 	GetByAddressAndPubKey(address types.Address, pubkey types.Pubkey) []*WaitListItem
 }
@@ -36,6 +36,11 @@ func (b *Bus) Punish(height uint64, address types.TmAddress) {
 	b.candidates.Punish(height, address)
 }
 
+// PunishDowntime punishes a candidate with given tendermint-address for the downtime offence
+func (b *Bus) PunishDowntime(height uint64, address types.TmAddress) {
+	b.candidates.PunishDowntime(height, address)
+}
+
 // ID returns id by a public key
 func (b *Bus) ID(pubkey types.Pubkey) uint32 {
 	return b.candidates.ID(pubkey)
@@ -77,6 +82,16 @@ func (b *Bus) TotalStakes() *big.Int {
 	return b.candidates.TotalStakes()
 }
 
+// IsAutoCompound returns true if rewards of the delegator from the candidate are added to the stake
+func (b *Bus) IsAutoCompound(pubkey types.Pubkey, address types.Address) bool {
+	return b.candidates.IsAutoCompound(pubkey, address)
+}
+
+// IsDelegatorStakeAllowed determines if given stake is sufficient to add it to a candidate
+func (b *Bus) IsDelegatorStakeAllowed(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) (bool, bool) {
+	return b.candidates.IsDelegatorStakeAllowed(address, pubkey, coin, amount)
+}
+
 // GetCandidateByTendermintAddress finds and returns candidate with given tendermint-address
 func (b *Bus) GetCandidateByTendermintAddress(tmAddress types.TmAddress) *bus.Candidate {
 	candidate := b.candidates.GetCandidateByTendermintAddress(tmAddress)
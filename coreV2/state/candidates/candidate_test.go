@@ -8,6 +8,9 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/frozenfunds"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params/paramstest"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/slashes"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
@@ -498,6 +501,64 @@ func TestCandidates_GetTotalStake_fromModelAndFromDB(t *testing.T) {
 	}
 }
 
+func TestCandidates_RecalculateStakes_autoRoute(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	wl := waitlist.NewWaitList(b, mutableTree.GetLastImmutable())
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetChecker(checker.NewChecker(b))
+	ff := frozenfunds.NewFrozenFunds(b, mutableTree.GetLastImmutable())
+	b.SetParams(paramstest.Params{AutoRoute: 100})
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{5}, 10, 0, 0)
+
+	var stakes []types.Stake
+	for i := 0; i < 1003; i++ {
+		value := strconv.Itoa(i + 2000)
+		stakes = append(stakes, types.Stake{
+			Owner:    types.StringToAddress(strconv.Itoa(i)),
+			Coin:     0,
+			Value:    value,
+			BipValue: value,
+		})
+	}
+	candidates.SetStakes([32]byte{4}, stakes, nil)
+
+	wl.SetRoute(types.StringToAddress("0"), candidates.ID([32]byte{5}))
+	wl.SetRoute(types.StringToAddress("1"), 0)
+
+	candidates.RecalculateStakes(10)
+
+	if wl.Get(types.StringToAddress("0"), [32]byte{4}, 0) != nil || wl.Get(types.StringToAddress("1"), [32]byte{4}, 0) != nil {
+		t.Fatal("routed stake is kicked to waitlist")
+	}
+	if item := wl.Get(types.StringToAddress("2"), [32]byte{4}, 0); item == nil || item.Value.String() != "2002" {
+		t.Fatal("stake without auto-route is not kicked to waitlist")
+	}
+
+	funds := ff.GetFrozenFunds(110)
+	if funds == nil || len(funds.List) != 2 {
+		t.Fatal("routed stakes are not frozen for the auto-route period")
+	}
+	for _, fund := range funds.List {
+		switch fund.Address {
+		case types.StringToAddress("0"):
+			if fund.Value.String() != "2000" || fund.GetMoveToCandidateID() != candidates.ID([32]byte{5}) {
+				t.Fatalf("invalid frozen move %s to %d", fund.Value, fund.GetMoveToCandidateID())
+			}
+		case types.StringToAddress("1"):
+			if fund.Value.String() != "2001" || fund.GetMoveToCandidateID() != 0 {
+				t.Fatalf("invalid frozen unbond %s to %d", fund.Value, fund.GetMoveToCandidateID())
+			}
+		default:
+			t.Fatalf("unexpected frozen fund of %s", fund.Address.String())
+		}
+	}
+}
+
 func TestCandidates_Export(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
@@ -696,6 +757,122 @@ func TestCandidates_Punish(t *testing.T) {
 	}
 }
 
+func TestCandidates_PunishDowntime(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetApp(app.NewApp(b, mutableTree.GetLastImmutable()))
+	b.SetChecker(checker.NewChecker(b))
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.SetStakes([32]byte{4}, []types.Stake{
+		{
+			Owner:    [20]byte{1},
+			Coin:     0,
+			Value:    "10000",
+			BipValue: "10000",
+		},
+	}, nil)
+	candidates.RecalculateStakes(1)
+
+	candidate := candidates.GetCandidate([32]byte{4})
+	epoch := types.GetDowntimeEpoch()
+
+	candidates.PunishDowntime(10, candidate.GetTmAddress())
+	if candidate.JailedUntil != 10+types.GetJailPeriod() {
+		t.Fatalf("candidate.JailedUntil == %d", candidate.JailedUntil)
+	}
+	if value := candidates.GetStakeValueOfAddress([32]byte{4}, [20]byte{1}, 0); value.String() != "10000" {
+		t.Fatalf("first offence slashed stake to %s", value)
+	}
+
+	candidates.PunishDowntime(20, candidate.GetTmAddress())
+	if candidate.JailedUntil != 20+2*types.GetJailPeriod() {
+		t.Fatalf("candidate.JailedUntil == %d", candidate.JailedUntil)
+	}
+	if value := candidates.GetStakeValueOfAddress([32]byte{4}, [20]byte{1}, 0); value.String() != "9900" {
+		t.Fatalf("second offence slashed stake to %s", value)
+	}
+
+	if level := candidates.DowntimeLevel([32]byte{4}, 20+epoch-1); level != 3 {
+		t.Fatalf("level of the next offence is %d", level)
+	}
+	if level := candidates.DowntimeLevel([32]byte{4}, 20+epoch); level != 1 {
+		t.Fatalf("level of the offence after the clean epoch is %d", level)
+	}
+
+	candidates.PunishDowntime(20+epoch, candidate.GetTmAddress())
+	if infractions := candidates.GetInfractions([32]byte{4}); len(infractions) != 1 || infractions[0].Level != 1 {
+		t.Fatalf("offences are not forgotten: %d", len(infractions))
+	}
+}
+
+func TestCandidates_ApplyCommissionChanges(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetChecker(checker.NewChecker(b))
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.ScheduleCommission([32]byte{4}, 12, 4, 90)
+
+	_, _, err := mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.ScheduleCommission([32]byte{4}, 15, 5, 100)
+
+	_, _, err = mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates = NewCandidates(b, mutableTree.GetLastImmutable())
+	candidates.LoadCandidates()
+
+	candidates.ApplyCommissionChanges(90)
+	if candidate := candidates.GetCandidate([32]byte{4}); candidate.Commission != 10 {
+		t.Fatalf("replaced commission change is applied: %d", candidate.Commission)
+	}
+
+	change := candidates.GetCommissionChange([32]byte{4})
+	if change == nil || change.Commission != 15 || change.AnnouncedHeight != 5 || change.Height != 100 {
+		t.Fatalf("pending change is not loaded: %+v", change)
+	}
+
+	candidates.ApplyCommissionChanges(99)
+	if candidate := candidates.GetCandidate([32]byte{4}); candidate.Commission != 10 {
+		t.Fatalf("commission is applied before notice period: %d", candidate.Commission)
+	}
+
+	candidates.ApplyCommissionChanges(100)
+	candidate := candidates.GetCandidate([32]byte{4})
+	if candidate.Commission != 15 || candidate.LastEditCommissionHeight != 5 {
+		t.Fatalf("commission is not applied: %d, %d", candidate.Commission, candidate.LastEditCommissionHeight)
+	}
+	if candidates.GetCommissionChange([32]byte{4}) != nil {
+		t.Fatal("pending change is not removed")
+	}
+
+	_, _, err = mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mutableTree.GetLastImmutable().IterateRange([]byte{commissionChangePrefix}, []byte{commissionChangePrefix + 1}, true, func(key []byte, value []byte) bool {
+		t.Fatalf("applied commission change is not deleted: %x", key)
+		return true
+	})
+	if _, enc := mutableTree.GetLastImmutable().Get(commissionHeightPath(1)); enc != nil {
+		t.Fatal("height of applied commission change is not deleted")
+	}
+}
+
 type fr struct {
 	unbounds []*big.Int
 }
@@ -703,6 +880,8 @@ type fr struct {
 func (fr *fr) AddFrozenFund(_ uint64, _ types.Address, _ *types.Pubkey, _ uint32, _ types.CoinID, value *big.Int) {
 	fr.unbounds = append(fr.unbounds, value)
 }
+func (fr *fr) AddFrozenMove(_ uint64, _ types.Address, _ *types.Pubkey, _ uint32, _ types.CoinID, _ *big.Int, _ uint32) {
+}
 func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
@@ -786,6 +965,61 @@ func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 	}
 }
 
+func TestCandidates_PunishByzantineCandidate_slashesLedger(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetFrozenFunds(&fr{})
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetApp(app.NewApp(b, mutableTree.GetLastImmutable()))
+	b.SetChecker(checker.NewChecker(b))
+	slashesState := slashes.NewSlashes(b, mutableTree.GetLastImmutable())
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	tests := []struct {
+		pubkey   types.Pubkey
+		owner    types.Address
+		punish   func(height uint64, tmAddress types.TmAddress)
+		recorded bool
+	}{
+		{pubkey: [32]byte{4}, owner: [20]byte{5}, punish: candidates.PunishByzantineCandidate, recorded: false},
+		{pubkey: [32]byte{6}, owner: [20]byte{7}, punish: candidates.PunishByzantineCandidateV2, recorded: true},
+	}
+
+	for _, test := range tests {
+		candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, test.pubkey, 10, 0, 0)
+		candidates.SetStakes(test.pubkey, []types.Stake{
+			{
+				Owner:    test.owner,
+				Coin:     0,
+				Value:    "100",
+				BipValue: "100",
+			},
+		}, nil)
+	}
+	candidates.RecalculateStakes(1)
+
+	for _, test := range tests {
+		test.punish(10, candidates.GetCandidate(test.pubkey).GetTmAddress())
+
+		ledger := slashesState.GetLedger(test.owner)
+		if !test.recorded {
+			if ledger != nil {
+				t.Errorf("slash of %s is recorded before v340", test.pubkey.String())
+			}
+			continue
+		}
+
+		if ledger == nil || len(ledger.Records) != 1 {
+			t.Fatalf("slash of %s is not recorded", test.pubkey.String())
+		}
+		record := ledger.Records[0]
+		if record.Height != 10 || record.Infraction != slashes.InfractionDoubleSign || record.CandidateID != candidates.ID(test.pubkey) || record.Amount.String() != "5" {
+			t.Errorf("invalid slash record %+v", record)
+		}
+	}
+}
+
 func TestCandidates_SubStake(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
@@ -970,6 +1204,64 @@ func TestCandidates_GetNewCandidates(t *testing.T) {
 	}
 }
 
+func TestCandidates_GetNewCandidates_minSelfBondRatio(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	b.SetParams(paramstest.Params{MinSelfBond: 1000})
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
+	candidates.SetStakes([32]byte{4}, []types.Stake{
+		{
+			Owner:    [20]byte{1},
+			Coin:     0,
+			Value:    "100000000000000000000",
+			BipValue: "100000000000000000000",
+		},
+		{
+			Owner:    [20]byte{6},
+			Coin:     0,
+			Value:    "900000000000000000000",
+			BipValue: "900000000000000000000",
+		},
+	}, nil)
+	candidates.SetOnline([32]byte{4})
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{5}, 10, 0, 0)
+	candidates.SetStakes([32]byte{5}, []types.Stake{
+		{
+			Owner:    [20]byte{1},
+			Coin:     0,
+			Value:    "99000000000000000000",
+			BipValue: "99000000000000000000",
+		},
+		{
+			Owner:    [20]byte{6},
+			Coin:     0,
+			Value:    "901000000000000000000",
+			BipValue: "901000000000000000000",
+		},
+	}, nil)
+	candidates.SetOnline([32]byte{5})
+
+	candidates.RecalculateStakes(1)
+
+	if ratio := candidates.SelfBondRatio([32]byte{5}); ratio != 990 {
+		t.Fatalf("self bond ratio is %d", ratio)
+	}
+	if selfStake := candidates.GetCandidate([32]byte{5}).getSelfBipStake(); selfStake == nil || selfStake.String() != "99000000000000000000" {
+		t.Fatalf("self stake is not totaled by recalculation: %s", selfStake)
+	}
+
+	newCandidates := candidates.GetNewCandidates(2)
+	if len(newCandidates) != 1 || newCandidates[0].PubKey != [32]byte{4} {
+		t.Fatal("candidate with low self bond is not skipped")
+	}
+}
+
 func TestCandidate_GetFilteredUpdates(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
@@ -28,6 +28,8 @@ const (
 	CandidateStatusOnline  = 0x02
 
 	MaxDelegatorsPerCandidate = 1000
+
+	MaxDowntimeLevel = 4
 )
 
 const (
@@ -36,13 +38,20 @@ const (
 	blockListPrefix        = mainPrefix + 'b'
 	maxIDPrefix            = mainPrefix + 'i'
 	deleteCandidatesPrefix = mainPrefix + 'd'
+	commissionChangePrefix = mainPrefix + 'e'
+	commissionHeightPrefix = 'e'
 	stakesPrefix           = 's'
 	totalStakePrefix       = 't'
 	updatesPrefix          = 'u'
+	autoCompoundPrefix     = 'a'
+	infractionsPrefix      = 'o'
 )
 
 var (
 	minValidatorBipStake = helpers.BipToPip(big.NewInt(1000))
+
+	// downtimeSlashPercents are percents of stakes slashed for the downtime offence of the level
+	downtimeSlashPercents = [MaxDowntimeLevel + 1]int64{0, 0, 1, 3, 5}
 )
 
 // RCandidates interface represents Candidates state
@@ -56,6 +65,7 @@ type RCandidates interface {
 	PubKey(id uint32) types.Pubkey
 	Count() int
 	IsNewCandidateStakeSufficient(coin types.CoinID, stake *big.Int, limit int) bool
+	CalculateBipValue(coin types.CoinID, amount *big.Int) *big.Int
 	IsDelegatorStakeSufficient(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) bool
 	IsDelegatorStakeAllowed(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) (low, big bool)
 	GetStakeValueOfAddress(pubkey types.Pubkey, address types.Address, coin types.CoinID) *big.Int
@@ -69,6 +79,13 @@ type RCandidates interface {
 	GetCandidates() []*Candidate
 	GetStakes(pubkey types.Pubkey) []*stake
 	IsCandidateJailed(pubkey types.Pubkey, block uint64) bool
+	IsAutoCompound(pubkey types.Pubkey, address types.Address) bool
+	GetInfractions(pubkey types.Pubkey) []*Infraction
+	DowntimeLevel(pubkey types.Pubkey, height uint64) uint32
+	GetSelfBipStake(pubkey types.Pubkey) *big.Int
+	GetCommissionChange(pubkey types.Pubkey) *CommissionChange
+	SelfBondRatio(pubkey types.Pubkey) uint64
+	MinSelfBondRatio() uint64
 }
 
 // Candidates struct is a store of Candidates state
@@ -91,6 +108,12 @@ type Candidates struct {
 	deletedCandidates      map[types.Pubkey]*deletedID
 	dirtyDeletedCandidates bool
 	muDeletedCandidates    sync.RWMutex
+
+	commissionChanges      map[uint64]map[uint32]*CommissionChange
+	dirtyCommissionChanges map[uint64]struct{}
+	commissionHeights      map[uint32]uint64
+	dirtyCommissionHeights map[uint32]struct{}
+	muCommissionChanges    sync.Mutex
 }
 
 type deletedID struct {
@@ -117,6 +140,11 @@ func NewCandidates(bus *bus.Bus, db *iavl.ImmutableTree) *Candidates {
 		pubKeyIDs:         map[types.Pubkey]uint32{},
 		list:              map[uint32]*Candidate{},
 		totalStakes:       big.NewInt(0),
+
+		commissionChanges:      map[uint64]map[uint32]*CommissionChange{},
+		dirtyCommissionChanges: map[uint64]struct{}{},
+		commissionHeights:      map[uint32]uint64{},
+		dirtyCommissionHeights: map[uint32]struct{}{},
 	}
 	candidates.bus.SetCandidates(NewBus(candidates))
 
@@ -176,6 +204,79 @@ func (c *Candidates) IsCandidateJailed(pubkey types.Pubkey, block uint64) bool {
 }
 
 // Commit writes changes to iavl, may return an error
+// Snapshot returns the function which reverts all changes of candidates, their stakes and commission changes made after the call
+func (c *Candidates) Snapshot() (revert func()) {
+	c.lock.RLock()
+	reverts := make([]func(), 0, len(c.list))
+	list := make(map[uint32]*Candidate, len(c.list))
+	for id, candidate := range c.list {
+		list[id] = candidate
+		reverts = append(reverts, candidate.snapshot())
+	}
+	blockList := make(map[types.Pubkey]struct{}, len(c.blockList))
+	for pubkey := range c.blockList {
+		blockList[pubkey] = struct{}{}
+	}
+	pubKeyIDs := make(map[types.Pubkey]uint32, len(c.pubKeyIDs))
+	for pubkey, id := range c.pubKeyIDs {
+		pubKeyIDs[pubkey] = id
+	}
+	isDirty, maxID, loaded, isChangedPublicKeys := c.isDirty, c.maxID, c.loaded, c.isChangedPublicKeys
+	totalStakes := big.NewInt(0).Set(c.totalStakes)
+	c.lock.RUnlock()
+
+	c.muDeletedCandidates.RLock()
+	deletedCandidates := make(map[types.Pubkey]*deletedID, len(c.deletedCandidates))
+	for pubkey, deleted := range c.deletedCandidates {
+		deletedCandidates[pubkey] = deleted
+	}
+	dirtyDeletedCandidates := c.dirtyDeletedCandidates
+	c.muDeletedCandidates.RUnlock()
+
+	c.muCommissionChanges.Lock()
+	commissionChanges := make(map[uint64]map[uint32]*CommissionChange, len(c.commissionChanges))
+	for height, changes := range c.commissionChanges {
+		commissionChanges[height] = make(map[uint32]*CommissionChange, len(changes))
+		for id, change := range changes {
+			commissionChanges[height][id] = change
+		}
+	}
+	dirtyCommissionChanges := make(map[uint64]struct{}, len(c.dirtyCommissionChanges))
+	for height := range c.dirtyCommissionChanges {
+		dirtyCommissionChanges[height] = struct{}{}
+	}
+	commissionHeights := make(map[uint32]uint64, len(c.commissionHeights))
+	for id, height := range c.commissionHeights {
+		commissionHeights[id] = height
+	}
+	dirtyCommissionHeights := make(map[uint32]struct{}, len(c.dirtyCommissionHeights))
+	for id := range c.dirtyCommissionHeights {
+		dirtyCommissionHeights[id] = struct{}{}
+	}
+	c.muCommissionChanges.Unlock()
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		c.lock.Lock()
+		c.list, c.blockList, c.pubKeyIDs = list, blockList, pubKeyIDs
+		c.isDirty, c.maxID, c.loaded, c.isChangedPublicKeys = isDirty, maxID, loaded, isChangedPublicKeys
+		c.totalStakes = totalStakes
+		c.lock.Unlock()
+
+		c.muDeletedCandidates.Lock()
+		c.deletedCandidates, c.dirtyDeletedCandidates = deletedCandidates, dirtyDeletedCandidates
+		c.muDeletedCandidates.Unlock()
+
+		c.muCommissionChanges.Lock()
+		c.commissionChanges, c.dirtyCommissionChanges = commissionChanges, dirtyCommissionChanges
+		c.commissionHeights, c.dirtyCommissionHeights = commissionHeights, dirtyCommissionHeights
+		c.muCommissionChanges.Unlock()
+	}
+}
+
 func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 	keys := c.getOrderedCandidates()
 
@@ -266,7 +367,7 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			if id.isDirty {
 				id.isDirty = false
 				db.IterateRange(append([]byte{mainPrefix}, idBytes(id.ID)...), append([]byte{mainPrefix}, idBytes(id.ID+1)...), true, func(key []byte, value []byte) bool {
-					if len(key) <= 5 || !(key[5] == stakesPrefix || key[5] == updatesPrefix || key[5] == totalStakePrefix) {
+					if len(key) <= 5 || !(key[5] == stakesPrefix || key[5] == updatesPrefix || key[5] == totalStakePrefix || key[5] == autoCompoundPrefix || key[5] == infractionsPrefix || key[5] == commissionHeightPrefix) {
 						return false
 					}
 
@@ -366,9 +467,57 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			path = append(path, updatesPrefix)
 			db.Set(path, data)
 		}
+
+		candidate.lock.RLock()
+		autoCompoundDirty := candidate.isAutoCompoundDirty
+		candidate.lock.RUnlock()
+
+		if autoCompoundDirty {
+			candidate.lock.Lock()
+			candidate.isAutoCompoundDirty = false
+			noAutoCompound := candidate.noAutoCompound
+			data, err := rlp.EncodeToBytes(noAutoCompound)
+			candidate.lock.Unlock()
+			if err != nil {
+				return fmt.Errorf("can't encode candidates auto-compound settings: %v", err)
+			}
+
+			path := []byte{mainPrefix}
+			path = append(path, candidate.idBytes()...)
+			path = append(path, autoCompoundPrefix)
+			if len(noAutoCompound) == 0 {
+				db.Remove(path)
+			} else {
+				db.Set(path, data)
+			}
+		}
+
+		candidate.lock.RLock()
+		infractionsDirty := candidate.isInfractionsDirty
+		candidate.lock.RUnlock()
+
+		if infractionsDirty {
+			candidate.lock.Lock()
+			candidate.isInfractionsDirty = false
+			infractions := candidate.infractions
+			data, err := rlp.EncodeToBytes(infractions)
+			candidate.lock.Unlock()
+			if err != nil {
+				return fmt.Errorf("can't encode candidates infractions: %v", err)
+			}
+
+			path := []byte{mainPrefix}
+			path = append(path, candidate.idBytes()...)
+			path = append(path, infractionsPrefix)
+			if len(infractions) == 0 {
+				db.Remove(path)
+			} else {
+				db.Set(path, data)
+			}
+		}
 	}
 
-	return nil
+	return c.commitCommissionChanges(db)
 }
 
 // GetNewCandidates returns list of candidates that can be the new validators
@@ -387,6 +536,17 @@ func (c *Candidates) GetNewCandidates(valCount int) []*Candidate {
 			continue
 		}
 
+		if minRatio := c.minSelfBondRatio(); minRatio != 0 {
+			if ratio := c.selfBondRatio(candidate, candidate.GetTotalBipStake()); ratio < minRatio {
+				c.bus.Events().AddEvent(&eventsdb.LowSelfBondEvent{
+					CandidatePubKey:  candidate.PubKey,
+					SelfBondRatio:    ratio,
+					MinSelfBondRatio: minRatio,
+				})
+				continue
+			}
+		}
+
 		result = append(result, candidate)
 	}
 
@@ -397,6 +557,65 @@ func (c *Candidates) GetNewCandidates(valCount int) []*Candidate {
 	return result
 }
 
+// GetSelfBipStake returns bip value of stakes of the owner of the candidate
+func (c *Candidates) GetSelfBipStake(pubkey types.Pubkey) *big.Int {
+	selfStake := big.NewInt(0)
+
+	candidate := c.GetCandidate(pubkey)
+	if candidate == nil {
+		return selfStake
+	}
+
+	for _, stake := range c.GetStakes(pubkey) {
+		if stake.Owner == candidate.OwnerAddress {
+			selfStake.Add(selfStake, stake.BipValue)
+		}
+	}
+
+	return selfStake
+}
+
+// SelfBondRatio returns the share of stakes of the owner in the total stake of the candidate in basis points
+func (c *Candidates) SelfBondRatio(pubkey types.Pubkey) uint64 {
+	if c.GetCandidate(pubkey) == nil {
+		return 0
+	}
+
+	return ratioOfStake(c.GetSelfBipStake(pubkey), c.GetTotalStake(pubkey))
+}
+
+// selfBondRatio returns the self-bond ratio of the candidate by the self stake totaled with the total stake in RecalculateStakes,
+// stakes are walked only if they are not recalculated since the candidate was loaded
+func (c *Candidates) selfBondRatio(candidate *Candidate, total *big.Int) uint64 {
+	selfStake := candidate.getSelfBipStake()
+	if selfStake == nil {
+		selfStake = c.GetSelfBipStake(candidate.PubKey)
+	}
+
+	return ratioOfStake(selfStake, total)
+}
+
+func ratioOfStake(stake, total *big.Int) uint64 {
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	ratio := big.NewInt(0).Mul(stake, big.NewInt(10000))
+	return ratio.Div(ratio, total).Uint64()
+}
+
+// MinSelfBondRatio returns the minimal share of stakes of the owner in basis points required to be a validator, 0 if there is no requirement
+func (c *Candidates) MinSelfBondRatio() uint64 {
+	return c.minSelfBondRatio()
+}
+
+func (c *Candidates) minSelfBondRatio() uint64 {
+	if c.bus.Params() == nil {
+		return 0
+	}
+	return c.bus.Params().MinSelfBondRatio()
+}
+
 // Create creates a new candidate with given params and adds it to state
 func (c *Candidates) Create(ownerAddress, rewardAddress, controlAddress types.Address, pubkey types.Pubkey, commission uint32, block uint64, jailedUntil uint64) {
 	candidate := &Candidate{
@@ -430,6 +649,15 @@ func (c *Candidates) CreateWithID(ownerAddress, rewardAddress, controlAddress ty
 // 1. Subs 5% of each stake of a candidate
 // 2. Unbond each stake of a candidate
 func (c *Candidates) PunishByzantineCandidate(height uint64, tmAddress types.TmAddress) {
+	c.punishByzantineCandidate(height, tmAddress, false)
+}
+
+// PunishByzantineCandidateV2 punishes the candidate as PunishByzantineCandidate and records slashed stakes to the slashes ledger
+func (c *Candidates) PunishByzantineCandidateV2(height uint64, tmAddress types.TmAddress) {
+	c.punishByzantineCandidate(height, tmAddress, true)
+}
+
+func (c *Candidates) punishByzantineCandidate(height uint64, tmAddress types.TmAddress, withLedger bool) {
 	candidate := c.GetCandidateByTendermintAddress(tmAddress)
 	stakes := c.GetStakes(candidate.PubKey)
 
@@ -461,9 +689,12 @@ func (c *Candidates) PunishByzantineCandidate(height uint64, tmAddress types.TmA
 			Coin:            uint64(stake.Coin),
 			ValidatorPubKey: candidate.PubKey,
 		})
+		if withLedger && c.bus.Slashes() != nil {
+			c.bus.Slashes().AddDoubleSignSlash(height, candidate.ID, stake.Owner, stake.Coin, slashed)
+		}
 
 		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(newValue))
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.unbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
 		stake.setValue(big.NewInt(0))
 	}
 }
@@ -566,14 +797,19 @@ func (c *Candidates) recalculateStakes(height uint64) {
 		candidate.clearUpdates()
 
 		totalBipValue := big.NewInt(0)
+		selfBipValue := big.NewInt(0)
 		for _, stake := range stakes {
 			if stake == nil {
 				continue
 			}
 			totalBipValue.Add(totalBipValue, stake.BipValue)
+			if stake.Owner == candidate.OwnerAddress {
+				selfBipValue.Add(selfBipValue, stake.BipValue)
+			}
 		}
 
 		candidate.setTotalBipStake(totalBipValue)
+		candidate.setSelfBipStake(selfBipValue)
 
 		c.lock.Lock()
 		c.totalStakes.Add(c.totalStakes, totalBipValue)
@@ -582,7 +818,16 @@ func (c *Candidates) recalculateStakes(height uint64) {
 }
 
 func (c *Candidates) stakeKick(owner types.Address, value *big.Int, coin types.CoinID, pubKey types.Pubkey, height uint64) {
-	c.bus.WaitList().AddToWaitList(owner, pubKey, coin, value)
+	if toCandidateID, ok := c.bus.WaitList().AutoRoute(owner); ok {
+		// the kicked stake is moved by the delegator preference, or unbonded if it is kicked from the preferred candidate
+		candidateID := c.ID(pubKey)
+		if toCandidateID == candidateID {
+			toCandidateID = 0
+		}
+		c.bus.FrozenFunds().AddFrozenMove(height+c.autoRoutePeriod(), owner, &pubKey, candidateID, coin, value, toCandidateID)
+	} else {
+		c.bus.WaitList().AddToWaitList(owner, pubKey, coin, value)
+	}
 	c.bus.Events().AddEvent(&eventsdb.StakeKickEvent{
 		Address:         owner,
 		Amount:          value.String(),
@@ -651,6 +896,14 @@ func (c *Candidates) IsNewCandidateStakeSufficient(coin types.CoinID, stake *big
 	return false
 }
 
+// CalculateBipValue returns BIP value of the new stake of given coin
+func (c *Candidates) CalculateBipValue(coin types.CoinID, amount *big.Int) *big.Int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.calculateBipValue(coin, amount, true, true, nil)
+}
+
 // GetCandidate returns candidate by a public key
 func (c *Candidates) GetCandidate(pubkey types.Pubkey) *Candidate {
 	return c.getFromMap(pubkey)
@@ -746,6 +999,217 @@ func (c *Candidates) EditCommission(pubkey types.Pubkey, commission uint32, heig
 	candidate.setCommission(commission, height)
 }
 
+// ScheduleCommission announces the new commission of the candidate at the height which becomes effective at the effective height.
+// The announced one replaces the pending change of the candidate.
+func (c *Candidates) ScheduleCommission(pubkey types.Pubkey, commission uint32, height uint64, effectiveHeight uint64) {
+	candidate := c.getFromMap(pubkey)
+	candidate.setLastEditCommissionHeight(height)
+	c.SetCommissionChange(pubkey, commission, height, effectiveHeight)
+}
+
+// SetCommissionChange sets the pending commission change of the candidate. Used in Import.
+func (c *Candidates) SetCommissionChange(pubkey types.Pubkey, commission uint32, announcedHeight uint64, height uint64) {
+	id := c.ID(pubkey)
+
+	c.muCommissionChanges.Lock()
+	defer c.muCommissionChanges.Unlock()
+
+	if pendingHeight := c.getCommissionHeight(id); pendingHeight != 0 {
+		c.getCommissionChanges(pendingHeight)[id] = nil
+		c.dirtyCommissionChanges[pendingHeight] = struct{}{}
+	}
+
+	c.getCommissionChanges(height)[id] = &CommissionChange{
+		CandidateID:     id,
+		Commission:      commission,
+		AnnouncedHeight: announcedHeight,
+		Height:          height,
+	}
+	c.dirtyCommissionChanges[height] = struct{}{}
+	c.commissionHeights[id] = height
+	c.dirtyCommissionHeights[id] = struct{}{}
+}
+
+// GetCommissionChange returns the pending commission change of the candidate or nil
+func (c *Candidates) GetCommissionChange(pubkey types.Pubkey) *CommissionChange {
+	id := c.ID(pubkey)
+	if id == 0 {
+		return nil
+	}
+
+	c.muCommissionChanges.Lock()
+	defer c.muCommissionChanges.Unlock()
+
+	height := c.getCommissionHeight(id)
+	if height == 0 {
+		return nil
+	}
+
+	return c.getCommissionChanges(height)[id]
+}
+
+// ApplyCommissionChanges sets commissions of candidates which changes become effective at the height
+func (c *Candidates) ApplyCommissionChanges(height uint64) {
+	c.muCommissionChanges.Lock()
+	changes := c.getCommissionChanges(height)
+	due := make([]*CommissionChange, 0, len(changes))
+	for id, change := range changes {
+		if change == nil {
+			continue
+		}
+		due = append(due, change)
+		changes[id] = nil
+		c.commissionHeights[id] = 0
+		c.dirtyCommissionHeights[id] = struct{}{}
+	}
+	if len(due) != 0 {
+		c.dirtyCommissionChanges[height] = struct{}{}
+	}
+	c.muCommissionChanges.Unlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].CandidateID < due[j].CandidateID
+	})
+
+	for _, change := range due {
+		candidate := c.GetCandidate(c.PubKey(change.CandidateID))
+		if candidate == nil {
+			continue
+		}
+
+		oldCommission := candidate.Commission
+		candidate.setCommission(change.Commission, change.AnnouncedHeight)
+		c.bus.Events().AddEvent(&eventsdb.CandidateCommissionChangedEvent{
+			CandidatePubKey: candidate.PubKey,
+			OldCommission:   uint64(oldCommission),
+			Commission:      uint64(change.Commission),
+		})
+	}
+}
+
+func commissionChangesPath(height uint64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+	return append([]byte{commissionChangePrefix}, heightBytes...)
+}
+
+func commissionChangePath(height uint64, id uint32) []byte {
+	idBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(idBytes, id)
+	return append(commissionChangesPath(height), idBytes...)
+}
+
+func commissionHeightPath(id uint32) []byte {
+	return append(append([]byte{mainPrefix}, idBytes(id)...), commissionHeightPrefix)
+}
+
+// getCommissionChanges returns changes becoming effective at the height by candidates IDs, nil change is deleted
+func (c *Candidates) getCommissionChanges(height uint64) map[uint32]*CommissionChange {
+	if changes, ok := c.commissionChanges[height]; ok {
+		return changes
+	}
+
+	changes := map[uint32]*CommissionChange{}
+	c.commissionChanges[height] = changes
+
+	if c.immutableTree() == nil {
+		return changes
+	}
+
+	c.immutableTree().IterateRange(commissionChangesPath(height), commissionChangesPath(height+1), true, func(key []byte, value []byte) bool {
+		change := &CommissionChange{}
+		if err := rlp.DecodeBytes(value, change); err != nil {
+			panic(fmt.Sprintf("failed to decode commission change at height %d: %s", height, err))
+		}
+		changes[change.CandidateID] = change
+		return false
+	})
+
+	return changes
+}
+
+// getCommissionHeight returns the height of the pending commission change of the candidate, 0 if there is no change
+func (c *Candidates) getCommissionHeight(id uint32) uint64 {
+	if height, ok := c.commissionHeights[id]; ok {
+		return height
+	}
+
+	var height uint64
+	if c.immutableTree() != nil {
+		if _, enc := c.immutableTree().Get(commissionHeightPath(id)); len(enc) != 0 {
+			height = binary.BigEndian.Uint64(enc)
+		}
+	}
+	c.commissionHeights[id] = height
+
+	return height
+}
+
+func (c *Candidates) commitCommissionChanges(db *iavl.MutableTree) error {
+	c.muCommissionChanges.Lock()
+	defer c.muCommissionChanges.Unlock()
+
+	heights := make([]uint64, 0, len(c.dirtyCommissionChanges))
+	for height := range c.dirtyCommissionChanges {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		changes := c.commissionChanges[height]
+		ids := make([]uint32, 0, len(changes))
+		for id := range changes {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return ids[i] < ids[j]
+		})
+
+		for _, id := range ids {
+			change := changes[id]
+			if change == nil {
+				db.Remove(commissionChangePath(height, id))
+				continue
+			}
+
+			data, err := rlp.EncodeToBytes(change)
+			if err != nil {
+				return fmt.Errorf("can't encode commission change of candidate %d: %v", id, err)
+			}
+			db.Set(commissionChangePath(height, id), data)
+		}
+	}
+
+	ids := make([]uint32, 0, len(c.dirtyCommissionHeights))
+	for id := range c.dirtyCommissionHeights {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		height := c.commissionHeights[id]
+		if height == 0 {
+			db.Remove(commissionHeightPath(id))
+			continue
+		}
+
+		heightBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(heightBytes, height)
+		db.Set(commissionHeightPath(id), heightBytes)
+	}
+
+	c.commissionChanges = map[uint64]map[uint32]*CommissionChange{}
+	c.dirtyCommissionChanges = map[uint64]struct{}{}
+	c.commissionHeights = map[uint32]uint64{}
+	c.dirtyCommissionHeights = map[uint32]struct{}{}
+
+	return nil
+}
+
 // SetOnline sets candidate status to CandidateStatusOnline
 func (c *Candidates) SetOnline(pubkey types.Pubkey) {
 	c.getFromMap(pubkey).setStatus(CandidateStatusOnline)
@@ -1018,11 +1482,202 @@ func (c *Candidates) calculateBipValue(coinID types.CoinID, amount *big.Int, inc
 // Punish punished a candidate with given tendermint-address
 func (c *Candidates) Punish(height uint64, address types.TmAddress) {
 	candidate := c.GetCandidateByTendermintAddress(address)
-	jailUntil := height + types.GetJailPeriod()
+	jailUntil := height + c.jailPeriod()
 	candidate.jainUntil(jailUntil)
 	c.bus.Events().AddEvent(&eventsdb.JailEvent{ValidatorPubKey: candidate.PubKey, JailedUntil: jailUntil})
 }
 
+// PunishDowntime jails a candidate with given tendermint-address for the downtime offence.
+// Repeated offences within the downtime epoch raise the level of the offence, which doubles
+// the jail period and slashes a part of stakes from the second level
+func (c *Candidates) PunishDowntime(height uint64, address types.TmAddress) {
+	candidate := c.GetCandidateByTendermintAddress(address)
+	c.loadInfractions(candidate)
+
+	level := candidate.addDowntime(height, c.downtimeEpoch())
+	jailUntil := height + c.jailPeriod()<<(level-1)
+	candidate.jainUntil(jailUntil)
+	c.bus.Events().AddEvent(&eventsdb.JailEvent{ValidatorPubKey: candidate.PubKey, JailedUntil: jailUntil, Level: uint64(level)})
+	if c.bus.Slashes() != nil {
+		c.bus.Slashes().AddDowntime(height, candidate.ID)
+	}
+
+	percent := downtimeSlashPercents[level]
+	if percent == 0 {
+		return
+	}
+
+	for _, stake := range c.GetStakes(candidate.PubKey) {
+		slashed := big.NewInt(0).Mul(stake.Value, big.NewInt(percent))
+		slashed.Div(slashed, big.NewInt(100))
+		if slashed.Sign() == 0 {
+			continue
+		}
+
+		if !stake.Coin.IsBaseCoin() {
+			coin := c.bus.Coins().GetCoin(stake.Coin)
+			ret := formula.CalculateSaleReturn(coin.Volume, coin.Reserve, coin.Crr, slashed)
+
+			c.bus.Coins().SubCoinVolume(coin.ID, slashed)
+			c.bus.Coins().SubCoinReserve(coin.ID, ret)
+
+			c.bus.App().AddTotalSlashed(ret)
+		} else {
+			c.bus.App().AddTotalSlashed(slashed)
+		}
+
+		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(slashed))
+
+		c.bus.Events().AddEvent(&eventsdb.SlashEvent{
+			Address:         stake.Owner,
+			Amount:          slashed.String(),
+			Coin:            uint64(stake.Coin),
+			ValidatorPubKey: candidate.PubKey,
+		})
+		if c.bus.Slashes() != nil {
+			c.bus.Slashes().AddDowntimeSlash(height, candidate.ID, stake.Owner, stake.Coin, slashed)
+		}
+
+		stake.subValue(slashed)
+	}
+}
+
+// GetInfractions returns downtime offences of the candidate which are not forgotten yet
+func (c *Candidates) GetInfractions(pubkey types.Pubkey) []*Infraction {
+	candidate := c.GetCandidate(pubkey)
+	if candidate == nil {
+		return nil
+	}
+
+	c.loadInfractions(candidate)
+
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	return candidate.infractions
+}
+
+// DowntimeLevel returns the level of the next downtime offence of the candidate at the height
+func (c *Candidates) DowntimeLevel(pubkey types.Pubkey, height uint64) uint32 {
+	candidate := c.GetCandidate(pubkey)
+	if candidate == nil {
+		return 1
+	}
+
+	c.loadInfractions(candidate)
+	return candidate.downtimeLevel(height, c.downtimeEpoch())
+}
+
+// SetInfractions sets downtime offences of the candidate. Used in Import.
+func (c *Candidates) SetInfractions(pubkey types.Pubkey, infractions []types.Infraction) {
+	candidate := c.GetCandidate(pubkey)
+	c.loadInfractions(candidate)
+
+	list := make([]*Infraction, 0, len(infractions))
+	for _, infraction := range infractions {
+		list = append(list, &Infraction{Height: infraction.Height, Level: uint32(infraction.Level)})
+	}
+	candidate.setInfractions(list)
+}
+
+func (c *Candidates) loadInfractions(candidate *Candidate) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	if candidate.isInfractionsLoaded {
+		return
+	}
+	candidate.isInfractionsLoaded = true
+
+	if c.immutableTree() == nil {
+		return
+	}
+
+	path := []byte{mainPrefix}
+	path = append(path, candidate.idBytes()...)
+	path = append(path, infractionsPrefix)
+	_, enc := c.immutableTree().Get(path)
+	if len(enc) == 0 {
+		return
+	}
+
+	if err := rlp.DecodeBytes(enc, &candidate.infractions); err != nil {
+		panic(fmt.Sprintf("failed to decode infractions: %s", err))
+	}
+}
+
+func (c *Candidates) downtimeEpoch() uint64 {
+	if c.bus.Params() == nil {
+		return types.GetDowntimeEpoch()
+	}
+	return c.bus.Params().DowntimeEpoch()
+}
+
+func (c *Candidates) unbondPeriod() uint64 {
+	if c.bus.Params() == nil {
+		return types.GetUnbondPeriod()
+	}
+	return c.bus.Params().UnbondPeriod()
+}
+
+func (c *Candidates) autoRoutePeriod() uint64 {
+	if c.bus.Params() == nil {
+		return types.GetMovePeriod()
+	}
+	return c.bus.Params().AutoRoutePeriod()
+}
+
+func (c *Candidates) jailPeriod() uint64 {
+	if c.bus.Params() == nil {
+		return types.GetJailPeriod()
+	}
+	return c.bus.Params().JailPeriod()
+}
+
+// IsAutoCompound returns true if rewards of the delegator from the candidate are added to the stake, it is the default
+func (c *Candidates) IsAutoCompound(pubkey types.Pubkey, address types.Address) bool {
+	candidate := c.GetCandidate(pubkey)
+	if candidate == nil {
+		return true
+	}
+
+	c.loadAutoCompound(candidate)
+	return candidate.isAutoCompound(address)
+}
+
+// SetAutoCompound enables or disables adding rewards of the delegator from the candidate to the stake
+func (c *Candidates) SetAutoCompound(pubkey types.Pubkey, address types.Address, enabled bool) {
+	candidate := c.GetCandidate(pubkey)
+	c.loadAutoCompound(candidate)
+	candidate.setAutoCompound(address, enabled)
+}
+
+func (c *Candidates) loadAutoCompound(candidate *Candidate) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	if candidate.isAutoCompoundLoaded {
+		return
+	}
+	candidate.isAutoCompoundLoaded = true
+
+	if c.immutableTree() == nil {
+		return
+	}
+
+	path := []byte{mainPrefix}
+	path = append(path, candidate.idBytes()...)
+	path = append(path, autoCompoundPrefix)
+	_, enc := c.immutableTree().Get(path)
+	if len(enc) == 0 {
+		return
+	}
+
+	if err := rlp.DecodeBytes(enc, &candidate.noAutoCompound); err != nil {
+		panic(fmt.Sprintf("failed to decode auto-compound settings: %s", err))
+	}
+}
+
 // SetStakes Sets stakes and updates of a candidate. Used in Import.
 func (c *Candidates) SetStakes(pubkey types.Pubkey, stakes []types.Stake, updates []types.Stake) {
 	candidate := c.GetCandidate(pubkey)
@@ -1104,6 +1759,16 @@ func (c *Candidates) Export(state *types.AppState) {
 			}
 		}
 
+		c.loadAutoCompound(candidate)
+		c.loadInfractions(candidate)
+		candidate.lock.RLock()
+		noAutoCompound := append([]types.Address(nil), candidate.noAutoCompound...)
+		infractions := make([]types.Infraction, 0, len(candidate.infractions))
+		for _, infraction := range candidate.infractions {
+			infractions = append(infractions, types.Infraction{Height: infraction.Height, Level: uint64(infraction.Level)})
+		}
+		candidate.lock.RUnlock()
+
 		state.Candidates = append(state.Candidates, types.Candidate{
 			ID:                       uint64(candidate.ID),
 			RewardAddress:            candidate.RewardAddress,
@@ -1117,6 +1782,8 @@ func (c *Candidates) Export(state *types.AppState) {
 			Stakes:                   stakes,
 			JailedUntil:              candidate.JailedUntil,
 			LastEditCommissionHeight: candidate.LastEditCommissionHeight,
+			NoAutoCompound:           noAutoCompound,
+			Infractions:              infractions,
 		})
 	}
 
@@ -1137,6 +1804,24 @@ func (c *Candidates) Export(state *types.AppState) {
 	sort.SliceStable(state.DeletedCandidates, func(i, j int) bool {
 		return state.DeletedCandidates[i].ID < state.DeletedCandidates[j].ID
 	})
+
+	c.immutableTree().IterateRange([]byte{commissionChangePrefix}, []byte{commissionChangePrefix + 1}, true, func(key []byte, value []byte) bool {
+		change := &CommissionChange{}
+		if err := rlp.DecodeBytes(value, change); err != nil {
+			panic(fmt.Sprintf("failed to decode commission change: %s", err))
+		}
+		if _, ok := c.list[change.CandidateID]; !ok {
+			return false
+		}
+
+		state.CommissionChanges = append(state.CommissionChanges, types.CommissionChange{
+			CandidateID:     uint64(change.CandidateID),
+			Commission:      uint64(change.Commission),
+			AnnouncedHeight: change.AnnouncedHeight,
+			Height:          change.Height,
+		})
+		return false
+	})
 }
 
 // Deprecated: Use getOrderedCandidatesLessID
@@ -1447,7 +2132,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(s.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.unbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
 		c.bus.Checker().AddCoin(s.Coin, big.NewInt(0).Neg(s.Value))
 		s.setValue(big.NewInt(0))
 	}
@@ -1461,7 +2146,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(u.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.unbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
 		c.bus.Checker().AddCoin(u.Coin, big.NewInt(0).Neg(u.Value))
 		u.setValue(big.NewInt(0))
 	}
@@ -76,7 +76,7 @@ func (c *Candidates) ExportV1(state *types.AppState, height uint64, validators [
 					continue
 				}
 				state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-					Height:       height + types.GetUnbondPeriod(),
+					Height:       height + c.unbondPeriod(),
 					Address:      s.Owner,
 					CandidateKey: nil,
 					CandidateID:  0,
@@ -89,7 +89,7 @@ func (c *Candidates) ExportV1(state *types.AppState, height uint64, validators [
 					continue
 				}
 				state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-					Height:       height + types.GetUnbondPeriod(),
+					Height:       height + c.unbondPeriod(),
 					Address:      u.Owner,
 					CandidateKey: nil,
 					CandidateID:  0,
@@ -17,16 +17,25 @@ type pubkeyID struct {
 // Candidate represents candidate object which is stored on disk
 type Candidate struct {
 	totalBipStake *big.Int
+	selfBipStake  *big.Int
 	stakesCount   int
 	stakes        [MaxDelegatorsPerCandidate]*stake
 	updates       []*stake
 	tmAddress     *types.TmAddress
 	lock          sync.RWMutex
 
-	isDirty           bool
-	isTotalStakeDirty bool
-	isUpdatesDirty    bool
-	dirtyStakes       [MaxDelegatorsPerCandidate]bool
+	noAutoCompound       []types.Address
+	isAutoCompoundLoaded bool
+
+	infractions         []*Infraction
+	isInfractionsLoaded bool
+
+	isDirty             bool
+	isTotalStakeDirty   bool
+	isUpdatesDirty      bool
+	isAutoCompoundDirty bool
+	isInfractionsDirty  bool
+	dirtyStakes         [MaxDelegatorsPerCandidate]bool
 
 	PubKey                   types.Pubkey
 	RewardAddress            types.Address
@@ -39,6 +48,52 @@ type Candidate struct {
 	JailedUntil              uint64
 }
 
+func (candidate *Candidate) snapshot() (revert func()) {
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	totalBipStake := big.NewInt(0).Set(candidate.totalBipStake)
+	selfBipStake := candidate.selfBipStake
+	stakesCount, stakes, dirtyStakes := candidate.stakesCount, candidate.stakes, candidate.dirtyStakes
+	updates := append([]*stake(nil), candidate.updates...)
+	reverts := make([]func(), 0, len(updates)+stakesCount)
+	for _, s := range stakes {
+		if s != nil {
+			reverts = append(reverts, s.snapshot())
+		}
+	}
+	for _, s := range updates {
+		reverts = append(reverts, s.snapshot())
+	}
+	tmAddress := candidate.tmAddress
+	noAutoCompound, isAutoCompoundLoaded := append([]types.Address(nil), candidate.noAutoCompound...), candidate.isAutoCompoundLoaded
+	infractions, isInfractionsLoaded := append([]*Infraction(nil), candidate.infractions...), candidate.isInfractionsLoaded
+	isDirty, isTotalStakeDirty, isUpdatesDirty := candidate.isDirty, candidate.isTotalStakeDirty, candidate.isUpdatesDirty
+	isAutoCompoundDirty, isInfractionsDirty := candidate.isAutoCompoundDirty, candidate.isInfractionsDirty
+	pubKey, rewardAddress, ownerAddress, controlAddress := candidate.PubKey, candidate.RewardAddress, candidate.OwnerAddress, candidate.ControlAddress
+	commission, status, lastEditCommissionHeight, jailedUntil := candidate.Commission, candidate.Status, candidate.LastEditCommissionHeight, candidate.JailedUntil
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		candidate.lock.Lock()
+		defer candidate.lock.Unlock()
+
+		candidate.totalBipStake, candidate.selfBipStake = totalBipStake, selfBipStake
+		candidate.stakesCount, candidate.stakes, candidate.dirtyStakes = stakesCount, stakes, dirtyStakes
+		candidate.updates = updates
+		candidate.tmAddress = tmAddress
+		candidate.noAutoCompound, candidate.isAutoCompoundLoaded = noAutoCompound, isAutoCompoundLoaded
+		candidate.infractions, candidate.isInfractionsLoaded = infractions, isInfractionsLoaded
+		candidate.isDirty, candidate.isTotalStakeDirty, candidate.isUpdatesDirty = isDirty, isTotalStakeDirty, isUpdatesDirty
+		candidate.isAutoCompoundDirty, candidate.isInfractionsDirty = isAutoCompoundDirty, isInfractionsDirty
+		candidate.PubKey, candidate.RewardAddress, candidate.OwnerAddress, candidate.ControlAddress = pubKey, rewardAddress, ownerAddress, controlAddress
+		candidate.Commission, candidate.Status, candidate.LastEditCommissionHeight, candidate.JailedUntil = commission, status, lastEditCommissionHeight, jailedUntil
+	}
+}
+
 func (candidate *Candidate) idBytes() []byte {
 	return idBytes(candidate.ID)
 }
@@ -74,6 +129,14 @@ func (candidate *Candidate) setCommission(commission uint32, height uint64) {
 	candidate.LastEditCommissionHeight = height
 }
 
+func (candidate *Candidate) setLastEditCommissionHeight(height uint64) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	candidate.isDirty = true
+	candidate.LastEditCommissionHeight = height
+}
+
 func (candidate *Candidate) jainUntil(height uint64) {
 	candidate.lock.Lock()
 	defer candidate.lock.Unlock()
@@ -120,6 +183,106 @@ func (candidate *Candidate) addUpdate(stake *stake) {
 	candidate.updates = append(candidate.updates, stake)
 }
 
+func (candidate *Candidate) isAutoCompound(address types.Address) bool {
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	for _, a := range candidate.noAutoCompound {
+		if a == address {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (candidate *Candidate) setAutoCompound(address types.Address, enabled bool) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	for i, a := range candidate.noAutoCompound {
+		if a != address {
+			continue
+		}
+		if enabled {
+			candidate.noAutoCompound = append(candidate.noAutoCompound[:i:i], candidate.noAutoCompound[i+1:]...)
+			candidate.isAutoCompoundDirty = true
+		}
+		return
+	}
+
+	if enabled {
+		return
+	}
+
+	candidate.noAutoCompound = append(candidate.noAutoCompound, address)
+	sort.Slice(candidate.noAutoCompound, func(i, j int) bool {
+		return candidate.noAutoCompound[i].Compare(candidate.noAutoCompound[j]) == -1
+	})
+	candidate.isAutoCompoundDirty = true
+}
+
+// CommissionChange is the commission of the candidate announced at the height, which becomes effective after the notice period
+type CommissionChange struct {
+	CandidateID     uint32
+	Commission      uint32
+	AnnouncedHeight uint64
+	Height          uint64
+}
+
+// Infraction is a downtime offence of the candidate with the level of penalty applied for it
+type Infraction struct {
+	Height uint64
+	Level  uint32
+}
+
+// addDowntime records the downtime offence at the height and returns its level.
+// Offences are forgotten if there were none for the epoch before the height.
+func (candidate *Candidate) addDowntime(height uint64, epoch uint64) uint32 {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	if l := len(candidate.infractions); l != 0 && candidate.infractions[l-1].Height+epoch <= height {
+		candidate.infractions = nil
+	}
+
+	level := uint32(len(candidate.infractions)) + 1
+	if level > MaxDowntimeLevel {
+		level = MaxDowntimeLevel
+	}
+
+	candidate.infractions = append(candidate.infractions, &Infraction{Height: height, Level: level})
+	if len(candidate.infractions) > MaxDowntimeLevel {
+		candidate.infractions = candidate.infractions[len(candidate.infractions)-MaxDowntimeLevel:]
+	}
+	candidate.isInfractionsDirty = true
+
+	return level
+}
+
+// downtimeLevel returns the level of the next downtime offence at the height
+func (candidate *Candidate) downtimeLevel(height uint64, epoch uint64) uint32 {
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	l := len(candidate.infractions)
+	if l == 0 || candidate.infractions[l-1].Height+epoch <= height {
+		return 1
+	}
+	if l >= MaxDowntimeLevel {
+		return MaxDowntimeLevel
+	}
+	return uint32(l) + 1
+}
+
+func (candidate *Candidate) setInfractions(infractions []*Infraction) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	candidate.infractions = infractions
+	candidate.isInfractionsDirty = true
+}
+
 func (candidate *Candidate) clearUpdates() {
 	candidate.lock.Lock()
 	defer candidate.lock.Unlock()
@@ -142,6 +305,24 @@ func (candidate *Candidate) setTotalBipStake(totalBipValue *big.Int) {
 	candidate.totalBipStake.Set(totalBipValue)
 }
 
+func (candidate *Candidate) setSelfBipStake(selfBipValue *big.Int) {
+	candidate.lock.Lock()
+	defer candidate.lock.Unlock()
+
+	candidate.selfBipStake = selfBipValue
+}
+
+// getSelfBipStake returns bip value of stakes of the owner totaled by the last recalculation of stakes, nil if stakes are not recalculated since the candidate was loaded
+func (candidate *Candidate) getSelfBipStake() *big.Int {
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	if candidate.selfBipStake == nil {
+		return nil
+	}
+	return big.NewInt(0).Set(candidate.selfBipStake)
+}
+
 // GetTmAddress returns tendermint-address of a candidate
 func (candidate *Candidate) GetTmAddress() types.TmAddress {
 	candidate.lock.RLock()
@@ -266,6 +447,20 @@ type stake struct {
 	lock      sync.RWMutex
 }
 
+func (stake *stake) snapshot() (revert func()) {
+	stake.lock.RLock()
+	defer stake.lock.RUnlock()
+
+	value, bipValue, index, markDirty := stake.Value, stake.BipValue, stake.index, stake.markDirty
+
+	return func() {
+		stake.lock.Lock()
+		defer stake.lock.Unlock()
+
+		stake.Value, stake.BipValue, stake.index, stake.markDirty = value, bipValue, index, markDirty
+	}
+}
+
 func (stake *stake) addValue(value *big.Int) {
 	stake.markDirty(stake.index)
 
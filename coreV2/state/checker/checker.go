@@ -52,6 +52,29 @@ func (c *Checker) AddCoinVolume(coin types.CoinID, value *big.Int) {
 	cValue.Add(cValue, value)
 }
 
+// Snapshot returns the function which reverts all changes of checker coin data made after the call
+func (c *Checker) Snapshot() (revert func()) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	delta, volumeDelta := copyDeltas(c.delta), copyDeltas(c.volumeDelta)
+
+	return func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		c.delta, c.volumeDelta = delta, volumeDelta
+	}
+}
+
+func copyDeltas(deltas map[types.CoinID]*big.Int) map[types.CoinID]*big.Int {
+	result := make(map[types.CoinID]*big.Int, len(deltas))
+	for coin, value := range deltas {
+		result[coin] = big.NewInt(0).Set(value)
+	}
+	return result
+}
+
 // Reset resets checker coin data
 func (c *Checker) Reset() {
 	c.lock.Lock()
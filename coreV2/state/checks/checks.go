@@ -3,36 +3,65 @@ package checks
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync"
 	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/cosmos/iavl"
 )
 
 const mainPrefix = byte('t')
 
+// values of used checks, the cancelled check can't be redeemed as the used one
+const (
+	usedCheckMark      = byte(0x1)
+	cancelledCheckMark = byte(0x2)
+)
+
+// usedCheckPath is the only key of 1+HashLength bytes under mainPrefix, escrows and reusable checks are nested under the longer keys
+func usedCheckPath(hash types.Hash) []byte {
+	return append([]byte{mainPrefix}, hash.Bytes()...)
+}
+
 type RChecks interface {
 	Export(state *types.AppState)
 	IsCheckUsed(check *check.Check) bool
+	GetReusableCheck(hash types.Hash) *ReusableCheck
+	GetRedeemedBy(hash types.Hash, address types.Address) *big.Int
+	IsCheckCancelled(hash types.Hash) bool
+	GetEscrow(hash types.Hash) *Escrow
 }
 
 type Checks struct {
-	usedChecks map[types.Hash]struct{}
+	usedChecks     map[types.Hash]byte
+	reusableChecks map[types.Hash]*ReusableCheck
+	redeemed       map[redeemerKey]*big.Int
+	escrows        map[types.Hash]*Escrow
 
 	db atomic.Value
 
+	bus *bus.Bus
+
 	lock sync.RWMutex
 }
 
-func NewChecks(db *iavl.ImmutableTree) *Checks {
+func NewChecks(stateBus *bus.Bus, db *iavl.ImmutableTree) *Checks {
 	immutableTree := atomic.Value{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
-	return &Checks{db: immutableTree, usedChecks: map[types.Hash]struct{}{}}
+	return &Checks{
+		bus:            stateBus,
+		db:             immutableTree,
+		usedChecks:     map[types.Hash]byte{},
+		reusableChecks: map[types.Hash]*ReusableCheck{},
+		redeemed:       map[redeemerKey]*big.Int{},
+		escrows:        map[types.Hash]*Escrow{},
+	}
 }
 
 func (c *Checks) immutableTree() *iavl.ImmutableTree {
@@ -51,14 +80,49 @@ func (c *Checks) Commit(db *iavl.MutableTree, version int64) error {
 	hashes := c.getOrderedHashes()
 	for _, hash := range hashes {
 		c.lock.Lock()
+		mark := c.usedChecks[hash]
 		delete(c.usedChecks, hash)
 		c.lock.Unlock()
 
-		trieHash := append([]byte{mainPrefix}, hash.Bytes()...)
-		db.Set(trieHash, []byte{0x1})
+		db.Set(usedCheckPath(hash), []byte{mark})
+	}
+
+	if err := c.commitEscrows(db); err != nil {
+		return err
+	}
+
+	return c.commitReusable(db)
+}
+
+// Snapshot returns the function which reverts all changes of checks made after the call.
+// Values of the maps are never changed in place, so copies of the maps are enough
+func (c *Checks) Snapshot() (revert func()) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	usedChecks := make(map[types.Hash]byte, len(c.usedChecks))
+	for hash, mark := range c.usedChecks {
+		usedChecks[hash] = mark
+	}
+	reusableChecks := make(map[types.Hash]*ReusableCheck, len(c.reusableChecks))
+	for hash, check := range c.reusableChecks {
+		reusableChecks[hash] = check
+	}
+	redeemed := make(map[redeemerKey]*big.Int, len(c.redeemed))
+	for key, value := range c.redeemed {
+		redeemed[key] = value
 	}
+	escrows := make(map[types.Hash]*Escrow, len(c.escrows))
+	for hash, escrow := range c.escrows {
+		escrows[hash] = escrow
+	}
+
+	return func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
 
-	return nil
+		c.usedChecks, c.reusableChecks, c.redeemed, c.escrows = usedChecks, reusableChecks, redeemed, escrows
+	}
 }
 
 func (c *Checks) IsCheckUsed(check *check.Check) bool {
@@ -69,7 +133,7 @@ func (c *Checks) IsCheckUsed(check *check.Check) bool {
 		return true
 	}
 
-	_, data := c.immutableTree().Get(append([]byte{mainPrefix}, check.Hash().Bytes()...))
+	_, data := c.immutableTree().Get(usedCheckPath(check.Hash()))
 
 	return len(data) != 0
 }
@@ -82,14 +146,45 @@ func (c *Checks) UseCheckHash(hash types.Hash) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.usedChecks[hash] = struct{}{}
+	c.usedChecks[hash] = usedCheckMark
+}
+
+// CancelCheckHash marks the check as cancelled by its issuer, so it can't be redeemed
+func (c *Checks) CancelCheckHash(hash types.Hash) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.usedChecks[hash] = cancelledCheckMark
+}
+
+// IsCheckCancelled reports whether the check is cancelled by its issuer
+func (c *Checks) IsCheckCancelled(hash types.Hash) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if mark, has := c.usedChecks[hash]; has {
+		return mark == cancelledCheckMark
+	}
+
+	_, data := c.immutableTree().Get(usedCheckPath(hash))
+
+	return len(data) == 1 && data[0] == cancelledCheckMark
 }
 
 func (c *Checks) Export(state *types.AppState) {
 	c.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 1+types.HashLength {
+			return false
+		}
+		if len(value) == 1 && value[0] == cancelledCheckMark {
+			state.CancelledChecks = append(state.CancelledChecks, types.UsedCheck(fmt.Sprintf("%x", key[1:])))
+			return false
+		}
 		state.UsedChecks = append(state.UsedChecks, types.UsedCheck(fmt.Sprintf("%x", key[1:])))
 		return false
 	})
+	c.exportEscrows(state)
+	c.exportReusable(state)
 }
 
 func (c *Checks) getOrderedHashes() []types.Hash {
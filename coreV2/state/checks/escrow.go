@@ -0,0 +1,141 @@
+package checks
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+// escrowPrefix follows mainPrefix and is followed by the hash of the check
+const escrowPrefix = byte('g')
+
+// Escrow is the value of the check locked by its issuer until the check is redeemed or cancelled
+type Escrow struct {
+	Issuer   types.Address
+	Coin     types.CoinID
+	Value    *big.Int
+	DueBlock uint64
+
+	deleted bool
+}
+
+// IsExpired reports whether the check can't be redeemed at the height, so anyone can return the escrow to the issuer
+func (e *Escrow) IsExpired(height uint64) bool {
+	return e.DueBlock < height
+}
+
+func escrowPath(hash types.Hash) []byte {
+	return append([]byte{mainPrefix, escrowPrefix}, hash.Bytes()...)
+}
+
+// GetEscrow returns the escrow of the check, nil if the check is not escrowed
+func (c *Checks) GetEscrow(hash types.Hash) *Escrow {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.getEscrow(hash)
+}
+
+func (c *Checks) getEscrow(hash types.Hash) *Escrow {
+	if escrow, ok := c.escrows[hash]; ok {
+		if escrow.deleted {
+			return nil
+		}
+		return escrow
+	}
+
+	_, enc := c.immutableTree().Get(escrowPath(hash))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	escrow := &Escrow{}
+	if err := rlp.DecodeBytes(enc, escrow); err != nil {
+		panic(fmt.Sprintf("failed to decode escrow of check %s: %s", hash.String(), err))
+	}
+
+	return escrow
+}
+
+// CreateEscrow locks the value of the check
+func (c *Checks) CreateEscrow(hash types.Hash, issuer types.Address, coin types.CoinID, value *big.Int, dueBlock uint64) {
+	c.lock.Lock()
+	c.escrows[hash] = &Escrow{
+		Issuer:   issuer,
+		Coin:     coin,
+		Value:    big.NewInt(0).Set(value),
+		DueBlock: dueBlock,
+	}
+	c.lock.Unlock()
+
+	c.bus.Checker().AddCoin(coin, value)
+}
+
+// DeleteEscrow removes the escrow of the redeemed or cancelled check
+func (c *Checks) DeleteEscrow(hash types.Hash) {
+	c.lock.Lock()
+	escrow := c.getEscrow(hash)
+	if escrow == nil {
+		c.lock.Unlock()
+		return
+	}
+	c.escrows[hash] = &Escrow{Issuer: escrow.Issuer, Coin: escrow.Coin, Value: escrow.Value, DueBlock: escrow.DueBlock, deleted: true}
+	c.lock.Unlock()
+
+	c.bus.Checker().AddCoin(escrow.Coin, big.NewInt(0).Neg(escrow.Value))
+}
+
+func (c *Checks) commitEscrows(db *iavl.MutableTree) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	hashes := make([]types.Hash, 0, len(c.escrows))
+	for hash := range c.escrows {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i].Bytes(), hashes[j].Bytes()) == -1
+	})
+
+	for _, hash := range hashes {
+		escrow := c.escrows[hash]
+		if escrow.deleted {
+			db.Remove(escrowPath(hash))
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(escrow)
+		if err != nil {
+			return fmt.Errorf("can't encode escrow of check %s: %v", hash.String(), err)
+		}
+		db.Set(escrowPath(hash), data)
+	}
+
+	c.escrows = map[types.Hash]*Escrow{}
+
+	return nil
+}
+
+func (c *Checks) exportEscrows(state *types.AppState) {
+	c.immutableTree().IterateRange([]byte{mainPrefix, escrowPrefix}, []byte{mainPrefix, escrowPrefix + 1}, true, func(key []byte, value []byte) bool {
+		hash := types.BytesToHash(key[2:])
+		escrow := &Escrow{}
+		if err := rlp.DecodeBytes(value, escrow); err != nil {
+			panic(fmt.Sprintf("failed to decode escrow of check %s: %s", hash.String(), err))
+		}
+
+		state.CheckEscrows = append(state.CheckEscrows, types.CheckEscrow{
+			Hash:     hash,
+			Issuer:   escrow.Issuer,
+			Coin:     uint64(escrow.Coin),
+			Value:    escrow.Value.String(),
+			DueBlock: escrow.DueBlock,
+		})
+		return false
+	})
+}
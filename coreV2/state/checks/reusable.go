@@ -0,0 +1,196 @@
+package checks
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+// reusablePrefix follows mainPrefix and is followed by the hash of the check for its state and by the hash and the address for the value redeemed by the address
+const reusablePrefix = byte('e')
+
+// ReusableCheck is the state of the check redeemed by parts
+type ReusableCheck struct {
+	Value    *big.Int
+	Redeemed *big.Int
+	Revoked  bool
+}
+
+// Remaining returns the value of the check which is not redeemed yet
+func (check *ReusableCheck) Remaining() *big.Int {
+	if check.Revoked {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Sub(check.Value, check.Redeemed)
+}
+
+type redeemerKey struct {
+	hash    types.Hash
+	address types.Address
+}
+
+func reusableCheckPath(hash types.Hash) []byte {
+	return append([]byte{mainPrefix, reusablePrefix}, hash.Bytes()...)
+}
+
+func redeemedPath(key redeemerKey) []byte {
+	return append(reusableCheckPath(key.hash), key.address.Bytes()...)
+}
+
+// GetReusableCheck returns the state of the reusable check, nil if it was neither redeemed nor revoked
+func (c *Checks) GetReusableCheck(hash types.Hash) *ReusableCheck {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.getReusableCheck(hash)
+}
+
+func (c *Checks) getReusableCheck(hash types.Hash) *ReusableCheck {
+	if check, ok := c.reusableChecks[hash]; ok {
+		return &ReusableCheck{Value: big.NewInt(0).Set(check.Value), Redeemed: big.NewInt(0).Set(check.Redeemed), Revoked: check.Revoked}
+	}
+
+	_, enc := c.immutableTree().Get(reusableCheckPath(hash))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	check := &ReusableCheck{}
+	if err := rlp.DecodeBytes(enc, check); err != nil {
+		panic(fmt.Sprintf("failed to decode reusable check %s: %s", hash.String(), err))
+	}
+
+	return check
+}
+
+// GetRedeemedBy returns the value of the reusable check redeemed by the address
+func (c *Checks) GetRedeemedBy(hash types.Hash, address types.Address) *big.Int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.getRedeemedBy(redeemerKey{hash: hash, address: address})
+}
+
+func (c *Checks) getRedeemedBy(key redeemerKey) *big.Int {
+	if value, ok := c.redeemed[key]; ok {
+		return big.NewInt(0).Set(value)
+	}
+
+	_, enc := c.immutableTree().Get(redeemedPath(key))
+
+	return big.NewInt(0).SetBytes(enc)
+}
+
+// RedeemReusableCheck adds the value to the redeemed value of the check of the total value and of the address
+func (c *Checks) RedeemReusableCheck(hash types.Hash, total *big.Int, address types.Address, value *big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	check := c.getReusableCheck(hash)
+	if check == nil {
+		check = &ReusableCheck{Value: big.NewInt(0).Set(total), Redeemed: big.NewInt(0)}
+	}
+	check.Redeemed.Add(check.Redeemed, value)
+	c.reusableChecks[hash] = check
+
+	key := redeemerKey{hash: hash, address: address}
+	c.redeemed[key] = big.NewInt(0).Add(c.getRedeemedBy(key), value)
+}
+
+// RevokeReusableCheck marks the check of the total value as revoked, so the rest of the value can't be redeemed
+func (c *Checks) RevokeReusableCheck(hash types.Hash, total *big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	check := c.getReusableCheck(hash)
+	if check == nil {
+		check = &ReusableCheck{Value: big.NewInt(0).Set(total), Redeemed: big.NewInt(0)}
+	}
+	check.Revoked = true
+	c.reusableChecks[hash] = check
+}
+
+// SetReusableCheck sets the state of the reusable check, used for import of the genesis
+func (c *Checks) SetReusableCheck(hash types.Hash, check *ReusableCheck, redeemers map[types.Address]*big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.reusableChecks[hash] = check
+	for address, value := range redeemers {
+		c.redeemed[redeemerKey{hash: hash, address: address}] = big.NewInt(0).Set(value)
+	}
+}
+
+func (c *Checks) commitReusable(db *iavl.MutableTree) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	hashes := make([]types.Hash, 0, len(c.reusableChecks))
+	for hash := range c.reusableChecks {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i].Bytes(), hashes[j].Bytes()) == -1
+	})
+	for _, hash := range hashes {
+		data, err := rlp.EncodeToBytes(c.reusableChecks[hash])
+		if err != nil {
+			return fmt.Errorf("can't encode object at %s: %v", hash.String(), err)
+		}
+		db.Set(reusableCheckPath(hash), data)
+	}
+
+	keys := make([]redeemerKey, 0, len(c.redeemed))
+	for key := range c.redeemed {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(redeemedPath(keys[i]), redeemedPath(keys[j])) == -1
+	})
+	for _, key := range keys {
+		db.Set(redeemedPath(key), c.redeemed[key].Bytes())
+	}
+
+	c.reusableChecks = map[types.Hash]*ReusableCheck{}
+	c.redeemed = map[redeemerKey]*big.Int{}
+
+	return nil
+}
+
+func (c *Checks) exportReusable(state *types.AppState) {
+	checks := map[types.Hash]*types.ReusableCheck{}
+	var hashes []types.Hash
+	c.immutableTree().IterateRange([]byte{mainPrefix, reusablePrefix}, []byte{mainPrefix, reusablePrefix + 1}, true, func(key []byte, value []byte) bool {
+		hash := types.BytesToHash(key[2 : 2+types.HashLength])
+		if len(key) == 2+types.HashLength {
+			check := &ReusableCheck{}
+			if err := rlp.DecodeBytes(value, check); err != nil {
+				panic(fmt.Sprintf("failed to decode reusable check %s: %s", hash.String(), err))
+			}
+			checks[hash] = &types.ReusableCheck{
+				Hash:     hash,
+				Value:    check.Value.String(),
+				Redeemed: check.Redeemed.String(),
+				Revoked:  check.Revoked,
+			}
+			hashes = append(hashes, hash)
+			return false
+		}
+
+		checks[hash].Redeemers = append(checks[hash].Redeemers, types.ReusableCheckRedeemer{
+			Address: types.BytesToAddress(key[2+types.HashLength:]),
+			Value:   big.NewInt(0).SetBytes(value).String(),
+		})
+		return false
+	})
+
+	for _, hash := range hashes {
+		state.ReusableChecks = append(state.ReusableChecks, *checks[hash])
+	}
+}
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+// checksKeys returns keys of the tree under the prefix of checks module
+func checksKeys(s *State) [][]byte {
+	var keys [][]byte
+	s.Tree().GetLastImmutable().IterateRange([]byte{'t'}, []byte{'t' + 1}, true, func(key []byte, value []byte) bool {
+		keys = append(keys, key)
+		return false
+	})
+	return keys
+}
+
+func TestChecks_ReusableCheckNested(t *testing.T) {
+	t.Parallel()
+	s, err := NewState(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := types.Hash{1}
+	s.Checks.RedeemReusableCheck(hash, big.NewInt(100), types.Address{2}, big.NewInt(30))
+
+	if _, err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := checksKeys(s)
+	if len(keys) != 2 {
+		t.Fatalf("checks module has %d keys, want 2", len(keys))
+	}
+	if want := append([]byte{'t', 'e'}, hash.Bytes()...); !bytes.Equal(keys[0], want) {
+		t.Errorf("key of reusable check is %x, want %x", keys[0], want)
+	}
+
+	check := s.Checks.GetReusableCheck(hash)
+	if check == nil || check.Value.Int64() != 100 || check.Redeemed.Int64() != 30 {
+		t.Fatalf("reusable check is %+v", check)
+	}
+	if redeemed := s.Checks.GetRedeemedBy(hash, types.Address{2}); redeemed.Int64() != 30 {
+		t.Errorf("redeemed by address %s, want 30", redeemed)
+	}
+
+	appState := new(types.AppState)
+	s.Checks.Export(appState)
+	if len(appState.UsedChecks) != 0 || len(appState.CancelledChecks) != 0 {
+		t.Errorf("reusable check is exported as used %v or cancelled %v", appState.UsedChecks, appState.CancelledChecks)
+	}
+	if len(appState.ReusableChecks) != 1 || appState.ReusableChecks[0].Redeemed != "30" || len(appState.ReusableChecks[0].Redeemers) != 1 {
+		t.Errorf("exported reusable checks %+v", appState.ReusableChecks)
+	}
+}
+
+func TestChecks_EscrowExportImport(t *testing.T) {
+	t.Parallel()
+	s := getState()
+
+	hash := types.Hash{1}
+	issuer := types.Address{2}
+	s.Checks.CreateEscrow(hash, issuer, types.GetBaseCoinID(), big.NewInt(100), 50)
+
+	if _, err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := checksKeys(s)
+	if want := append([]byte{'t', 'g'}, hash.Bytes()...); len(keys) != 1 || !bytes.Equal(keys[0], want) {
+		t.Fatalf("keys of checks module are %x, want %x", keys, want)
+	}
+
+	appState := s.Export()
+	if len(appState.UsedChecks) != 0 || len(appState.CheckEscrows) != 1 {
+		t.Fatalf("exported %d used checks and %d escrows", len(appState.UsedChecks), len(appState.CheckEscrows))
+	}
+
+	imported, err := NewState(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appState.PrevReward.Reward = "0"
+	if err := imported.Import(appState, appState.Version); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := imported.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	escrow := imported.Checks.GetEscrow(hash)
+	if escrow == nil || escrow.Issuer != issuer || escrow.Coin != types.GetBaseCoinID() || escrow.Value.Int64() != 100 || escrow.DueBlock != 50 {
+		t.Fatalf("imported escrow is %+v", escrow)
+	}
+
+	reexported := imported.Export()
+	if len(reexported.CheckEscrows) != 1 || reexported.CheckEscrows[0] != appState.CheckEscrows[0] {
+		t.Errorf("escrows are %+v after import, want %+v", reexported.CheckEscrows, appState.CheckEscrows)
+	}
+}
@@ -189,6 +189,43 @@ func (c *Coins) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// Snapshot returns the function which reverts all changes of coins made after the call
+func (c *Coins) Snapshot() (revert func()) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	reverts := make([]func(), 0, len(c.list)+len(c.symbolsInfoList))
+	list := make(map[types.CoinID]*Model, len(c.list))
+	for id, coin := range c.list {
+		list[id] = coin
+		reverts = append(reverts, coin.snapshot())
+	}
+	dirty := make(map[types.CoinID]struct{}, len(c.dirty))
+	for id := range c.dirty {
+		dirty[id] = struct{}{}
+	}
+	symbolsList := make(map[types.CoinSymbol][]types.CoinID, len(c.symbolsList))
+	for symbol, ids := range c.symbolsList {
+		symbolsList[symbol] = append([]types.CoinID(nil), ids...)
+	}
+	symbolsInfoList := make(map[types.CoinSymbol]*SymbolInfo, len(c.symbolsInfoList))
+	for symbol, info := range c.symbolsInfoList {
+		symbolsInfoList[symbol] = info
+		reverts = append(reverts, info.snapshot())
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		c.list, c.dirty, c.symbolsList, c.symbolsInfoList = list, dirty, symbolsList, symbolsInfoList
+	}
+}
+
 func (c *Coins) GetCoin(id types.CoinID) *Model {
 	return c.get(id)
 }
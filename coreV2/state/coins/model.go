@@ -31,6 +31,41 @@ type Model struct {
 	isCreated bool
 }
 
+func (m *Model) snapshot() (revert func()) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	name, crr, version, symbol, mintable, burnable := m.CName, m.CCrr, m.CVersion, m.CSymbol, m.Mintable, m.Burnable
+	maxSupply := big.NewInt(0).Set(m.CMaxSupply)
+	info, symbolInfo := m.info, m.symbolInfo
+	isDirty, isCreated := m.isDirty, m.isCreated
+
+	var revertInfo, revertSymbolInfo func()
+	if info != nil {
+		revertInfo = info.snapshot()
+	}
+	if symbolInfo != nil {
+		revertSymbolInfo = symbolInfo.snapshot()
+	}
+
+	return func() {
+		if revertInfo != nil {
+			revertInfo()
+		}
+		if revertSymbolInfo != nil {
+			revertSymbolInfo()
+		}
+
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.CName, m.CCrr, m.CVersion, m.CSymbol, m.Mintable, m.Burnable = name, crr, version, symbol, mintable, burnable
+		m.CMaxSupply.Set(maxSupply)
+		m.info, m.symbolInfo = info, symbolInfo
+		m.isDirty, m.isCreated = isDirty, isCreated
+	}
+}
+
 func (m *Model) Name() string {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -294,6 +329,36 @@ type SymbolInfo struct {
 	lock sync.RWMutex
 }
 
+func (i *Info) snapshot() (revert func()) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	volume, reserve, isDirty := big.NewInt(0).Set(i.Volume), big.NewInt(0).Set(i.Reserve), i.isDirty
+
+	return func() {
+		i.lock.Lock()
+		defer i.lock.Unlock()
+
+		i.Volume.Set(volume)
+		i.Reserve.Set(reserve)
+		i.isDirty = isDirty
+	}
+}
+
+func (i *SymbolInfo) snapshot() (revert func()) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	owner, isDirty := i.COwnerAddress, i.isDirty
+
+	return func() {
+		i.lock.Lock()
+		defer i.lock.Unlock()
+
+		i.COwnerAddress, i.isDirty = owner, isDirty
+	}
+}
+
 func (i *SymbolInfo) setOwnerAddress(address types.Address) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
@@ -324,6 +324,38 @@ func (c *Commission) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// Snapshot returns the function which reverts all changes of commission votes and prices made after the call
+func (c *Commission) Snapshot() (revert func()) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var reverts []func()
+	list := make(map[uint64][]*Model, len(c.list))
+	for height, models := range c.list {
+		list[height] = append([]*Model(nil), models...)
+		for _, model := range models {
+			reverts = append(reverts, model.snapshot())
+		}
+	}
+	dirty := make(map[uint64]struct{}, len(c.dirty))
+	for height := range c.dirty {
+		dirty[height] = struct{}{}
+	}
+	forDelete, currentPrice, dirtyCurrent := c.forDelete, c.currentPrice, c.dirtyCurrent
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		c.list, c.dirty = list, dirty
+		c.forDelete, c.currentPrice, c.dirtyCurrent = forDelete, currentPrice, dirtyCurrent
+	}
+}
+
 func (c *Commission) GetVotes(height uint64) []*Model {
 	return c.get(height)
 }
@@ -115,6 +115,150 @@ func (d *Price) Encode() []byte {
 //	return d.Send
 //}
 
+// CreateVestingPrice returns price of CreateVesting transaction, Lock price is used until the own price is voted
+func (d *Price) CreateVestingPrice() *big.Int {
+	if len(d.More) > 0 {
+		return d.More[0]
+	}
+	return d.Lock
+}
+
+// ClaimVestingPrice returns price of ClaimVesting transaction, Send price is used until the own price is voted
+func (d *Price) ClaimVestingPrice() *big.Int {
+	if len(d.More) > 1 {
+		return d.More[1]
+	}
+	return d.Send
+}
+
+// RevokeVestingPrice returns price of RevokeVesting transaction, Lock price is used until the own price is voted
+func (d *Price) RevokeVestingPrice() *big.Int {
+	if len(d.More) > 2 {
+		return d.More[2]
+	}
+	return d.Lock
+}
+
+// CreateProposalPrice returns price of CreateProposal transaction, EditMultisig price is used until the own price is voted
+func (d *Price) CreateProposalPrice() *big.Int {
+	if len(d.More) > 3 {
+		return d.More[3]
+	}
+	return d.EditMultisig
+}
+
+// ApproveProposalPrice returns price of ApproveProposal transaction, Send price is used until the own price is voted
+func (d *Price) ApproveProposalPrice() *big.Int {
+	if len(d.More) > 4 {
+		return d.More[4]
+	}
+	return d.Send
+}
+
+// RejectProposalPrice returns price of RejectProposal transaction, Send price is used until the own price is voted
+func (d *Price) RejectProposalPrice() *big.Int {
+	if len(d.More) > 5 {
+		return d.More[5]
+	}
+	return d.Send
+}
+
+// CreateHTLCPrice returns price of CreateHTLC transaction, Lock price is used until the own price is voted
+func (d *Price) CreateHTLCPrice() *big.Int {
+	if len(d.More) > 6 {
+		return d.More[6]
+	}
+	return d.Lock
+}
+
+// ClaimHTLCPrice returns price of ClaimHTLC transaction, Send price is used until the own price is voted
+func (d *Price) ClaimHTLCPrice() *big.Int {
+	if len(d.More) > 7 {
+		return d.More[7]
+	}
+	return d.Send
+}
+
+// RefundHTLCPrice returns price of RefundHTLC transaction, Send price is used until the own price is voted
+func (d *Price) RefundHTLCPrice() *big.Int {
+	if len(d.More) > 8 {
+		return d.More[8]
+	}
+	return d.Send
+}
+
+// RedeemReusableCheckPrice returns price of RedeemReusableCheck transaction, RedeemCheck price is used until the own price is voted
+func (d *Price) RedeemReusableCheckPrice() *big.Int {
+	if len(d.More) > 9 {
+		return d.More[9]
+	}
+	return d.RedeemCheck
+}
+
+// RevokeReusableCheckPrice returns price of RevokeReusableCheck transaction, Send price is used until the own price is voted
+func (d *Price) RevokeReusableCheckPrice() *big.Int {
+	if len(d.More) > 10 {
+		return d.More[10]
+	}
+	return d.Send
+}
+
+// EscrowCheckPrice returns price of EscrowCheck transaction, Lock price is used until the own price is voted
+func (d *Price) EscrowCheckPrice() *big.Int {
+	if len(d.More) > 11 {
+		return d.More[11]
+	}
+	return d.Lock
+}
+
+// CancelCheckPrice returns price of CancelCheck transaction, Send price is used until the own price is voted
+func (d *Price) CancelCheckPrice() *big.Int {
+	if len(d.More) > 12 {
+		return d.More[12]
+	}
+	return d.Send
+}
+
+// SetAutoCompoundPrice returns price of SetAutoCompound transaction, Send price is used until the own price is voted
+func (d *Price) SetAutoCompoundPrice() *big.Int {
+	if len(d.More) > 13 {
+		return d.More[13]
+	}
+	return d.Send
+}
+
+// CancelUnbondPrice returns price of CancelUnbond transaction, Unbond price is used until the own price is voted
+func (d *Price) CancelUnbondPrice() *big.Int {
+	if len(d.More) > 14 {
+		return d.More[14]
+	}
+	return d.Unbond
+}
+
+// VoteParamPrice returns price of VoteParam transaction, VoteUpdate price is used until the own price is voted
+func (d *Price) VoteParamPrice() *big.Int {
+	if len(d.More) > 15 {
+		return d.More[15]
+	}
+	return d.VoteUpdate
+}
+
+// VoteRewardPoolPrice returns price of VoteRewardPool transaction, VoteUpdate price is used until the own price is voted
+func (d *Price) VoteRewardPoolPrice() *big.Int {
+	if len(d.More) > 16 {
+		return d.More[16]
+	}
+	return d.VoteUpdate
+}
+
+// SetAutoRoutePrice returns price of SetAutoRoute transaction, Send price is used until the own price is voted
+func (d *Price) SetAutoRoutePrice() *big.Int {
+	if len(d.More) > 17 {
+		return d.More[17]
+	}
+	return d.Send
+}
+
 func Decode(s string) *Price {
 	var p Price
 	err := rlp.DecodeBytes([]byte(s), &p)
@@ -134,6 +278,20 @@ type Model struct {
 	lock sync.Mutex
 }
 
+func (m *Model) snapshot() (revert func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	votes := append([]types.Pubkey(nil), m.Votes...)
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.Votes = votes
+	}
+}
+
 func (m *Model) addVote(pubkey types.Pubkey) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -13,6 +13,10 @@ func (b *Bus) AddFrozenFund(height uint64, address types.Address, pubkey *types.
 	b.frozenfunds.AddFund(height, address, pubkey, candidateID, coin, big.NewInt(0).Set(value), 0)
 }
 
+func (b *Bus) AddFrozenMove(height uint64, address types.Address, pubkey *types.Pubkey, candidateID uint32, coin types.CoinID, value *big.Int, moveToCandidateID uint32) {
+	b.frozenfunds.AddFund(height, address, pubkey, candidateID, coin, big.NewInt(0).Set(value), moveToCandidateID)
+}
+
 func NewBus(frozenfunds *FrozenFunds) *Bus {
 	return &Bus{frozenfunds: frozenfunds}
 }
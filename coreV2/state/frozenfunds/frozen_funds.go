@@ -90,6 +90,34 @@ func (f *FrozenFunds) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// Snapshot returns the function which reverts all changes of frozen funds made after the call
+func (f *FrozenFunds) Snapshot() (revert func()) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	reverts := make([]func(), 0, len(f.list))
+	list := make(map[uint64]*Model, len(f.list))
+	for height, ff := range f.list {
+		list[height] = ff
+		reverts = append(reverts, ff.snapshot())
+	}
+	dirty := make(map[uint64]interface{}, len(f.dirty))
+	for height := range f.dirty {
+		dirty[height] = struct{}{}
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		f.list, f.dirty = list, dirty
+	}
+}
+
 func (f *FrozenFunds) GetFrozenFunds(height uint64) *Model {
 	return f.get(height)
 }
@@ -98,6 +126,15 @@ func (f *FrozenFunds) GetFrozenFundsAll(ctx context.Context, from, to uint64) []
 }
 
 func (f *FrozenFunds) PunishFrozenFundsWithID(fromHeight uint64, toHeight uint64, candidateID uint32) {
+	f.punishFrozenFundsWithID(fromHeight, toHeight, candidateID, false)
+}
+
+// PunishFrozenFundsWithIDV2 punishes frozen funds as PunishFrozenFundsWithID and records slashed funds to the slashes ledger
+func (f *FrozenFunds) PunishFrozenFundsWithIDV2(fromHeight uint64, toHeight uint64, candidateID uint32) {
+	f.punishFrozenFundsWithID(fromHeight, toHeight, candidateID, true)
+}
+
+func (f *FrozenFunds) punishFrozenFundsWithID(fromHeight uint64, toHeight uint64, candidateID uint32, withLedger bool) {
 	for cBlock := fromHeight; cBlock <= toHeight; cBlock++ {
 		ff := f.get(cBlock)
 		if ff == nil {
@@ -133,6 +170,9 @@ func (f *FrozenFunds) PunishFrozenFundsWithID(fromHeight uint64, toHeight uint64
 					Coin:            uint64(item.Coin),
 					ValidatorPubKey: *item.CandidateKey,
 				})
+				if withLedger && f.bus.Slashes() != nil {
+					f.bus.Slashes().AddDoubleSignSlash(fromHeight, candidateID, item.Address, item.Coin, slashed)
+				}
 
 				item.Value = newValue
 			}
@@ -235,6 +275,25 @@ func (f *FrozenFunds) AddFund(height uint64, address types.Address, pubkey *type
 	f.bus.Checker().AddCoin(coin, value)
 }
 
+// CancelUnbond removes the unbond of the address from the candidate from the frozen funds at the height
+// and returns its value, nil is returned if there is no such unbond
+func (f *FrozenFunds) CancelUnbond(height uint64, address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) *big.Int {
+	ff := f.get(height)
+	if ff == nil {
+		return nil
+	}
+
+	i := ff.FindUnbond(address, pubkey, coin, value)
+	if i == -1 {
+		return nil
+	}
+
+	item := ff.cancelFund(i)
+	f.bus.Checker().AddCoin(item.Coin, big.NewInt(0).Neg(item.Value))
+
+	return item.Value
+}
+
 func (f *FrozenFunds) Delete(height uint64) {
 	ff := f.get(height)
 	if ff == nil {
@@ -1,6 +1,7 @@
 package frozenfunds
 
 import (
+	"context"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
@@ -95,3 +96,48 @@ func TestFrozenFundsToDeleteNotExistingFund(t *testing.T) {
 
 	ff.Delete(0)
 }
+
+func TestFrozenFundsToCancelUnbond(t *testing.T) {
+	t.Parallel()
+	b := bus.NewBus()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	ff := NewFrozenFunds(b, mutableTree.GetLastImmutable())
+
+	b.SetChecker(checker.NewChecker(b))
+	coinsState := coins.NewCoins(b, mutableTree.GetLastImmutable())
+
+	b.SetCoins(coins.NewBus(coinsState))
+
+	height, addr, pubkey, coin := uint64(1), types.Address{0}, types.Pubkey{0}, types.GetBaseCoinID()
+
+	ff.AddFund(height, addr, &pubkey, 1, coin, big.NewInt(1e18), 0)
+	ff.AddFund(height, addr, &pubkey, 1, coin, big.NewInt(2e18), 0)
+	ff.AddFund(height, addr, &pubkey, 1, coin, big.NewInt(3e18), 0)
+
+	if value := ff.CancelUnbond(height, addr, pubkey, coin, big.NewInt(4e18)); value != nil {
+		t.Fatal("Not existing unbond is cancelled")
+	}
+
+	if value := ff.CancelUnbond(height, addr, pubkey, coin, big.NewInt(2e18)); value == nil || value.Cmp(big.NewInt(2e18)) != 0 {
+		t.Fatalf("Unbond is not cancelled: %s", value)
+	}
+
+	ff.AddFund(height, addr, &pubkey, 1, coin, big.NewInt(4e18), 0)
+
+	_, _, err := mutableTree.Commit(ff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ff.SetImmutableTree(mutableTree.GetLastImmutable())
+
+	all := ff.GetFrozenFundsAll(context.Background(), height, height+1)
+	if len(all) != 1 || len(all[0].List) != 3 {
+		t.Fatalf("Incorrect amount of funds: %v", all)
+	}
+
+	for i, id := range []uint32{1, 3, 4} {
+		if all[0].ID(i) != id {
+			t.Fatalf("Item %d has ID %d, want %d", i, all[0].ID(i), id)
+		}
+	}
+}
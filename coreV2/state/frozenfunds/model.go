@@ -24,6 +24,7 @@ func (i *Item) GetMoveToCandidateID() uint32 {
 
 type Model struct {
 	List []Item
+	IDs  []uint32 `rlp:"tail"` // identifiers of items, filled in on the first cancellation
 
 	height    uint64
 	deleted   bool
@@ -31,6 +32,20 @@ type Model struct {
 	lock      sync.RWMutex
 }
 
+func (m *Model) snapshot() (revert func()) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	list, ids, deleted := append([]Item(nil), m.List...), append([]uint32(nil), m.IDs...), m.deleted
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.List, m.IDs, m.deleted = list, ids, deleted
+	}
+}
+
 func (m *Model) delete() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -45,6 +60,9 @@ func (m *Model) addFund(address types.Address, pubkey *types.Pubkey, candidateID
 	if moveToCandidateID != 0 {
 		moveToCandidate = []uint32{moveToCandidateID}
 	}
+	if len(m.IDs) != 0 {
+		m.IDs = append(m.IDs, m.IDs[len(m.IDs)-1]+1)
+	}
 	m.List = append(m.List, Item{
 		Address:         address,
 		CandidateKey:    pubkey,
@@ -61,3 +79,47 @@ func (m *Model) addFund(address types.Address, pubkey *types.Pubkey, candidateID
 func (m *Model) Height() uint64 {
 	return m.height
 }
+
+// ID returns the identifier of the item with given index in the list.
+// Until the first cancellation identifiers are positions of items starting from 1
+func (m *Model) ID(i int) uint32 {
+	if len(m.IDs) == 0 {
+		return uint32(i + 1)
+	}
+	return m.IDs[i]
+}
+
+// FindUnbond returns the index of the unbond of the address from the candidate with given coin and value or -1
+func (m *Model) FindUnbond(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for i, item := range m.List {
+		if item.Address != address || item.CandidateKey == nil || *item.CandidateKey != pubkey || item.GetMoveToCandidateID() != 0 {
+			continue
+		}
+		if item.Coin == coin && item.Value.Cmp(value) == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (m *Model) cancelFund(i int) Item {
+	m.lock.Lock()
+	if len(m.IDs) == 0 {
+		m.IDs = make([]uint32, 0, len(m.List))
+		for j := range m.List {
+			m.IDs = append(m.IDs, uint32(j+1))
+		}
+	}
+	item := m.List[i]
+	m.List = append(m.List[:i:i], m.List[i+1:]...)
+	m.IDs = append(m.IDs[:i:i], m.IDs[i+1:]...)
+	m.lock.Unlock()
+
+	m.markDirty(m.height)
+
+	return item
+}
@@ -85,6 +85,34 @@ func (hb *HaltBlocks) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// Snapshot returns the function which reverts all changes of halt blocks made after the call
+func (hb *HaltBlocks) Snapshot() (revert func()) {
+	hb.lock.RLock()
+	defer hb.lock.RUnlock()
+
+	reverts := make([]func(), 0, len(hb.list))
+	list := make(map[uint64]*Model, len(hb.list))
+	for height, haltBlock := range hb.list {
+		list[height] = haltBlock
+		reverts = append(reverts, haltBlock.snapshot())
+	}
+	dirty := make(map[uint64]struct{}, len(hb.dirty))
+	for height := range hb.dirty {
+		dirty[height] = struct{}{}
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		hb.lock.Lock()
+		defer hb.lock.Unlock()
+
+		hb.list, hb.dirty = list, dirty
+	}
+}
+
 func (hb *HaltBlocks) GetHaltBlocks(height uint64) *Model {
 	return hb.get(height)
 }
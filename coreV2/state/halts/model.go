@@ -19,6 +19,20 @@ type Model struct {
 	lock sync.RWMutex
 }
 
+func (m *Model) snapshot() (revert func()) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	list, deleted := append([]Item(nil), m.List...), m.deleted
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.List, m.deleted = list, deleted
+	}
+}
+
 func (m *Model) delete() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
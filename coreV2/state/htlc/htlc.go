@@ -0,0 +1,277 @@
+package htlc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('k')
+
+// RHTLC is an interface of hash time-locked contracts for read only states
+type RHTLC interface {
+	Export(state *types.AppState)
+	Get(id uint32) *Model
+}
+
+// HTLC keeps coins locked by hash time-locked contracts until they are claimed or refunded
+type HTLC struct {
+	list  map[uint32]*Model
+	dirty map[uint32]struct{}
+
+	nextID      uint32
+	dirtyNextID bool
+
+	db atomic.Value
+
+	bus *bus.Bus
+
+	lock sync.RWMutex
+}
+
+func NewHTLC(stateBus *bus.Bus, db *iavl.ImmutableTree) *HTLC {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &HTLC{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[uint32]*Model{},
+		dirty: map[uint32]struct{}{},
+	}
+}
+
+func (h *HTLC) immutableTree() *iavl.ImmutableTree {
+	db := h.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (h *HTLC) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	h.db.Store(immutableTree)
+}
+
+func (h *HTLC) Export(state *types.AppState) {
+	h.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 5 {
+			return false
+		}
+
+		model := h.Get(binary.BigEndian.Uint32(key[1:]))
+		if model == nil {
+			return false
+		}
+
+		state.HTLCs = append(state.HTLCs, types.HTLC{
+			ID:        uint64(model.ID),
+			Sender:    model.Sender,
+			Recipient: model.Recipient,
+			Coin:      uint64(model.Coin),
+			Value:     model.Value.String(),
+			Hashlock:  model.Hashlock,
+			Timeout:   model.Timeout,
+		})
+
+		return false
+	})
+
+	state.NextHTLCID = uint64(h.getNextID())
+}
+
+func (h *HTLC) Commit(db *iavl.MutableTree, version int64) error {
+	h.lock.Lock()
+	if h.dirtyNextID {
+		h.dirtyNextID = false
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, h.nextID)
+		db.Set([]byte{mainPrefix}, b)
+	}
+	h.lock.Unlock()
+
+	for _, id := range h.getOrderedDirty() {
+		h.lock.Lock()
+		m := h.list[id]
+		delete(h.dirty, id)
+		h.lock.Unlock()
+
+		if m.deleted {
+			db.Remove(getPath(id))
+			h.lock.Lock()
+			delete(h.list, id)
+			h.lock.Unlock()
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(m)
+		if err != nil {
+			return fmt.Errorf("can't encode htlc %d: %v", id, err)
+		}
+		db.Set(getPath(id), data)
+	}
+
+	return nil
+}
+
+// Snapshot returns the function which reverts all changes of contracts made after the call
+func (h *HTLC) Snapshot() (revert func()) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	list := make(map[uint32]*Model, len(h.list))
+	deleted := make(map[uint32]bool, len(h.list))
+	for id, m := range h.list {
+		list[id], deleted[id] = m, m.deleted
+	}
+	dirty := make(map[uint32]struct{}, len(h.dirty))
+	for id := range h.dirty {
+		dirty[id] = struct{}{}
+	}
+	nextID, dirtyNextID := h.nextID, h.dirtyNextID
+
+	return func() {
+		h.lock.Lock()
+		defer h.lock.Unlock()
+
+		for id, m := range list {
+			m.deleted = deleted[id]
+		}
+		h.list, h.dirty = list, dirty
+		h.nextID, h.dirtyNextID = nextID, dirtyNextID
+	}
+}
+
+// Get returns the contract which is not claimed or refunded yet
+func (h *HTLC) Get(id uint32) *Model {
+	return h.get(id)
+}
+
+// Create locks value for the recipient and returns id of the contract
+func (h *HTLC) Create(sender, recipient types.Address, coin types.CoinID, value *big.Int, hashlock types.Hash, timeout uint64) uint32 {
+	id := h.getNextID()
+	h.setNextID(id + 1)
+
+	h.CreateWithID(id, sender, recipient, coin, value, hashlock, timeout)
+
+	return id
+}
+
+func (h *HTLC) CreateWithID(id uint32, sender, recipient types.Address, coin types.CoinID, value *big.Int, hashlock types.Hash, timeout uint64) {
+	m := &Model{
+		ID:        id,
+		Sender:    sender,
+		Recipient: recipient,
+		Coin:      coin,
+		Value:     new(big.Int).Set(value),
+		Hashlock:  hashlock,
+		Timeout:   timeout,
+	}
+
+	h.lock.Lock()
+	h.list[id] = m
+	h.dirty[id] = struct{}{}
+	h.lock.Unlock()
+
+	h.bus.Checker().AddCoin(coin, value)
+}
+
+// Delete removes claimed or refunded contract
+func (h *HTLC) Delete(id uint32) {
+	m := h.get(id)
+	if m == nil {
+		return
+	}
+
+	h.lock.Lock()
+	m.deleted = true
+	h.dirty[id] = struct{}{}
+	h.lock.Unlock()
+
+	h.bus.Checker().AddCoin(m.Coin, new(big.Int).Neg(m.Value))
+}
+
+func (h *HTLC) SetNextID(id uint32) {
+	h.setNextID(id)
+}
+
+func (h *HTLC) getNextID() uint32 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.nextID == 0 {
+		h.nextID = 1
+		if _, value := h.immutableTree().Get([]byte{mainPrefix}); len(value) == 4 {
+			h.nextID = binary.BigEndian.Uint32(value)
+		}
+	}
+
+	return h.nextID
+}
+
+func (h *HTLC) setNextID(id uint32) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.nextID = id
+	h.dirtyNextID = true
+}
+
+func (h *HTLC) get(id uint32) *Model {
+	h.lock.RLock()
+	m, ok := h.list[id]
+	isDeleted := ok && m.deleted
+	h.lock.RUnlock()
+	if isDeleted {
+		return nil
+	}
+	if ok {
+		return m
+	}
+
+	_, enc := h.immutableTree().Get(getPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	m = new(Model)
+	if err := rlp.DecodeBytes(enc, m); err != nil {
+		panic(fmt.Sprintf("failed to decode htlc %d: %s", id, err))
+	}
+
+	h.lock.Lock()
+	h.list[id] = m
+	h.lock.Unlock()
+
+	return m
+}
+
+func (h *HTLC) getOrderedDirty() []uint32 {
+	h.lock.Lock()
+	keys := make([]uint32, 0, len(h.dirty))
+	for k := range h.dirty {
+		keys = append(keys, k)
+	}
+	h.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func getPath(id uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append([]byte{mainPrefix}, b...)
+}
@@ -0,0 +1,32 @@
+package htlc
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is a hash time-locked contract, its value is released to the recipient by the preimage
+// of the hashlock or returned to the sender after the timeout height
+type Model struct {
+	ID        uint32
+	Sender    types.Address
+	Recipient types.Address
+	Coin      types.CoinID
+	Value     *big.Int
+	Hashlock  types.Hash
+	Timeout   uint64
+
+	deleted bool
+}
+
+// IsValidPreimage returns true if SHA-256 of the preimage equals to the hashlock of the contract
+func (m *Model) IsValidPreimage(preimage []byte) bool {
+	return sha256.Sum256(preimage) == m.Hashlock
+}
+
+// IsExpired returns true if the value can't be claimed at given height and can be refunded
+func (m *Model) IsExpired(height uint64) bool {
+	return height >= m.Timeout
+}
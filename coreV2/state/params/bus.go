@@ -0,0 +1,39 @@
+package params
+
+import "github.com/MinterTeam/minter-go-node/coreV2/types"
+
+type Bus struct {
+	params *Params
+}
+
+func NewBus(params *Params) *Bus {
+	return &Bus{params: params}
+}
+
+func (b *Bus) UnbondPeriod() uint64 {
+	return b.params.Get(UnbondPeriod)
+}
+
+func (b *Bus) JailPeriod() uint64 {
+	return b.params.Get(JailPeriod)
+}
+
+func (b *Bus) ValidatorMaxAbsentWindow() uint64 {
+	return b.params.Get(ValidatorMaxAbsentWindow)
+}
+
+func (b *Bus) DowntimeEpoch() uint64 {
+	return b.params.Get(DowntimeEpoch)
+}
+
+func (b *Bus) MinSelfBondRatio() uint64 {
+	return b.params.Get(MinSelfBondRatio)
+}
+
+func (b *Bus) AutoRoutePeriod() uint64 {
+	return b.params.Get(AutoRoutePeriod)
+}
+
+func (b *Bus) RewardPools() []types.RewardPool {
+	return b.params.RewardPools()
+}
@@ -0,0 +1,51 @@
+package params
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is a value of the parameter and validators voted for it
+type Model struct {
+	Votes   []types.Pubkey
+	Name    string
+	Value   uint64
+	Address types.Address // address of the reward pool, used only in votes for reward pools
+
+	height    uint64
+	markDirty func()
+
+	lock sync.Mutex
+}
+
+func (m *Model) snapshot() (revert func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	votes := append([]types.Pubkey(nil), m.Votes...)
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.Votes = votes
+	}
+}
+
+func (m *Model) addVote(pubkey types.Pubkey) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.Votes = append(m.Votes, pubkey)
+	m.markDirty()
+}
+
+// Height returns the height at which the value becomes effective
+func (m *Model) Height() uint64 {
+	return m.height
+}
+
+type value struct {
+	Name  string
+	Value uint64
+}
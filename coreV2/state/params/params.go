@@ -0,0 +1,453 @@
+package params
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const (
+	mainPrefix  = byte('n')
+	poolsPrefix = byte('p')
+)
+
+// Names of parameters changed by votes of validators
+const (
+	UnbondPeriod             = "unbond_period"
+	JailPeriod               = "jail_period"
+	ValidatorMaxAbsentWindow = "validator_max_absent_window"
+	ExpireOrdersPeriod       = "expire_orders_period"
+	UpdateStakesPeriod       = "update_stakes_period"
+	DowntimeEpoch            = "downtime_epoch"
+	MinSelfBondRatio         = "min_self_bond_ratio"
+	CommissionNoticePeriod   = "commission_notice_period"
+	MaxCommissionIncrease    = "max_commission_increase"
+	AutoRoutePeriod          = "auto_route_period"
+)
+
+type param struct {
+	defaultValue func() uint64
+	min, max     uint64
+}
+
+var params = map[string]param{
+	UnbondPeriod:             {defaultValue: types.GetUnbondPeriod, min: 1, max: 1036800},
+	JailPeriod:               {defaultValue: types.GetJailPeriod, min: 1, max: 518400},
+	ValidatorMaxAbsentWindow: {defaultValue: func() uint64 { return validators.ValidatorMaxAbsentWindow }, min: 2, max: 240},
+	ExpireOrdersPeriod:       {defaultValue: types.GetExpireOrdersPeriod, min: 1, max: 2419200},
+	UpdateStakesPeriod:       {defaultValue: types.GetUpdateStakesPeriod, min: 2, max: 17280},
+	DowntimeEpoch:            {defaultValue: types.GetDowntimeEpoch, min: 1, max: 2419200},
+	MinSelfBondRatio:         {defaultValue: func() uint64 { return 0 }, min: 0, max: 10000},
+	CommissionNoticePeriod:   {defaultValue: types.GetUnbondPeriod, min: 1, max: 1036800},
+	MaxCommissionIncrease:    {defaultValue: func() uint64 { return 5 }, min: 1, max: 10},
+	AutoRoutePeriod:          {defaultValue: types.GetMovePeriod, min: 1, max: 1036800},
+}
+
+// Names returns names of all parameters in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsKnown returns true if there is a parameter with such name
+func IsKnown(name string) bool {
+	_, ok := params[name]
+	return ok
+}
+
+// Bounds returns minimal and maximal allowed values of the parameter
+func Bounds(name string) (min, max uint64) {
+	p := params[name]
+	return p.min, p.max
+}
+
+// Default returns the value of the parameter used until the other one is voted
+func Default(name string) uint64 {
+	return params[name].defaultValue()
+}
+
+type RParams interface {
+	Export(state *types.AppState)
+	Get(name string) uint64
+	Lookup(name string) (uint64, bool)
+	GetVotes(height uint64) []*Model
+	IsVoteExists(height uint64, pubkey types.Pubkey, name string) bool
+	RewardPools() []types.RewardPool
+}
+
+// Params keeps values of network parameters voted by validators and votes for the upcoming ones
+type Params struct {
+	values      map[string]uint64
+	pools       []types.RewardPool
+	loaded      bool
+	dirtyValues bool
+	dirtyPools  bool
+	list        map[uint64][]*Model
+	dirty       map[uint64]struct{}
+	forDelete   map[uint64]struct{}
+
+	db atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewParams(stateBus *bus.Bus, db *iavl.ImmutableTree) *Params {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	p := &Params{
+		db:        immutableTree,
+		values:    map[string]uint64{},
+		list:      map[uint64][]*Model{},
+		dirty:     map[uint64]struct{}{},
+		forDelete: map[uint64]struct{}{},
+	}
+
+	stateBus.SetParams(NewBus(p))
+
+	return p
+}
+
+func (p *Params) immutableTree() *iavl.ImmutableTree {
+	db := p.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (p *Params) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	p.db.Store(immutableTree)
+}
+
+func (p *Params) Export(state *types.AppState) {
+	p.loadValues()
+
+	p.lock.RLock()
+	for _, name := range Names() {
+		if value, ok := p.values[name]; ok {
+			state.Params = append(state.Params, types.Param{
+				Name:  name,
+				Value: value,
+			})
+		}
+	}
+	state.RewardPools = append(state.RewardPools, p.pools...)
+	p.lock.RUnlock()
+
+	p.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 9 {
+			return false
+		}
+		height := binary.BigEndian.Uint64(key[1:])
+		for _, vote := range p.get(height) {
+			paramVote := types.ParamVote{
+				Height: height,
+				Votes:  vote.Votes,
+				Name:   vote.Name,
+				Value:  vote.Value,
+			}
+			if _, ok := PoolName(vote.Name); ok {
+				address := vote.Address
+				paramVote.Address = &address
+			}
+			state.ParamVotes = append(state.ParamVotes, paramVote)
+		}
+
+		return false
+	})
+}
+
+func (p *Params) Commit(db *iavl.MutableTree, version int64) error {
+	p.lock.Lock()
+	if p.dirtyValues {
+		p.dirtyValues = false
+		values := make([]value, 0, len(p.values))
+		for _, name := range Names() {
+			if v, ok := p.values[name]; ok {
+				values = append(values, value{Name: name, Value: v})
+			}
+		}
+		data, err := rlp.EncodeToBytes(values)
+		if err != nil {
+			p.lock.Unlock()
+			return fmt.Errorf("can't encode params: %v", err)
+		}
+		db.Set([]byte{mainPrefix}, data)
+	}
+	if p.dirtyPools {
+		p.dirtyPools = false
+		data, err := rlp.EncodeToBytes(p.pools)
+		if err != nil {
+			p.lock.Unlock()
+			return fmt.Errorf("can't encode reward pools: %v", err)
+		}
+		db.Set([]byte{mainPrefix, poolsPrefix}, data)
+	}
+	p.lock.Unlock()
+
+	for _, height := range p.getOrderedDirty() {
+		p.lock.Lock()
+		models := p.list[height]
+		delete(p.dirty, height)
+		p.lock.Unlock()
+
+		data, err := rlp.EncodeToBytes(models)
+		if err != nil {
+			return fmt.Errorf("can't encode param votes at %d: %v", height, err)
+		}
+
+		db.Set(getPath(height), data)
+	}
+
+	p.lock.Lock()
+	for height := range p.forDelete {
+		db.Remove(getPath(height))
+		delete(p.list, height)
+	}
+	p.forDelete = map[uint64]struct{}{}
+	p.lock.Unlock()
+
+	return nil
+}
+
+// Snapshot returns the function which reverts all changes of parameters and votes made after the call
+func (p *Params) Snapshot() (revert func()) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	values := make(map[string]uint64, len(p.values))
+	for name, value := range p.values {
+		values[name] = value
+	}
+	pools := p.pools
+	loaded, dirtyValues, dirtyPools := p.loaded, p.dirtyValues, p.dirtyPools
+
+	var reverts []func()
+	list := make(map[uint64][]*Model, len(p.list))
+	for height, models := range p.list {
+		list[height] = append([]*Model(nil), models...)
+		for _, model := range models {
+			reverts = append(reverts, model.snapshot())
+		}
+	}
+	dirty := make(map[uint64]struct{}, len(p.dirty))
+	for height := range p.dirty {
+		dirty[height] = struct{}{}
+	}
+	forDelete := make(map[uint64]struct{}, len(p.forDelete))
+	for height := range p.forDelete {
+		forDelete[height] = struct{}{}
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		p.values, p.pools = values, pools
+		p.loaded, p.dirtyValues, p.dirtyPools = loaded, dirtyValues, dirtyPools
+		p.list, p.dirty, p.forDelete = list, dirty, forDelete
+	}
+}
+
+// Get returns the current value of the parameter
+func (p *Params) Get(name string) uint64 {
+	if value, ok := p.Lookup(name); ok {
+		return value
+	}
+	return Default(name)
+}
+
+// Lookup returns the value of the parameter if it was changed by validators
+func (p *Params) Lookup(name string) (uint64, bool) {
+	p.loadValues()
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	value, ok := p.values[name]
+	return value, ok
+}
+
+// Set sets the current value of the parameter
+func (p *Params) Set(name string, value uint64) {
+	p.loadValues()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.values[name] = value
+	p.dirtyValues = true
+}
+
+// GetVotes returns values of parameters voted for the height
+func (p *Params) GetVotes(height uint64) []*Model {
+	return p.get(height)
+}
+
+// IsVoteExists returns true if the validator has already voted for the parameter at the height
+func (p *Params) IsVoteExists(height uint64, pubkey types.Pubkey, name string) bool {
+	for _, model := range p.get(height) {
+		if model.Name != name {
+			continue
+		}
+		for _, vote := range model.Votes {
+			if vote == pubkey {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AddVote adds the vote of the validator for the value of the parameter at the height
+func (p *Params) AddVote(height uint64, pubkey types.Pubkey, name string, value uint64) {
+	p.getOrNew(height, name, value, types.Address{}).addVote(pubkey)
+}
+
+// Delete removes votes for the height
+func (p *Params) Delete(height uint64) {
+	if len(p.get(height)) == 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.dirty, height)
+	p.forDelete[height] = struct{}{}
+}
+
+func (p *Params) loadValues() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.loaded {
+		return
+	}
+	p.loaded = true
+
+	if _, enc := p.immutableTree().Get([]byte{mainPrefix}); len(enc) != 0 {
+		var values []value
+		if err := rlp.DecodeBytes(enc, &values); err != nil {
+			panic(fmt.Sprintf("failed to decode params: %s", err))
+		}
+
+		for _, v := range values {
+			p.values[v.Name] = v.Value
+		}
+	}
+
+	if _, enc := p.immutableTree().Get([]byte{mainPrefix, poolsPrefix}); len(enc) != 0 {
+		if err := rlp.DecodeBytes(enc, &p.pools); err != nil {
+			panic(fmt.Sprintf("failed to decode reward pools: %s", err))
+		}
+	}
+}
+
+func (p *Params) getOrNew(height uint64, name string, value uint64, address types.Address) *Model {
+	models := p.get(height)
+
+	for _, model := range models {
+		if model.Name == name && model.Value == value && model.Address == address {
+			return model
+		}
+	}
+
+	model := &Model{
+		height:    height,
+		Name:      name,
+		Value:     value,
+		Address:   address,
+		markDirty: p.markDirty(height),
+	}
+	p.setToMap(height, append(models, model))
+	return model
+}
+
+func (p *Params) get(height uint64) []*Model {
+	if models := p.getFromMap(height); models != nil {
+		return models
+	}
+
+	_, enc := p.immutableTree().Get(getPath(height))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	var models []*Model
+	if err := rlp.DecodeBytes(enc, &models); err != nil {
+		panic(fmt.Sprintf("failed to decode param votes at height %d: %s", height, err))
+	}
+
+	for _, model := range models {
+		model.markDirty = p.markDirty(height)
+		model.height = height
+	}
+
+	p.setToMap(height, models)
+
+	return models
+}
+
+func (p *Params) markDirty(height uint64) func() {
+	return func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.dirty[height] = struct{}{}
+	}
+}
+
+func (p *Params) getOrderedDirty() []uint64 {
+	p.lock.RLock()
+	keys := make([]uint64, 0, len(p.dirty))
+	for k := range p.dirty {
+		keys = append(keys, k)
+	}
+	p.lock.RUnlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (p *Params) getFromMap(height uint64) []*Model {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.list[height]
+}
+
+func (p *Params) setToMap(height uint64, models []*Model) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.list[height] = models
+}
+
+func getPath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix}, b...)
+}
@@ -0,0 +1,75 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestParamsToCommitAndDelete(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	p := NewParams(bus.NewBus(), mutableTree.GetLastImmutable())
+
+	if p.Get(JailPeriod) != types.GetJailPeriod() {
+		t.Fatalf("default value %d is not %d", p.Get(JailPeriod), types.GetJailPeriod())
+	}
+
+	pubkey, height := types.Pubkey{1}, uint64(10)
+	p.Set(UnbondPeriod, 100)
+	p.AddVote(height, pubkey, JailPeriod, 50)
+
+	_, _, err := mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p = NewParams(bus.NewBus(), mutableTree.GetLastImmutable())
+	if value, ok := p.Lookup(UnbondPeriod); !ok || value != 100 {
+		t.Fatalf("value %d is not saved", value)
+	}
+	if !p.IsVoteExists(height, pubkey, JailPeriod) {
+		t.Fatal("vote is not saved")
+	}
+	if p.IsVoteExists(height, pubkey, UnbondPeriod) {
+		t.Fatal("vote exists for another parameter")
+	}
+
+	p.Delete(height)
+
+	_, _, err = mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p = NewParams(bus.NewBus(), mutableTree.GetLastImmutable())
+	if len(p.GetVotes(height)) != 0 {
+		t.Fatal("votes are not deleted")
+	}
+}
+
+func TestWithRewardPool(t *testing.T) {
+	t.Parallel()
+	pools, err := WithRewardPool(DefaultRewardPools(), types.RewardPool{Name: "grants", Address: types.Address{1}, Commission: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 3 || pools[0].Name != "dao" || pools[1].Name != "developers" || pools[2].Name != "grants" {
+		t.Fatalf("wrong pools %v", pools)
+	}
+
+	pools, err = WithRewardPool(pools, types.RewardPool{Name: "dao", Commission: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 2 || pools[0].Name != "developers" {
+		t.Fatalf("pool is not removed %v", pools)
+	}
+
+	if _, err := WithRewardPool(pools, types.RewardPool{Name: "insurance", Commission: 36}); err == nil {
+		t.Fatal("total commission is not limited")
+	}
+}
@@ -0,0 +1,53 @@
+// Package paramstest provides network parameters for tests of state modules which can't import package params
+package paramstest
+
+import "github.com/MinterTeam/minter-go-node/coreV2/types"
+
+// validatorMaxAbsentWindow is the default of validators.ValidatorMaxAbsentWindow
+const validatorMaxAbsentWindow = 24
+
+// Params implements bus.Params with default values of parameters, non-zero fields override them
+type Params struct {
+	Unbond             uint64
+	Jail               uint64
+	ValidatorMaxAbsent uint64
+	Downtime           uint64
+	MinSelfBond        uint64
+	AutoRoute          uint64
+	Pools              []types.RewardPool
+}
+
+func (p Params) UnbondPeriod() uint64 {
+	return orDefault(p.Unbond, types.GetUnbondPeriod())
+}
+
+func (p Params) JailPeriod() uint64 {
+	return orDefault(p.Jail, types.GetJailPeriod())
+}
+
+func (p Params) ValidatorMaxAbsentWindow() uint64 {
+	return orDefault(p.ValidatorMaxAbsent, validatorMaxAbsentWindow)
+}
+
+func (p Params) DowntimeEpoch() uint64 {
+	return orDefault(p.Downtime, types.GetDowntimeEpoch())
+}
+
+func (p Params) MinSelfBondRatio() uint64 {
+	return p.MinSelfBond
+}
+
+func (p Params) AutoRoutePeriod() uint64 {
+	return orDefault(p.AutoRoute, types.GetMovePeriod())
+}
+
+func (p Params) RewardPools() []types.RewardPool {
+	return p.Pools
+}
+
+func orDefault(value, defaultValue uint64) uint64 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
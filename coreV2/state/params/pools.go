@@ -0,0 +1,109 @@
+package params
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/dao"
+	"github.com/MinterTeam/minter-go-node/coreV2/developers"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Limits of reward pools receiving commissions from rewards of validators
+const (
+	MaxRewardPools           = 8
+	MaxRewardPoolsCommission = 50 // in %
+)
+
+const poolVotePrefix = "pool."
+
+var allowedPoolNameRegexp = regexp.MustCompile("^[a-z0-9_]{1,20}$")
+
+// IsValidPoolName returns true if the name can be used for a reward pool
+func IsValidPoolName(name string) bool {
+	return allowedPoolNameRegexp.MatchString(name)
+}
+
+// PoolVoteName returns the name under which votes for the reward pool are kept
+func PoolVoteName(pool string) string {
+	return poolVotePrefix + pool
+}
+
+// PoolName returns the name of the reward pool if the vote is for a reward pool
+func PoolName(voteName string) (string, bool) {
+	if !strings.HasPrefix(voteName, poolVotePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(voteName, poolVotePrefix), true
+}
+
+// DefaultRewardPools returns reward pools used until validators vote for other ones
+func DefaultRewardPools() []types.RewardPool {
+	return []types.RewardPool{
+		{Name: dao.Pool, Address: dao.Address, Commission: uint32(dao.Commission)},
+		{Name: developers.Pool, Address: developers.Address, Commission: uint32(developers.Commission)},
+	}
+}
+
+// WithRewardPool returns reward pools ordered by name with the pool added or changed,
+// the pool with zero commission is removed
+func WithRewardPool(pools []types.RewardPool, pool types.RewardPool) ([]types.RewardPool, error) {
+	result := make([]types.RewardPool, 0, len(pools)+1)
+	var commission uint32
+	for _, p := range pools {
+		if p.Name == pool.Name {
+			continue
+		}
+		result = append(result, p)
+		commission += p.Commission
+	}
+
+	if pool.Commission != 0 {
+		result = append(result, pool)
+		commission += pool.Commission
+	}
+
+	if len(result) > MaxRewardPools {
+		return nil, fmt.Errorf("number of reward pools %d exceeds %d", len(result), MaxRewardPools)
+	}
+	if commission > MaxRewardPoolsCommission {
+		return nil, fmt.Errorf("total commission of reward pools %d%% exceeds %d%%", commission, MaxRewardPoolsCommission)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// RewardPools returns the current reward pools
+func (p *Params) RewardPools() []types.RewardPool {
+	p.loadValues()
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.pools == nil {
+		return DefaultRewardPools()
+	}
+	return append([]types.RewardPool{}, p.pools...)
+}
+
+// SetRewardPools sets the current reward pools
+func (p *Params) SetRewardPools(pools []types.RewardPool) {
+	p.loadValues()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.pools = append([]types.RewardPool{}, pools...)
+	p.dirtyPools = true
+}
+
+// AddRewardPoolVote adds the vote of the validator for the address and the commission of the reward pool at the height
+func (p *Params) AddRewardPoolVote(height uint64, pubkey types.Pubkey, pool string, address types.Address, commission uint32) {
+	p.getOrNew(height, PoolVoteName(pool), uint64(commission), address).addVote(pubkey)
+}
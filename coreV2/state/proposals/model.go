@@ -0,0 +1,88 @@
+package proposals
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is a transaction proposed for a multisig address and waiting for approvals of its signers
+type Model struct {
+	ID           uint32
+	Multisig     types.Address
+	Proposer     types.Address
+	GasCoin      types.CoinID
+	TxType       byte
+	TxData       []byte
+	ExpireHeight uint64
+	Approvals    []types.Address
+	Rejections   []types.Address
+
+	deleted   bool
+	markDirty func(id uint32)
+	lock      sync.RWMutex
+}
+
+// HasVoted returns true if address already approved or rejected the proposal
+func (m *Model) HasVoted(address types.Address) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, addr := range m.Approvals {
+		if addr == address {
+			return true
+		}
+	}
+	for _, addr := range m.Rejections {
+		if addr == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *Model) snapshot() (revert func()) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	approvals, rejections, deleted := append([]types.Address(nil), m.Approvals...), append([]types.Address(nil), m.Rejections...), m.deleted
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.Approvals, m.Rejections, m.deleted = approvals, rejections, deleted
+	}
+}
+
+func (m *Model) approve(address types.Address) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.Approvals = append(m.Approvals, address)
+	m.markDirty(m.ID)
+}
+
+func (m *Model) reject(address types.Address) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.Rejections = append(m.Rejections, address)
+	m.markDirty(m.ID)
+}
+
+func (m *Model) delete() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.deleted = true
+	m.markDirty(m.ID)
+}
+
+func (m *Model) isDeleted() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.deleted
+}
@@ -0,0 +1,457 @@
+package proposals
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('m')
+
+// RProposals is an interface of multisig proposals for read only states
+type RProposals interface {
+	Export(state *types.AppState)
+	Get(id uint32) *Model
+	GetByMultisig(multisig types.Address) []*Model
+	NextID() uint32
+}
+
+// Proposals keeps transactions proposed for multisig addresses until they are approved, rejected or expired
+type Proposals struct {
+	list  map[uint32]*Model
+	dirty map[uint32]struct{}
+
+	expiring      map[uint64][]uint32
+	dirtyExpiring map[uint64]struct{}
+
+	nextID      uint32
+	dirtyNextID bool
+
+	db atomic.Value
+
+	bus *bus.Bus
+
+	lock sync.RWMutex
+}
+
+func NewProposals(stateBus *bus.Bus, db *iavl.ImmutableTree) *Proposals {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Proposals{
+		bus:           stateBus,
+		db:            immutableTree,
+		list:          map[uint32]*Model{},
+		dirty:         map[uint32]struct{}{},
+		expiring:      map[uint64][]uint32{},
+		dirtyExpiring: map[uint64]struct{}{},
+	}
+}
+
+func (p *Proposals) immutableTree() *iavl.ImmutableTree {
+	db := p.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (p *Proposals) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	p.db.Store(immutableTree)
+}
+
+func (p *Proposals) Export(state *types.AppState) {
+	p.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 5 {
+			return false
+		}
+
+		model := p.Get(binary.BigEndian.Uint32(key[1:]))
+		if model == nil {
+			return false
+		}
+
+		model.lock.RLock()
+		state.Proposals = append(state.Proposals, types.Proposal{
+			ID:           uint64(model.ID),
+			Multisig:     model.Multisig,
+			Proposer:     model.Proposer,
+			GasCoin:      uint64(model.GasCoin),
+			TxType:       uint64(model.TxType),
+			TxData:       model.TxData,
+			ExpireHeight: model.ExpireHeight,
+			Approvals:    model.Approvals,
+			Rejections:   model.Rejections,
+		})
+		model.lock.RUnlock()
+
+		return false
+	})
+
+	state.NextProposalID = uint64(p.getNextID())
+}
+
+func (p *Proposals) Commit(db *iavl.MutableTree, version int64) error {
+	p.lock.Lock()
+	if p.dirtyNextID {
+		p.dirtyNextID = false
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, p.nextID)
+		db.Set([]byte{mainPrefix}, b)
+	}
+	p.lock.Unlock()
+
+	for _, id := range p.getOrderedDirty() {
+		m := p.getFromMap(id)
+
+		p.lock.Lock()
+		delete(p.dirty, id)
+		p.lock.Unlock()
+
+		if m.isDeleted() {
+			db.Remove(getPath(id))
+			p.lock.Lock()
+			delete(p.list, id)
+			p.lock.Unlock()
+			continue
+		}
+
+		m.lock.RLock()
+		data, err := rlp.EncodeToBytes(m)
+		m.lock.RUnlock()
+		if err != nil {
+			return fmt.Errorf("can't encode proposal %d: %v", id, err)
+		}
+		db.Set(getPath(id), data)
+	}
+
+	for _, height := range p.getOrderedDirtyExpiring() {
+		p.lock.Lock()
+		ids := p.expiring[height]
+		delete(p.dirtyExpiring, height)
+		delete(p.expiring, height)
+		p.lock.Unlock()
+
+		if len(ids) == 0 {
+			db.Remove(getExpiringPath(height))
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(ids)
+		if err != nil {
+			return fmt.Errorf("can't encode proposals expiring at %d: %v", height, err)
+		}
+		db.Set(getExpiringPath(height), data)
+	}
+
+	return nil
+}
+
+// Snapshot returns the function which reverts all changes of proposals made after the call
+func (p *Proposals) Snapshot() (revert func()) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	reverts := make([]func(), 0, len(p.list))
+	list := make(map[uint32]*Model, len(p.list))
+	for id, m := range p.list {
+		list[id] = m
+		reverts = append(reverts, m.snapshot())
+	}
+	dirty := make(map[uint32]struct{}, len(p.dirty))
+	for id := range p.dirty {
+		dirty[id] = struct{}{}
+	}
+	expiring := make(map[uint64][]uint32, len(p.expiring))
+	for height, ids := range p.expiring {
+		expiring[height] = append([]uint32(nil), ids...)
+	}
+	dirtyExpiring := make(map[uint64]struct{}, len(p.dirtyExpiring))
+	for height := range p.dirtyExpiring {
+		dirtyExpiring[height] = struct{}{}
+	}
+	nextID, dirtyNextID := p.nextID, p.dirtyNextID
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		p.list, p.dirty = list, dirty
+		p.expiring, p.dirtyExpiring = expiring, dirtyExpiring
+		p.nextID, p.dirtyNextID = nextID, dirtyNextID
+	}
+}
+
+// Get returns the pending proposal
+func (p *Proposals) Get(id uint32) *Model {
+	return p.get(id)
+}
+
+// GetByMultisig returns pending proposals of the multisig address ordered by id
+func (p *Proposals) GetByMultisig(multisig types.Address) []*Model {
+	ids := map[uint32]struct{}{}
+	p.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) == 5 {
+			ids[binary.BigEndian.Uint32(key[1:])] = struct{}{}
+		}
+		return false
+	})
+
+	p.lock.RLock()
+	for id := range p.list {
+		ids[id] = struct{}{}
+	}
+	p.lock.RUnlock()
+
+	var list []*Model
+	for id := range ids {
+		m := p.get(id)
+		if m == nil || m.Multisig != multisig {
+			continue
+		}
+		list = append(list, m)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	return list
+}
+
+// Create adds a proposal approved by its proposer and returns id of the proposal
+func (p *Proposals) Create(multisig, proposer types.Address, gasCoin types.CoinID, txType byte, txData []byte, expireHeight uint64) uint32 {
+	id := p.getNextID()
+	p.setNextID(id + 1)
+
+	p.CreateWithID(id, multisig, proposer, gasCoin, txType, txData, expireHeight, []types.Address{proposer}, nil)
+
+	return id
+}
+
+func (p *Proposals) CreateWithID(id uint32, multisig, proposer types.Address, gasCoin types.CoinID, txType byte, txData []byte, expireHeight uint64, approvals, rejections []types.Address) {
+	m := &Model{
+		ID:           id,
+		Multisig:     multisig,
+		Proposer:     proposer,
+		GasCoin:      gasCoin,
+		TxType:       txType,
+		TxData:       txData,
+		ExpireHeight: expireHeight,
+		Approvals:    append([]types.Address{}, approvals...),
+		Rejections:   append([]types.Address{}, rejections...),
+		markDirty:    p.markDirty,
+	}
+
+	p.setToMap(id, m)
+	p.markDirty(id)
+	p.addExpiring(expireHeight, id)
+}
+
+// Approve adds approval of the signer to the proposal
+func (p *Proposals) Approve(id uint32, address types.Address) {
+	m := p.get(id)
+	if m == nil {
+		panic(fmt.Sprintf("proposal %d not found", id))
+	}
+
+	m.approve(address)
+}
+
+// Reject adds rejection of the signer to the proposal
+func (p *Proposals) Reject(id uint32, address types.Address) {
+	m := p.get(id)
+	if m == nil {
+		panic(fmt.Sprintf("proposal %d not found", id))
+	}
+
+	m.reject(address)
+}
+
+// Delete removes executed or rejected proposal
+func (p *Proposals) Delete(id uint32) {
+	m := p.get(id)
+	if m == nil {
+		return
+	}
+
+	m.delete()
+}
+
+// DeleteExpired removes proposals expiring at given height
+func (p *Proposals) DeleteExpired(height uint64) {
+	ids := p.getExpiring(height)
+	if len(ids) == 0 {
+		return
+	}
+
+	for _, id := range ids {
+		p.Delete(id)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.expiring[height] = nil
+	p.dirtyExpiring[height] = struct{}{}
+}
+
+// NextID returns id of the next created proposal
+func (p *Proposals) NextID() uint32 {
+	return p.getNextID()
+}
+
+func (p *Proposals) SetNextID(id uint32) {
+	p.setNextID(id)
+}
+
+func (p *Proposals) getNextID() uint32 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.nextID == 0 {
+		p.nextID = 1
+		if _, value := p.immutableTree().Get([]byte{mainPrefix}); len(value) == 4 {
+			p.nextID = binary.BigEndian.Uint32(value)
+		}
+	}
+
+	return p.nextID
+}
+
+func (p *Proposals) setNextID(id uint32) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.nextID = id
+	p.dirtyNextID = true
+}
+
+func (p *Proposals) get(id uint32) *Model {
+	if m := p.getFromMap(id); m != nil {
+		if m.isDeleted() {
+			return nil
+		}
+		return m
+	}
+
+	_, enc := p.immutableTree().Get(getPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	m := new(Model)
+	if err := rlp.DecodeBytes(enc, m); err != nil {
+		panic(fmt.Sprintf("failed to decode proposal %d: %s", id, err))
+	}
+
+	m.ID = id
+	m.markDirty = p.markDirty
+	p.setToMap(id, m)
+
+	return m
+}
+
+func (p *Proposals) getExpiring(height uint64) []uint32 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if ids, ok := p.expiring[height]; ok {
+		return ids
+	}
+
+	var ids []uint32
+	if _, enc := p.immutableTree().Get(getExpiringPath(height)); len(enc) != 0 {
+		if err := rlp.DecodeBytes(enc, &ids); err != nil {
+			panic(fmt.Sprintf("failed to decode proposals expiring at %d: %s", height, err))
+		}
+	}
+	p.expiring[height] = ids
+
+	return ids
+}
+
+func (p *Proposals) addExpiring(height uint64, id uint32) {
+	ids := p.getExpiring(height)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.expiring[height] = append(ids, id)
+	p.dirtyExpiring[height] = struct{}{}
+}
+
+func (p *Proposals) getFromMap(id uint32) *Model {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.list[id]
+}
+
+func (p *Proposals) setToMap(id uint32, model *Model) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.list[id] = model
+}
+
+func (p *Proposals) markDirty(id uint32) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.dirty[id] = struct{}{}
+}
+
+func (p *Proposals) getOrderedDirty() []uint32 {
+	p.lock.Lock()
+	keys := make([]uint32, 0, len(p.dirty))
+	for k := range p.dirty {
+		keys = append(keys, k)
+	}
+	p.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func (p *Proposals) getOrderedDirtyExpiring() []uint64 {
+	p.lock.Lock()
+	keys := make([]uint64, 0, len(p.dirtyExpiring))
+	for k := range p.dirtyExpiring {
+		keys = append(keys, k)
+	}
+	p.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func getPath(id uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append([]byte{mainPrefix}, b...)
+}
+
+func getExpiringPath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+	return append([]byte{mainPrefix}, b...)
+}
@@ -0,0 +1,27 @@
+package slashes
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+type Bus struct {
+	slashes *Slashes
+}
+
+func NewBus(slashes *Slashes) *Bus {
+	return &Bus{slashes: slashes}
+}
+
+func (b *Bus) AddDoubleSignSlash(height uint64, candidateID uint32, address types.Address, coin types.CoinID, amount *big.Int) {
+	b.slashes.AddSlash(height, InfractionDoubleSign, candidateID, address, coin, amount)
+}
+
+func (b *Bus) AddDowntimeSlash(height uint64, candidateID uint32, address types.Address, coin types.CoinID, amount *big.Int) {
+	b.slashes.AddSlash(height, InfractionDowntime, candidateID, address, coin, amount)
+}
+
+func (b *Bus) AddDowntime(height uint64, candidateID uint32) {
+	b.slashes.AddInfraction(height, InfractionDowntime, candidateID)
+}
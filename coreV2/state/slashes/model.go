@@ -0,0 +1,90 @@
+package slashes
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Infractions for which stakes are slashed
+const (
+	InfractionDoubleSign byte = iota + 1
+	InfractionDowntime
+)
+
+// InfractionName returns the name of the infraction used in API
+func InfractionName(infraction byte) string {
+	switch infraction {
+	case InfractionDoubleSign:
+		return "double_sign"
+	case InfractionDowntime:
+		return "downtime"
+	}
+	return "unknown"
+}
+
+// Record is a part of the stake of the delegator slashed for the infraction of the candidate
+type Record struct {
+	Height      uint64
+	Infraction  byte
+	CandidateID uint32
+	Coin        types.CoinID
+	Amount      *big.Int
+}
+
+// Ledger is the history of slashes of stakes of the delegator
+type Ledger struct {
+	Records []*Record
+
+	address types.Address
+}
+
+// Slashed is the total amount of the coin slashed from stakes of the candidate
+type Slashed struct {
+	Coin   types.CoinID
+	Amount *big.Int
+}
+
+// Summary is lifetime infractions and slashes of the candidate
+type Summary struct {
+	DoubleSigns    uint32
+	Downtimes      uint32
+	LastHeight     uint64
+	LastInfraction byte
+	Slashed        []*Slashed
+
+	candidateID uint32
+}
+
+func (s *Summary) copy() Summary {
+	summary := *s
+	summary.Slashed = make([]*Slashed, 0, len(s.Slashed))
+	for _, slashed := range s.Slashed {
+		summary.Slashed = append(summary.Slashed, &Slashed{Coin: slashed.Coin, Amount: new(big.Int).Set(slashed.Amount)})
+	}
+	return summary
+}
+
+func (s *Summary) addInfraction(height uint64, infraction byte) {
+	if s.LastHeight == height && s.LastInfraction == infraction {
+		return
+	}
+	s.LastHeight, s.LastInfraction = height, infraction
+
+	switch infraction {
+	case InfractionDoubleSign:
+		s.DoubleSigns++
+	case InfractionDowntime:
+		s.Downtimes++
+	}
+}
+
+func (s *Summary) addSlashed(coin types.CoinID, amount *big.Int) {
+	for _, slashed := range s.Slashed {
+		if slashed.Coin == coin {
+			slashed.Amount.Add(slashed.Amount, amount)
+			return
+		}
+	}
+	s.Slashed = append(s.Slashed, &Slashed{Coin: coin, Amount: new(big.Int).Set(amount)})
+}
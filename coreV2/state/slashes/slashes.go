@@ -0,0 +1,390 @@
+package slashes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('x')
+
+const (
+	ledgerPrefix  = byte('a')
+	summaryPrefix = byte('c')
+)
+
+// RSlashes is an interface of slashes for read only states
+type RSlashes interface {
+	Export(state *types.AppState)
+	GetLedger(address types.Address) *Ledger
+	GetSummary(candidateID uint32) *Summary
+}
+
+// Slashes keeps the history of slashed stakes of delegators and lifetime infractions of candidates
+type Slashes struct {
+	ledgers   map[types.Address]*Ledger
+	summaries map[uint32]*Summary
+
+	dirtyLedgers   map[types.Address]struct{}
+	dirtySummaries map[uint32]struct{}
+
+	db atomic.Value
+
+	bus *bus.Bus
+
+	lock sync.RWMutex
+}
+
+func NewSlashes(stateBus *bus.Bus, db *iavl.ImmutableTree) *Slashes {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	s := &Slashes{
+		bus:            stateBus,
+		db:             immutableTree,
+		ledgers:        map[types.Address]*Ledger{},
+		summaries:      map[uint32]*Summary{},
+		dirtyLedgers:   map[types.Address]struct{}{},
+		dirtySummaries: map[uint32]struct{}{},
+	}
+	stateBus.SetSlashes(NewBus(s))
+
+	return s
+}
+
+func (s *Slashes) immutableTree() *iavl.ImmutableTree {
+	db := s.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (s *Slashes) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	s.db.Store(immutableTree)
+}
+
+func (s *Slashes) Export(state *types.AppState) {
+	s.immutableTree().IterateRange([]byte{mainPrefix, ledgerPrefix}, []byte{mainPrefix, ledgerPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 2+types.AddressLength {
+			return false
+		}
+
+		ledger := s.GetLedger(types.BytesToAddress(key[2:]))
+		if ledger == nil {
+			return false
+		}
+
+		for _, record := range ledger.Records {
+			state.Slashes = append(state.Slashes, types.Slash{
+				Height:      record.Height,
+				Infraction:  uint64(record.Infraction),
+				CandidateID: uint64(record.CandidateID),
+				Address:     ledger.address,
+				Coin:        uint64(record.Coin),
+				Amount:      record.Amount.String(),
+			})
+		}
+
+		return false
+	})
+
+	s.immutableTree().IterateRange([]byte{mainPrefix, summaryPrefix}, []byte{mainPrefix, summaryPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 2+4 {
+			return false
+		}
+
+		summary := s.GetSummary(binary.BigEndian.Uint32(key[2:]))
+		if summary == nil {
+			return false
+		}
+
+		slashed := make([]types.SlashedValue, 0, len(summary.Slashed))
+		for _, value := range summary.Slashed {
+			slashed = append(slashed, types.SlashedValue{
+				Coin:   uint64(value.Coin),
+				Amount: value.Amount.String(),
+			})
+		}
+
+		state.SlashSummaries = append(state.SlashSummaries, types.SlashSummary{
+			CandidateID:    uint64(summary.candidateID),
+			DoubleSigns:    uint64(summary.DoubleSigns),
+			Downtimes:      uint64(summary.Downtimes),
+			LastHeight:     summary.LastHeight,
+			LastInfraction: uint64(summary.LastInfraction),
+			Slashed:        slashed,
+		})
+
+		return false
+	})
+}
+
+func (s *Slashes) Commit(db *iavl.MutableTree, version int64) error {
+	for _, address := range s.getOrderedDirtyLedgers() {
+		s.lock.Lock()
+		ledger := s.ledgers[address]
+		delete(s.dirtyLedgers, address)
+		s.lock.Unlock()
+
+		data, err := rlp.EncodeToBytes(ledger)
+		if err != nil {
+			return fmt.Errorf("can't encode slashes of %s: %v", address.String(), err)
+		}
+		db.Set(getLedgerPath(address), data)
+	}
+
+	for _, id := range s.getOrderedDirtySummaries() {
+		s.lock.Lock()
+		summary := s.summaries[id]
+		delete(s.dirtySummaries, id)
+		s.lock.Unlock()
+
+		data, err := rlp.EncodeToBytes(summary)
+		if err != nil {
+			return fmt.Errorf("can't encode slashes of candidate %d: %v", id, err)
+		}
+		db.Set(getSummaryPath(id), data)
+	}
+
+	return nil
+}
+
+// Snapshot returns the function which reverts all changes of ledgers and summaries made after the call
+func (s *Slashes) Snapshot() (revert func()) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ledgers := make(map[types.Address]*Ledger, len(s.ledgers))
+	records := make(map[types.Address][]*Record, len(s.ledgers))
+	for address, ledger := range s.ledgers {
+		ledgers[address], records[address] = ledger, append([]*Record(nil), ledger.Records...)
+	}
+	summaries := make(map[uint32]*Summary, len(s.summaries))
+	summaryValues := make(map[uint32]Summary, len(s.summaries))
+	for candidateID, summary := range s.summaries {
+		summaries[candidateID], summaryValues[candidateID] = summary, summary.copy()
+	}
+	dirtyLedgers := make(map[types.Address]struct{}, len(s.dirtyLedgers))
+	for address := range s.dirtyLedgers {
+		dirtyLedgers[address] = struct{}{}
+	}
+	dirtySummaries := make(map[uint32]struct{}, len(s.dirtySummaries))
+	for candidateID := range s.dirtySummaries {
+		dirtySummaries[candidateID] = struct{}{}
+	}
+
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		for address, ledger := range ledgers {
+			ledger.Records = records[address]
+		}
+		for candidateID, summary := range summaries {
+			*summary = summaryValues[candidateID]
+		}
+		s.ledgers, s.summaries = ledgers, summaries
+		s.dirtyLedgers, s.dirtySummaries = dirtyLedgers, dirtySummaries
+	}
+}
+
+// GetLedger returns the history of slashes of stakes of the delegator
+func (s *Slashes) GetLedger(address types.Address) *Ledger {
+	return s.getLedger(address)
+}
+
+// GetSummary returns lifetime infractions and slashes of the candidate
+func (s *Slashes) GetSummary(candidateID uint32) *Summary {
+	return s.getSummary(candidateID)
+}
+
+// AddSlash records the amount of the coin slashed from the stake of the delegator
+// for the infraction of the candidate at the height
+func (s *Slashes) AddSlash(height uint64, infraction byte, candidateID uint32, address types.Address, coin types.CoinID, amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+
+	ledger := s.getOrNewLedger(address)
+	summary := s.getOrNewSummary(candidateID)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ledger.Records = append(ledger.Records, &Record{
+		Height:      height,
+		Infraction:  infraction,
+		CandidateID: candidateID,
+		Coin:        coin,
+		Amount:      new(big.Int).Set(amount),
+	})
+	s.dirtyLedgers[address] = struct{}{}
+
+	summary.addInfraction(height, infraction)
+	summary.addSlashed(coin, amount)
+	s.dirtySummaries[candidateID] = struct{}{}
+}
+
+// AddInfraction records the infraction of the candidate at the height which does not slash stakes
+func (s *Slashes) AddInfraction(height uint64, infraction byte, candidateID uint32) {
+	summary := s.getOrNewSummary(candidateID)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	summary.addInfraction(height, infraction)
+	s.dirtySummaries[candidateID] = struct{}{}
+}
+
+// SetRecord adds the record to the history of the delegator. Used in Import.
+func (s *Slashes) SetRecord(address types.Address, record *Record) {
+	ledger := s.getOrNewLedger(address)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ledger.Records = append(ledger.Records, record)
+	s.dirtyLedgers[address] = struct{}{}
+}
+
+// SetSummary sets lifetime infractions and slashes of the candidate. Used in Import.
+func (s *Slashes) SetSummary(candidateID uint32, summary *Summary) {
+	summary.candidateID = candidateID
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.summaries[candidateID] = summary
+	s.dirtySummaries[candidateID] = struct{}{}
+}
+
+func (s *Slashes) getLedger(address types.Address) *Ledger {
+	s.lock.RLock()
+	ledger, ok := s.ledgers[address]
+	s.lock.RUnlock()
+	if ok {
+		return ledger
+	}
+
+	_, enc := s.immutableTree().Get(getLedgerPath(address))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	ledger = &Ledger{}
+	if err := rlp.DecodeBytes(enc, ledger); err != nil {
+		panic(fmt.Sprintf("failed to decode slashes of %s: %s", address.String(), err))
+	}
+	ledger.address = address
+
+	s.lock.Lock()
+	s.ledgers[address] = ledger
+	s.lock.Unlock()
+
+	return ledger
+}
+
+func (s *Slashes) getOrNewLedger(address types.Address) *Ledger {
+	if ledger := s.getLedger(address); ledger != nil {
+		return ledger
+	}
+
+	ledger := &Ledger{address: address}
+
+	s.lock.Lock()
+	s.ledgers[address] = ledger
+	s.lock.Unlock()
+
+	return ledger
+}
+
+func (s *Slashes) getSummary(candidateID uint32) *Summary {
+	s.lock.RLock()
+	summary, ok := s.summaries[candidateID]
+	s.lock.RUnlock()
+	if ok {
+		return summary
+	}
+
+	_, enc := s.immutableTree().Get(getSummaryPath(candidateID))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	summary = &Summary{}
+	if err := rlp.DecodeBytes(enc, summary); err != nil {
+		panic(fmt.Sprintf("failed to decode slashes of candidate %d: %s", candidateID, err))
+	}
+	summary.candidateID = candidateID
+
+	s.lock.Lock()
+	s.summaries[candidateID] = summary
+	s.lock.Unlock()
+
+	return summary
+}
+
+func (s *Slashes) getOrNewSummary(candidateID uint32) *Summary {
+	if summary := s.getSummary(candidateID); summary != nil {
+		return summary
+	}
+
+	summary := &Summary{candidateID: candidateID}
+
+	s.lock.Lock()
+	s.summaries[candidateID] = summary
+	s.lock.Unlock()
+
+	return summary
+}
+
+func (s *Slashes) getOrderedDirtyLedgers() []types.Address {
+	s.lock.Lock()
+	keys := make([]types.Address, 0, len(s.dirtyLedgers))
+	for k := range s.dirtyLedgers {
+		keys = append(keys, k)
+	}
+	s.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == -1
+	})
+
+	return keys
+}
+
+func (s *Slashes) getOrderedDirtySummaries() []uint32 {
+	s.lock.Lock()
+	keys := make([]uint32, 0, len(s.dirtySummaries))
+	for k := range s.dirtySummaries {
+		keys = append(keys, k)
+	}
+	s.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+func getLedgerPath(address types.Address) []byte {
+	return append([]byte{mainPrefix, ledgerPrefix}, address.Bytes()...)
+}
+
+func getSummaryPath(candidateID uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, candidateID)
+	return append([]byte{mainPrefix, summaryPrefix}, b...)
+}
@@ -0,0 +1,97 @@
+package slashes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestSlashes_AddSlash(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	slashes := NewSlashes(b, mutableTree.GetLastImmutable())
+
+	delegator, other := types.Address{1}, types.Address{2}
+
+	b.Slashes().AddDoubleSignSlash(10, 1, delegator, 0, big.NewInt(50))
+	b.Slashes().AddDoubleSignSlash(10, 1, other, 0, big.NewInt(20))
+	b.Slashes().AddDoubleSignSlash(10, 1, delegator, 2, big.NewInt(0))
+	b.Slashes().AddDowntime(20, 1)
+	b.Slashes().AddDowntime(30, 1)
+	b.Slashes().AddDowntimeSlash(30, 1, delegator, 2, big.NewInt(7))
+	b.Slashes().AddDowntimeSlash(40, 3, delegator, 0, big.NewInt(5))
+
+	_, _, err := mutableTree.Commit(slashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slashes = NewSlashes(b, mutableTree.GetLastImmutable())
+
+	ledger := slashes.GetLedger(delegator)
+	if ledger == nil {
+		t.Fatal("ledger is not saved")
+	}
+
+	records := []Record{
+		{Height: 10, Infraction: InfractionDoubleSign, CandidateID: 1, Coin: 0, Amount: big.NewInt(50)},
+		{Height: 30, Infraction: InfractionDowntime, CandidateID: 1, Coin: 2, Amount: big.NewInt(7)},
+		{Height: 40, Infraction: InfractionDowntime, CandidateID: 3, Coin: 0, Amount: big.NewInt(5)},
+	}
+	if len(ledger.Records) != len(records) {
+		t.Fatalf("ledger has %d records, want %d", len(ledger.Records), len(records))
+	}
+	for i, want := range records {
+		got := ledger.Records[i]
+		if got.Height != want.Height || got.Infraction != want.Infraction || got.CandidateID != want.CandidateID || got.Coin != want.Coin || got.Amount.Cmp(want.Amount) != 0 {
+			t.Errorf("record %d is %+v, want %+v", i, got, want)
+		}
+	}
+
+	if slashes.GetLedger(types.Address{3}) != nil {
+		t.Fatal("ledger of not slashed delegator exists")
+	}
+
+	tests := []struct {
+		candidateID    uint32
+		doubleSigns    uint32
+		downtimes      uint32
+		lastHeight     uint64
+		lastInfraction byte
+		slashed        map[types.CoinID]int64
+	}{
+		{candidateID: 1, doubleSigns: 1, downtimes: 2, lastHeight: 30, lastInfraction: InfractionDowntime, slashed: map[types.CoinID]int64{0: 70, 2: 7}},
+		{candidateID: 3, doubleSigns: 0, downtimes: 1, lastHeight: 40, lastInfraction: InfractionDowntime, slashed: map[types.CoinID]int64{0: 5}},
+	}
+	for _, test := range tests {
+		summary := slashes.GetSummary(test.candidateID)
+		if summary == nil {
+			t.Fatalf("summary of candidate %d is not saved", test.candidateID)
+		}
+		if summary.DoubleSigns != test.doubleSigns || summary.Downtimes != test.downtimes || summary.LastHeight != test.lastHeight || summary.LastInfraction != test.lastInfraction {
+			t.Errorf("summary of candidate %d is %+v", test.candidateID, summary)
+		}
+		if len(summary.Slashed) != len(test.slashed) {
+			t.Errorf("candidate %d has %d slashed coins, want %d", test.candidateID, len(summary.Slashed), len(test.slashed))
+		}
+		for _, slashed := range summary.Slashed {
+			if slashed.Amount.Int64() != test.slashed[slashed.Coin] {
+				t.Errorf("candidate %d slashed %s of coin %d, want %d", test.candidateID, slashed.Amount, slashed.Coin, test.slashed[slashed.Coin])
+			}
+		}
+	}
+
+	appState := new(types.AppState)
+	slashes.Export(appState)
+	if len(appState.Slashes) != 4 || len(appState.SlashSummaries) != 2 {
+		t.Fatalf("exported %d slashes and %d summaries", len(appState.Slashes), len(appState.SlashSummaries))
+	}
+	if slash := appState.Slashes[0]; slash.Address != delegator || slash.Amount != "50" || slash.Infraction != uint64(InfractionDoubleSign) {
+		t.Fatalf("invalid exported slash %+v", slash)
+	}
+}
@@ -17,9 +17,14 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/frozenfunds"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/halts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/htlc"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/proposals"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/slashes"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/update"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/vesting"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
@@ -33,13 +38,50 @@ type Interface interface {
 }
 
 type CheckState struct {
-	state *State
+	state    *State
+	transfer *transfer
+}
+
+// transfer is the value moved between the balances of the addresses in the check state without changing the state
+type transfer struct {
+	from  types.Address
+	to    types.Address
+	coin  types.CoinID
+	value *big.Int
+}
+
+// transferredAccounts returns balances of accounts including the transfer
+type transferredAccounts struct {
+	accounts.RAccounts
+	transfer *transfer
+}
+
+func (a *transferredAccounts) GetBalance(address types.Address, coin types.CoinID) *big.Int {
+	balance := a.RAccounts.GetBalance(address, coin)
+	if coin != a.transfer.coin {
+		return balance
+	}
+
+	switch address {
+	case a.transfer.from:
+		return big.NewInt(0).Sub(balance, a.transfer.value)
+	case a.transfer.to:
+		return big.NewInt(0).Add(balance, a.transfer.value)
+	}
+
+	return balance
 }
 
 func NewCheckState(state *State) *CheckState {
 	return &CheckState{state: state}
 }
 
+// WithTransfer returns the check state where the value is moved from the balance of one address to another,
+// e.g. to check the tx with the commission paid by the sponsor
+func (cs *CheckState) WithTransfer(from, to types.Address, coin types.CoinID, value *big.Int) *CheckState {
+	return &CheckState{state: cs.state, transfer: &transfer{from: from, to: to, coin: coin, value: big.NewInt(0).Set(value)}}
+}
+
 func (cs *CheckState) isValue_State() {}
 
 func (cs *CheckState) Export() types.AppState {
@@ -56,6 +98,11 @@ func (cs *CheckState) Export() types.AppState {
 	cs.Swap().Export(appState)
 	cs.Commission().Export(appState)
 	cs.Updates().Export(appState)
+	cs.Vesting().Export(appState)
+	cs.Proposals().Export(appState)
+	cs.HTLC().Export(appState)
+	cs.Params().Export(appState)
+	cs.Slashes().Export(appState)
 
 	return *appState
 }
@@ -82,6 +129,10 @@ func (cs *CheckState) Halts() halts.RHalts {
 	return cs.state.Halts
 }
 func (cs *CheckState) Accounts() accounts.RAccounts {
+	if cs.transfer != nil {
+		return &transferredAccounts{RAccounts: cs.state.Accounts, transfer: cs.transfer}
+	}
+
 	return cs.state.Accounts
 }
 func (cs *CheckState) Coins() coins.RCoins {
@@ -105,6 +156,26 @@ func (cs *CheckState) Commission() commission.RCommission {
 	return cs.state.Commission
 }
 
+func (cs *CheckState) Vesting() vesting.RVesting {
+	return cs.state.Vesting
+}
+
+func (cs *CheckState) Proposals() proposals.RProposals {
+	return cs.state.Proposals
+}
+
+func (cs *CheckState) HTLC() htlc.RHTLC {
+	return cs.state.HTLC
+}
+
+func (cs *CheckState) Params() params.RParams {
+	return cs.state.Params
+}
+
+func (cs *CheckState) Slashes() slashes.RSlashes {
+	return cs.state.Slashes
+}
+
 type State struct {
 	App         *app.App
 	Validators  *validators.Validators
@@ -120,6 +191,11 @@ type State struct {
 	SwapV2      *swap.SwapV2
 	Commission  *commission.Commission
 	Updates     *update.Update
+	Vesting     *vesting.Vesting
+	Proposals   *proposals.Proposals
+	HTLC        *htlc.HTLC
+	Params      *params.Params
+	Slashes     *slashes.Slashes
 
 	db     db.DB
 	events eventsdb.IEventsDB
@@ -267,6 +343,11 @@ func (s *State) Commit() ([]byte, error) {
 		s.GetSwap(),
 		s.Commission,
 		s.Updates,
+		s.Vesting,
+		s.Proposals,
+		s.HTLC,
+		s.Params,
+		s.Slashes,
 	)
 	if err != nil {
 		return hash, err
@@ -286,6 +367,73 @@ func (s *State) Commit() ([]byte, error) {
 	return hash, nil
 }
 
+// Snapshot returns the functions which revert or keep all changes of the state made after the call, one of them must be called.
+// The events added after the call are passed to the events db only when the changes are kept
+func (s *State) Snapshot() (revert, keep func()) {
+	reverts := []func(){
+		s.App.Snapshot(),
+		s.Validators.Snapshot(),
+		s.Candidates.Snapshot(),
+		s.FrozenFunds.Snapshot(),
+		s.Halts.Snapshot(),
+		s.Accounts.Snapshot(),
+		s.Coins.Snapshot(),
+		s.Checks.Snapshot(),
+		s.Checker.Snapshot(),
+		s.Waitlist.Snapshot(),
+		s.Commission.Snapshot(),
+		s.Updates.Snapshot(),
+		s.Vesting.Snapshot(),
+		s.Proposals.Snapshot(),
+		s.HTLC.Snapshot(),
+		s.Params.Snapshot(),
+		s.Slashes.Snapshot(),
+	}
+	// only the pools of v2 can be reverted, the pools of v1 are not changed after it
+	if s.SwapV2 != nil {
+		reverts = append(reverts, s.SwapV2.Snapshot())
+	}
+
+	events := s.bus.Events()
+	buffer := &bufferedEvents{IEventsDB: events}
+	s.bus.SetEvents(buffer)
+
+	revert = func() {
+		s.bus.SetEvents(events)
+		for _, r := range reverts {
+			r()
+		}
+	}
+	keep = func() {
+		s.bus.SetEvents(events)
+		for _, event := range buffer.items {
+			events.AddEvent(event)
+		}
+	}
+
+	return revert, keep
+}
+
+// bufferedEvents holds the added events until the changes of the state are kept
+type bufferedEvents struct {
+	eventsdb.IEventsDB
+	items eventsdb.Events
+}
+
+func (e *bufferedEvents) AddEvent(event eventsdb.Event) { e.items = append(e.items, event) }
+
+// Branch returns the state to try the txs in the deliver mode and the function which discards all changes of it.
+// It is the state of the check state itself, so the discard function must be called before the next use of the check state
+func (cs *CheckState) Branch() (branch *State, discard func()) {
+	revert, _ := cs.state.Snapshot()
+	if cs.transfer != nil {
+		cs.state.Accounts.SubBalance(cs.transfer.from, cs.transfer.coin, cs.transfer.value)
+		cs.state.Accounts.AddBalance(cs.transfer.to, cs.transfer.coin, cs.transfer.value)
+	}
+
+	return cs.state, revert
+}
+
 func (s *State) Import(state types.AppState, version string) error {
 	defer s.Checker.RemoveBaseCoin()
 
@@ -355,12 +503,22 @@ func (s *State) Import(state types.AppState, version string) error {
 
 		s.Candidates.SetTotalStake(c.PubKey, helpers.StringToBigInt(c.TotalBipStake))
 		s.Candidates.SetStakes(c.PubKey, c.Stakes, c.Updates)
+		for _, address := range c.NoAutoCompound {
+			s.Candidates.SetAutoCompound(c.PubKey, address, false)
+		}
+		if len(c.Infractions) != 0 {
+			s.Candidates.SetInfractions(c.PubKey, c.Infractions)
+		}
 	}
 
 	if len(state.DeletedCandidates) > 0 {
 		s.Candidates.SetDeletedCandidates(state.DeletedCandidates)
 	}
 
+	for _, change := range state.CommissionChanges {
+		s.Candidates.SetCommissionChange(s.Candidates.PubKey(uint32(change.CandidateID)), uint32(change.Commission), change.AnnouncedHeight, change.Height)
+	}
+
 	s.Candidates.RecalculateStakesV2(uint64(s.height))
 
 	for _, w := range state.Waitlist {
@@ -369,6 +527,10 @@ func (s *State) Import(state types.AppState, version string) error {
 		s.Waitlist.AddWaitList(w.Owner, s.Candidates.PubKey(uint32(w.CandidateID)), coinID, value)
 	}
 
+	for _, route := range state.AutoRoutes {
+		s.Waitlist.SetRoute(route.Owner, uint32(route.CandidateID))
+	}
+
 	for _, hashString := range state.UsedChecks {
 		bytes, _ := hex.DecodeString(string(hashString))
 		var hash types.Hash
@@ -376,6 +538,29 @@ func (s *State) Import(state types.AppState, version string) error {
 		s.Checks.UseCheckHash(hash)
 	}
 
+	for _, hashString := range state.CancelledChecks {
+		bytes, _ := hex.DecodeString(string(hashString))
+		var hash types.Hash
+		copy(hash[:], bytes)
+		s.Checks.CancelCheckHash(hash)
+	}
+
+	for _, escrow := range state.CheckEscrows {
+		s.Checks.CreateEscrow(escrow.Hash, escrow.Issuer, types.CoinID(escrow.Coin), helpers.StringToBigInt(escrow.Value), escrow.DueBlock)
+	}
+
+	for _, check := range state.ReusableChecks {
+		redeemers := map[types.Address]*big.Int{}
+		for _, redeemer := range check.Redeemers {
+			redeemers[redeemer.Address] = helpers.StringToBigInt(redeemer.Value)
+		}
+		s.Checks.SetReusableCheck(check.Hash, &checks.ReusableCheck{
+			Value:    helpers.StringToBigInt(check.Value),
+			Redeemed: helpers.StringToBigInt(check.Redeemed),
+			Revoked:  check.Revoked,
+		}, redeemers)
+	}
+
 	for _, ff := range state.FrozenFunds {
 		coinID := types.CoinID(ff.Coin)
 		value := helpers.StringToBigInt(ff.Value)
@@ -384,6 +569,27 @@ func (s *State) Import(state types.AppState, version string) error {
 
 	s.Swapper().Import(&state)
 
+	for _, v := range state.Vestings {
+		s.Vesting.CreateWithID(uint32(v.ID), v.Beneficiary, types.CoinID(v.Coin), helpers.StringToBigInt(v.Value), helpers.StringToBigInt(v.Claimed), v.StartHeight, v.CliffHeight, v.EndHeight, v.Revoker)
+	}
+	if state.NextVestingID != 0 {
+		s.Vesting.SetNextID(uint32(state.NextVestingID))
+	}
+
+	for _, p := range state.Proposals {
+		s.Proposals.CreateWithID(uint32(p.ID), p.Multisig, p.Proposer, types.CoinID(p.GasCoin), byte(p.TxType), p.TxData, p.ExpireHeight, p.Approvals, p.Rejections)
+	}
+	if state.NextProposalID != 0 {
+		s.Proposals.SetNextID(uint32(state.NextProposalID))
+	}
+
+	for _, h := range state.HTLCs {
+		s.HTLC.CreateWithID(uint32(h.ID), h.Sender, h.Recipient, types.CoinID(h.Coin), helpers.StringToBigInt(h.Value), h.Hashlock, h.Timeout)
+	}
+	if state.NextHTLCID != 0 {
+		s.HTLC.SetNextID(uint32(state.NextHTLCID))
+	}
+
 	c := state.Commission
 	com := &commission.Price{
 		Coin:                    types.CoinID(c.Coin),
@@ -521,6 +727,51 @@ func (s *State) Import(state types.AppState, version string) error {
 		}
 	}
 
+	for _, p := range state.Params {
+		s.Params.Set(p.Name, p.Value)
+	}
+
+	if len(state.RewardPools) != 0 {
+		s.Params.SetRewardPools(state.RewardPools)
+	}
+
+	for _, vote := range state.ParamVotes {
+		for _, pubkey := range vote.Votes {
+			if pool, ok := params.PoolName(vote.Name); ok && vote.Address != nil {
+				s.Params.AddRewardPoolVote(vote.Height, pubkey, pool, *vote.Address, uint32(vote.Value))
+				continue
+			}
+			s.Params.AddVote(vote.Height, pubkey, vote.Name, vote.Value)
+		}
+	}
+
+	for _, slash := range state.Slashes {
+		s.Slashes.SetRecord(slash.Address, &slashes.Record{
+			Height:      slash.Height,
+			Infraction:  byte(slash.Infraction),
+			CandidateID: uint32(slash.CandidateID),
+			Coin:        types.CoinID(slash.Coin),
+			Amount:      helpers.StringToBigInt(slash.Amount),
+		})
+	}
+
+	for _, summary := range state.SlashSummaries {
+		slashed := make([]*slashes.Slashed, 0, len(summary.Slashed))
+		for _, value := range summary.Slashed {
+			slashed = append(slashed, &slashes.Slashed{
+				Coin:   types.CoinID(value.Coin),
+				Amount: helpers.StringToBigInt(value.Amount),
+			})
+		}
+		s.Slashes.SetSummary(uint32(summary.CandidateID), &slashes.Summary{
+			DoubleSigns:    uint32(summary.DoubleSigns),
+			Downtimes:      uint32(summary.Downtimes),
+			LastHeight:     summary.LastHeight,
+			LastInfraction: byte(summary.LastInfraction),
+			Slashed:        slashed,
+		})
+	}
+
 	return nil
 }
 
@@ -579,7 +830,7 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 
 	coinsState := coins.NewCoins(stateBus, immutableTree)
 
-	checksState := checks.NewChecks(immutableTree)
+	checksState := checks.NewChecks(stateBus, immutableTree)
 
 	haltsState := halts.NewHalts(stateBus, immutableTree)
 
@@ -591,6 +842,16 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 
 	update := update.New(immutableTree)
 
+	vestingState := vesting.NewVesting(stateBus, immutableTree)
+
+	proposalsState := proposals.NewProposals(stateBus, immutableTree)
+
+	htlcState := htlc.NewHTLC(stateBus, immutableTree)
+
+	paramsState := params.NewParams(stateBus, immutableTree)
+
+	slashesState := slashes.NewSlashes(stateBus, immutableTree)
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -605,6 +866,11 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 		Swap:        pool,
 		Commission:  commission,
 		Updates:     update,
+		Vesting:     vestingState,
+		Proposals:   proposalsState,
+		HTLC:        htlcState,
+		Params:      paramsState,
+		Slashes:     slashesState,
 
 		height:         immutableTree.Version(),
 		bus:            stateBus,
@@ -633,7 +899,7 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 
 	coinsState := coins.NewCoins(stateBus, immutableTree)
 
-	checksState := checks.NewChecks(immutableTree)
+	checksState := checks.NewChecks(stateBus, immutableTree)
 
 	haltsState := halts.NewHalts(stateBus, immutableTree)
 
@@ -645,6 +911,16 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 
 	update := update.New(immutableTree)
 
+	vestingState := vesting.NewVesting(stateBus, immutableTree)
+
+	proposalsState := proposals.NewProposals(stateBus, immutableTree)
+
+	htlcState := htlc.NewHTLC(stateBus, immutableTree)
+
+	paramsState := params.NewParams(stateBus, immutableTree)
+
+	slashesState := slashes.NewSlashes(stateBus, immutableTree)
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -659,6 +935,11 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 		SwapV2:      poolV2,
 		Commission:  commission,
 		Updates:     update,
+		Vesting:     vestingState,
+		Proposals:   proposalsState,
+		HTLC:        htlcState,
+		Params:      paramsState,
+		Slashes:     slashesState,
 
 		height:         immutableTree.Version(),
 		bus:            stateBus,
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"github.com/MinterTeam/minter-go-node/coreV2/check"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
@@ -354,3 +355,92 @@ func TestStateExport(t *testing.T) {
 		t.Fatal("Invalid waitlist data")
 	}
 }
+
+func TestStateSnapshot(t *testing.T) {
+	t.Parallel()
+
+	prepare := func() *State {
+		s, err := NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 1, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s.Accounts.AddBalance(types.Address{1}, 0, helpers.BipToPip(big.NewInt(100)))
+		s.SwapV2.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+		s.SwapV2.PairAddOrder(0, 1, helpers.BipToPip(big.NewInt(20)), helpers.BipToPip(big.NewInt(10)), types.Address{2}, 1)
+		if _, err := s.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	s, expected := prepare(), prepare()
+
+	revert, _ := s.Snapshot()
+	s.Accounts.AddBalance(types.Address{1}, 0, helpers.BipToPip(big.NewInt(100)))
+	s.Accounts.SubBalance(types.Address{1}, 0, helpers.BipToPip(big.NewInt(50)))
+	s.SwapV2.PairSellWithOrders(1, 0, helpers.BipToPip(big.NewInt(100)), big.NewInt(1))
+	s.SwapV2.PairAddOrder(1, 0, helpers.BipToPip(big.NewInt(20)), helpers.BipToPip(big.NewInt(10)), types.Address{1}, 1)
+	s.bus.Events().AddEvent(&eventsdb.OrderExpiredEvent{ID: 1})
+	revert()
+
+	if events := s.events.LoadEvents(0); len(events) != 0 {
+		t.Fatalf("events of reverted changes are added: %d", len(events))
+	}
+
+	hash, err := s.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHash, err := expected.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(hash, expectedHash) {
+		t.Fatalf("state is not reverted: hash %X, expected %X", hash, expectedHash)
+	}
+
+	_, keep := s.Snapshot()
+	s.bus.Events().AddEvent(&eventsdb.OrderExpiredEvent{ID: 1})
+	keep()
+
+	if events := s.events.LoadEvents(0); len(events) != 1 {
+		t.Fatalf("events of kept changes are not added: %d", len(events))
+	}
+}
+
+func TestCheckStateWithTransfer(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from, to := types.Address{1}, types.Address{2}
+	s.Accounts.AddBalance(from, 0, helpers.BipToPip(big.NewInt(100)))
+
+	cs := NewCheckState(s).WithTransfer(from, to, 0, helpers.BipToPip(big.NewInt(30)))
+	if balance := cs.Accounts().GetBalance(from, 0); balance.Cmp(helpers.BipToPip(big.NewInt(70))) != 0 {
+		t.Fatalf("balance of sender of transfer is not debited: %s", balance)
+	}
+	if balance := cs.Accounts().GetBalance(to, 0); balance.Cmp(helpers.BipToPip(big.NewInt(30))) != 0 {
+		t.Fatalf("balance of receiver of transfer is not credited: %s", balance)
+	}
+	if balance := s.Accounts.GetBalance(from, 0); balance.Cmp(helpers.BipToPip(big.NewInt(100))) != 0 {
+		t.Fatalf("state is changed by transfer: %s", balance)
+	}
+
+	branch, discard := cs.Branch()
+	if balance := branch.Accounts.GetBalance(from, 0); balance.Cmp(helpers.BipToPip(big.NewInt(70))) != 0 {
+		t.Fatalf("balance of sender of transfer is not debited in branch: %s", balance)
+	}
+	if balance := branch.Accounts.GetBalance(to, 0); balance.Cmp(helpers.BipToPip(big.NewInt(30))) != 0 {
+		t.Fatalf("balance of receiver of transfer is not credited in branch: %s", balance)
+	}
+	discard()
+
+	if balance := s.Accounts.GetBalance(to, 0); balance.Sign() != 0 {
+		t.Fatalf("branch is not discarded: %s", balance)
+	}
+}
@@ -0,0 +1,309 @@
+package swap
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+// ObservationsWindow is the maximum number of blocks of the window for calculating TWAP, about a week
+const ObservationsWindow = 17280 * 7
+
+// ObservationsGranularity is the number of blocks of the period of the ring of observations,
+// only the first and the last observations of the pool in the period are kept
+const ObservationsGranularity = 240
+
+// ObservationsCardinality is the number of the last observations kept for every pool, the oldest one is overwritten by the next one.
+// Two observations of the period take ObservationsCardinality/2*ObservationsGranularity blocks, it is more than ObservationsWindow
+const ObservationsCardinality = 1024
+
+// priceResolution is the number of fractional bits of the cumulative prices
+const priceResolution = 112
+
+var (
+	ErrorObservationNotExist = errors.New("OBSERVATION_NOT_EXISTS")
+	ErrorWrongTWAPWindow     = errors.New("WRONG_TWAP_WINDOW")
+)
+
+// Observation is the state of the price accumulators of the pool at the height of its last change.
+// Price0Cumulative is the sum of the prices of Coin0 in Coin1 at the end of every block before Height
+// in the 112-bit fixed point format, Price1Cumulative is the same for the price of Coin1 in Coin0
+type Observation struct {
+	Price0Cumulative *big.Int
+	Price1Cumulative *big.Int
+	Reserve0         *big.Int
+	Reserve1         *big.Int
+	Height           uint64
+}
+
+// observationsHead is the position of the last observation of the pool in its ring of observations
+type observationsHead struct {
+	Index uint16
+	Count uint16
+}
+
+// position returns the index in the ring of the i-th observation counting from the oldest one
+func (h *observationsHead) position(i uint16) uint16 {
+	return uint16((uint32(h.Index) + 1 + uint32(i)) % uint32(h.Count))
+}
+
+// CumulativeAt returns the accumulators at the height, the prices are not changed since the observation
+func (o *Observation) CumulativeAt(height uint64) (price0Cumulative, price1Cumulative *big.Int) {
+	price0Cumulative, price1Cumulative = new(big.Int).Set(o.Price0Cumulative), new(big.Int).Set(o.Price1Cumulative)
+	if height <= o.Height || o.Reserve0.Sign() != 1 || o.Reserve1.Sign() != 1 {
+		return price0Cumulative, price1Cumulative
+	}
+
+	blocks := new(big.Int).SetUint64(height - o.Height)
+	price0 := new(big.Int).Div(new(big.Int).Lsh(o.Reserve1, priceResolution), o.Reserve0)
+	price1 := new(big.Int).Div(new(big.Int).Lsh(o.Reserve0, priceResolution), o.Reserve1)
+
+	return price0Cumulative.Add(price0Cumulative, price0.Mul(price0, blocks)), price1Cumulative.Add(price1Cumulative, price1.Mul(price1, blocks))
+}
+
+func (o *Observation) reverse() *Observation {
+	return &Observation{
+		Price0Cumulative: o.Price1Cumulative,
+		Price1Cumulative: o.Price0Cumulative,
+		Reserve0:         o.Reserve1,
+		Reserve1:         o.Reserve0,
+		Height:           o.Height,
+	}
+}
+
+func pathObservationsHead(id uint32) []byte {
+	return append([]byte{mainPrefix, pairObservationPrefix}, id2Bytes(id)...)
+}
+
+func pathObservation(id uint32, index uint16) []byte {
+	i := make([]byte, 2)
+	binary.BigEndian.PutUint16(i, index)
+	return append(pathObservationsHead(id), i...)
+}
+
+// GetObservation returns the last observation of the pool at or before the height
+func (s *SwapV2) GetObservation(coin0, coin1 types.CoinID, height uint64) *Observation {
+	pair := s.Pair(coin0, coin1)
+	if pair == nil {
+		return nil
+	}
+
+	observation := s.loadObservation(pair.GetID(), height)
+	if observation == nil {
+		return nil
+	}
+	if !pair.isSorted() {
+		return observation.reverse()
+	}
+	return observation
+}
+
+// TWAP returns the time-weighted average price of coin0 in coin1 at the end of blocks from fromHeight to toHeight-1.
+// The state must be not older than toHeight and the observation at fromHeight must be not overwritten yet.
+// The prices between the kept observations are taken from the earlier one, so the ends of the window
+// are accurate to ObservationsGranularity blocks if the pool changes more often
+func (s *SwapV2) TWAP(coin0, coin1 types.CoinID, fromHeight, toHeight uint64) (*big.Rat, error) {
+	if fromHeight >= toHeight {
+		return nil, ErrorWrongTWAPWindow
+	}
+
+	if s.Pair(coin0, coin1) == nil {
+		return nil, ErrorNotExist
+	}
+
+	from := s.GetObservation(coin0, coin1, fromHeight)
+	to := s.GetObservation(coin0, coin1, toHeight)
+	if from == nil || to == nil {
+		return nil, ErrorObservationNotExist
+	}
+
+	fromCumulative, _ := from.CumulativeAt(fromHeight)
+	toCumulative, _ := to.CumulativeAt(toHeight)
+
+	return new(big.Rat).SetFrac(
+		new(big.Int).Sub(toCumulative, fromCumulative),
+		new(big.Int).Lsh(new(big.Int).SetUint64(toHeight-fromHeight), priceResolution),
+	), nil
+}
+
+func (s *SwapV2) loadObservationsHead(id uint32) *observationsHead {
+	s.muObservations.Lock()
+	defer s.muObservations.Unlock()
+
+	if head, ok := s.observationsHeads[id]; ok {
+		return head
+	}
+
+	_, value := s.immutableTree().Get(pathObservationsHead(id))
+	if len(value) == 0 {
+		return nil
+	}
+
+	head := &observationsHead{}
+	if err := rlp.DecodeBytes(value, head); err != nil {
+		panic(err)
+	}
+	s.observationsHeads[id] = head
+
+	return head
+}
+
+func (s *SwapV2) loadObservationAt(id uint32, index uint16) *Observation {
+	_, value := s.immutableTree().Get(pathObservation(id, index))
+	if len(value) == 0 {
+		return nil
+	}
+
+	observation := &Observation{}
+	if err := rlp.DecodeBytes(value, observation); err != nil {
+		panic(err)
+	}
+
+	return observation
+}
+
+// loadObservation returns the last saved observation of the pool at or before the height,
+// the ring is ordered by heights, so it is found by binary search
+func (s *SwapV2) loadObservation(id uint32, height uint64) *Observation {
+	head := s.loadObservationsHead(id)
+	if head == nil || head.Count == 0 {
+		return nil
+	}
+
+	var observation *Observation
+	low, high := 0, int(head.Count)-1
+	for low <= high {
+		mid := (low + high) / 2
+		current := s.loadObservationAt(id, head.position(uint16(mid)))
+		if current.Height > height {
+			high = mid - 1
+			continue
+		}
+		observation = current
+		low = mid + 1
+	}
+
+	return observation
+}
+
+func (s *SwapV2) lastObservation(id uint32) *Observation {
+	s.muObservations.Lock()
+	observation, ok := s.observations[id]
+	s.muObservations.Unlock()
+	if ok {
+		return observation
+	}
+
+	return s.loadObservation(id, math.MaxUint64)
+}
+
+// Observe accumulates the prices of the pools changed in the block since their last observations
+// and makes the new ones with the reserves at the end of the block, they are saved on commit
+func (s *SwapV2) Observe(height uint64) {
+	s.muPairs.RLock()
+	defer s.muPairs.RUnlock()
+
+	for _, key := range s.getOrderedDirtyPairs() {
+		pair, _ := s.pair(key)
+		id := pair.GetID()
+
+		observation := &Observation{Price0Cumulative: big.NewInt(0), Price1Cumulative: big.NewInt(0), Height: height}
+		if last := s.lastObservation(id); last != nil {
+			observation.Price0Cumulative, observation.Price1Cumulative = last.CumulativeAt(height)
+		}
+		observation.Reserve0, observation.Reserve1 = pair.Reserves()
+
+		s.muObservations.Lock()
+		s.observations[id] = observation
+		s.dirtyObservations[id] = struct{}{}
+		s.muObservations.Unlock()
+	}
+}
+
+// commitObservations saves the new observations over the oldest ones in the rings of the pools.
+// The last observation of the period is overwritten by the next one of the same period, so the ring keeps
+// the first and the last observations of every period and covers ObservationsWindow however often the pool changes
+func (s *SwapV2) commitObservations(db *iavl.MutableTree) {
+	s.muObservations.Lock()
+	ids := make([]uint32, 0, len(s.dirtyObservations))
+	for id := range s.dirtyObservations {
+		ids = append(ids, id)
+	}
+	s.dirtyObservations = map[uint32]struct{}{}
+	s.muObservations.Unlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		s.muObservations.Lock()
+		observation := s.observations[id]
+		s.muObservations.Unlock()
+
+		head := &observationsHead{Index: ObservationsCardinality - 1}
+		if last := s.loadObservationsHead(id); last != nil {
+			*head = *last
+		}
+		if !s.isLastOfPeriod(id, head, observation.Height/ObservationsGranularity) {
+			head.Index = (head.Index + 1) % ObservationsCardinality
+			if head.Count < ObservationsCardinality {
+				head.Count++
+			}
+		}
+
+		s.muObservations.Lock()
+		s.observationsHeads[id] = head
+		s.muObservations.Unlock()
+
+		data, err := rlp.EncodeToBytes(observation)
+		if err != nil {
+			panic(err)
+		}
+		db.Set(pathObservation(id, head.Index), data)
+
+		headData, err := rlp.EncodeToBytes(head)
+		if err != nil {
+			panic(err)
+		}
+		db.Set(pathObservationsHead(id), headData)
+	}
+}
+
+// isLastOfPeriod returns true if the head of the ring is the observation of the period, which is not the first one
+func (s *SwapV2) isLastOfPeriod(id uint32, head *observationsHead, period uint64) bool {
+	if head.Count < 2 {
+		return false
+	}
+
+	previous := (head.Index + ObservationsCardinality - 1) % ObservationsCardinality
+	return s.loadObservationAt(id, head.Index).Height/ObservationsGranularity == period &&
+		s.loadObservationAt(id, previous).Height/ObservationsGranularity == period
+}
+
+func (s *SwapV2) importObservation(id uint32, pool types.Pool) {
+	if pool.PriceCumulativeHeight == 0 {
+		return
+	}
+
+	reserve0, reserve1 := helpers.StringToBigInt(pool.Reserve0), helpers.StringToBigInt(pool.Reserve1)
+
+	s.muObservations.Lock()
+	defer s.muObservations.Unlock()
+
+	s.observations[id] = &Observation{
+		Price0Cumulative: helpers.StringToBigInt(pool.Price0Cumulative),
+		Price1Cumulative: helpers.StringToBigInt(pool.Price1Cumulative),
+		Reserve0:         reserve0,
+		Reserve1:         reserve1,
+		Height:           pool.PriceCumulativeHeight,
+	}
+	s.dirtyObservations[id] = struct{}{}
+}
@@ -0,0 +1,212 @@
+package swap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestSwapV2_TWAP(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	commit := func() {
+		swap.Observe(uint64(immutableTree.Version()) + 1)
+		if _, _, err := immutableTree.Commit(swap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	swap.PairCreate(0, 2, big.NewInt(1e18), big.NewInt(2e18))
+
+	for i := 0; i < 3; i++ {
+		commit()
+	}
+
+	swap.PairSell(0, 2, big.NewInt(1e18), big.NewInt(0))
+	commit()
+
+	if _, err := swap.TWAP(0, 2, 4, 4); err != ErrorWrongTWAPWindow {
+		t.Fatalf("error %v is not %v", err, ErrorWrongTWAPWindow)
+	}
+
+	price, err := swap.TWAP(0, 2, 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Fatalf("TWAP %s is not 2", price.FloatString(18))
+	}
+
+	price, err = swap.TWAP(2, 0, 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cmp(big.NewRat(1, 2)) != 0 {
+		t.Fatalf("TWAP %s is not 0.5", price.FloatString(18))
+	}
+
+	price, err = swap.TWAP(0, 2, 1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cmp(swap.Pair(0, 2).PriceRat()) != 1 || price.Cmp(big.NewRat(2, 1)) != -1 {
+		t.Fatalf("TWAP %s is not between the prices", price.FloatString(18))
+	}
+
+	state := &types.AppState{}
+	swap.Export(state)
+	if len(state.Pools) != 1 || state.Pools[0].PriceCumulativeHeight != 4 {
+		t.Fatalf("accumulators are not exported: %+v", state.Pools)
+	}
+
+	importedTree, err := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	importedBus := bus.NewBus()
+	checker.NewChecker(importedBus)
+	importedSwap := NewV2(importedBus, importedTree.GetLastImmutable())
+	importedSwap.Import(state)
+	if _, _, err = importedTree.Commit(importedSwap); err != nil {
+		t.Fatal(err)
+	}
+
+	if importedSwap.GetObservation(0, 2, 3) != nil {
+		t.Fatal("observation before the export is imported")
+	}
+	observation := importedSwap.GetObservation(0, 2, 4)
+	if observation == nil {
+		t.Fatal("observation is not imported")
+	}
+	if observation.Price0Cumulative.String() != state.Pools[0].Price0Cumulative || observation.Price1Cumulative.String() != state.Pools[0].Price1Cumulative {
+		t.Fatalf("accumulators %s, %s are not imported", observation.Price0Cumulative, observation.Price1Cumulative)
+	}
+}
+
+func TestSwapV2_Observe_ring(t *testing.T) {
+	immutableTree, err := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	swap.PairCreate(0, 2, big.NewInt(1e18), big.NewInt(1e18))
+
+	blocks := 3*ObservationsGranularity + 10
+	for i := 0; i < blocks; i++ {
+		if i != 0 {
+			swap.PairSell(0, 2, big.NewInt(1e12), big.NewInt(0))
+		}
+		swap.Observe(uint64(immutableTree.Version()) + 1)
+		if _, _, err = immutableTree.Commit(swap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the first and the last observations of 4 periods and the head of the ring
+	id := swap.Pair(0, 2).GetID()
+	count := 0
+	immutableTree.GetLastImmutable().IterateRange(pathObservationsHead(id), pathObservationsHead(id+1), true, func(key []byte, value []byte) bool {
+		count++
+		return false
+	})
+	if count != 9 {
+		t.Fatalf("%d keys of observations are saved, want %d", count, 9)
+	}
+
+	tests := []struct {
+		height uint64
+		want   uint64
+	}{
+		{height: 1, want: 1},
+		{height: 100, want: 1},
+		{height: ObservationsGranularity - 1, want: ObservationsGranularity - 1},
+		{height: ObservationsGranularity + 100, want: ObservationsGranularity},
+		{height: 2*ObservationsGranularity - 1, want: 2*ObservationsGranularity - 1},
+		{height: uint64(blocks) - 1, want: 3 * ObservationsGranularity},
+		{height: uint64(blocks), want: uint64(blocks)},
+		{height: uint64(blocks) + 5, want: uint64(blocks)},
+	}
+	for _, test := range tests {
+		observation := swap.GetObservation(0, 2, test.height)
+		if observation == nil || observation.Height != test.want {
+			t.Errorf("observation at %d is %+v, want height %d", test.height, observation, test.want)
+		}
+	}
+}
+
+func TestSwapV2_TWAP_window(t *testing.T) {
+	immutableTree, err := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	swap.PairCreate(0, 2, big.NewInt(1e18), big.NewInt(1e18))
+
+	// the pool changes more often than two times in the period, so the ring is overwritten after ObservationsCardinality/2 periods
+	const step = ObservationsGranularity / 8
+	type change struct {
+		height uint64
+		price  *big.Rat
+	}
+	var changes []change
+	last := uint64(ObservationsCardinality/2*ObservationsGranularity + 4*ObservationsGranularity)
+	for height := uint64(1); height <= last; height += step {
+		if height != 1 {
+			swap.PairSell(2, 0, big.NewInt(1e14), big.NewInt(0))
+		}
+		swap.Observe(height)
+		if _, _, err = immutableTree.Commit(swap); err != nil {
+			t.Fatal(err)
+		}
+		changes = append(changes, change{height: height, price: swap.Pair(0, 2).PriceRat()})
+	}
+	toHeight := changes[len(changes)-1].height
+
+	if _, err := swap.TWAP(0, 2, ObservationsGranularity, toHeight); err != ErrorObservationNotExist {
+		t.Fatalf("error %v is not %v", err, ErrorObservationNotExist)
+	}
+
+	fromHeight := toHeight - ObservationsWindow
+	price, err := swap.TWAP(0, 2, fromHeight, toHeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the price at the end of the block is the price after the last change at or before it
+	sum := new(big.Float).SetPrec(Precision)
+	for i, c := range changes {
+		end := toHeight
+		if i+1 < len(changes) {
+			end = changes[i+1].height
+		}
+		start := c.height
+		if start < fromHeight {
+			start = fromHeight
+		}
+		if end <= start {
+			continue
+		}
+		sum.Add(sum, new(big.Float).SetPrec(Precision).Mul(new(big.Float).SetRat(c.price), new(big.Float).SetUint64(end-start)))
+	}
+	want := sum.Quo(sum, new(big.Float).SetUint64(ObservationsWindow))
+
+	diff := new(big.Float).Quo(new(big.Float).Sub(new(big.Float).SetRat(price), want), want)
+	if diff.Abs(diff).Cmp(big.NewFloat(0.001)) == 1 {
+		t.Fatalf("TWAP %s of the window differs from %s", price.FloatString(18), want.Text('f', 18))
+	}
+}
@@ -316,7 +316,28 @@ func (p *Pair) CalculateBuyForSellWithOrders(amount0In *big.Int) (amount1Out *bi
 	return amount1Out, orders
 }
 
+// CalculateSellForPriceWithOrders returns the part of amount0In which is matched with the orders and the pool without lowering the price below minPrice
+func (p *Pair) CalculateSellForPriceWithOrders(amount0In *big.Int, minPrice *big.Rat) (amount0 *big.Int) {
+	if amount0In == nil || amount0In.Sign() != 1 {
+		return big.NewInt(0)
+	}
+
+	p.lockOrders.Lock()
+	defer p.lockOrders.Unlock()
+
+	_, _, amountRest := p.calculateBuyForSellWithOrdersToPrice(amount0In, minPrice)
+
+	return big.NewInt(0).Sub(amount0In, amountRest)
+}
+
 func (p *Pair) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big.Int, orders []*Limit) {
+	amountOut, orders, _ = p.calculateBuyForSellWithOrdersToPrice(amount0In, nil)
+	return amountOut, orders
+}
+
+// calculateBuyForSellWithOrdersToPrice matches amount0In while the price is not less than minPrice, nil minPrice is not limited.
+// The unmatched part of amount0In is returned as amountRest
+func (p *Pair) calculateBuyForSellWithOrdersToPrice(amount0In *big.Int, minPrice *big.Rat) (amountOut *big.Int, orders []*Limit, amountRest *big.Int) {
 	amountOut = big.NewInt(0)
 	amountIn := big.NewInt(0).Set(amount0In)
 	var pair EditableChecker = p
@@ -325,7 +346,7 @@ func (p *Pair) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big
 			log.Println("wrong amountIn.Sign() == -1", pair.GetID(), fmt.Sprint(amountIn, amountOut))
 		}
 		if amountIn.Sign() == 0 {
-			return amountOut, orders
+			return amountOut, orders, big.NewInt(0)
 		}
 
 		limit := p.orderSellByIndex(i)
@@ -333,6 +354,10 @@ func (p *Pair) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big
 			break
 		}
 
+		if minPrice != nil && limit.PriceRat().Cmp(minPrice) == -1 {
+			break
+		}
+
 		price := limit.Price()
 		if pair.PriceRatCmp(limit.PriceRat()) == 1 {
 			reserve0diff, reserve1diff := pair.CalculateAddAmountsForPrice(price)
@@ -399,7 +424,7 @@ func (p *Pair) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big
 
 			comB := calcCommission1000(amount1)
 			amountOut.Add(amountOut, big.NewInt(0).Sub(amount1, comB)) // 999
-			return amountOut, orders
+			return amountOut, orders, big.NewInt(0)
 		}
 
 		orders = append(orders, &Limit{
@@ -422,6 +447,23 @@ func (p *Pair) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big
 		amountIn = big.NewInt(0).Sub(amountIn, big.NewInt(0).Add(limit.WantBuy, comS))
 	}
 
+	amountRest = big.NewInt(0)
+	if minPrice != nil {
+		if pair.PriceRatCmp(minPrice) != 1 {
+			return amountOut, orders, amountIn
+		}
+
+		reserve0diff, _ := pair.CalculateAddAmountsForPrice(new(big.Float).SetPrec(Precision).SetRat(minPrice))
+		if reserve0diff == nil {
+			return amountOut, orders, amountIn
+		}
+
+		if amountIn.Cmp(reserve0diff) == 1 {
+			amountRest.Sub(amountIn, reserve0diff)
+			amountIn = reserve0diff
+		}
+	}
+
 	amount1diff := pair.CalculateBuyForSell(amountIn)
 	if amount1diff != nil {
 		if err := pair.CheckSwap(amountIn, amount1diff); err != nil {
@@ -430,7 +472,7 @@ func (p *Pair) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big
 		}
 		amountOut.Add(amountOut, amount1diff)
 	}
-	return amountOut, orders
+	return amountOut, orders, amountRest
 }
 
 func calcCommission1000(amount0 *big.Int) *big.Int {
@@ -655,6 +697,8 @@ type Limit struct {
 	Height   uint64
 
 	PairKey
+	ExpireHeight []uint64 `rlp:"tail"`
+
 	oldSortPrice *big.Float
 	id           uint32
 
@@ -681,6 +725,14 @@ func (l *Limit) GetHeight() uint64 {
 	return l.Height
 }
 
+// GetExpireHeight returns the last height of the order set by its owner, 0 if the order is expired by the global period
+func (l *Limit) GetExpireHeight() uint64 {
+	if len(l.ExpireHeight) > 0 {
+		return l.ExpireHeight[0]
+	}
+	return 0
+}
+
 func (l *Limit) ID() uint32 {
 	if l == nil {
 		return 0
@@ -707,6 +759,34 @@ type limits struct {
 	ids []uint32
 }
 
+func (l *limits) copyIDs() []uint32 {
+	return append([]uint32(nil), l.ids...)
+}
+
+// snapshot returns the function which reverts volumes of the order, they are changed in place on match
+func (l *Limit) snapshot() (revert func()) {
+	l.mu.RLock()
+	wantBuy, wantSell := new(big.Int).Set(l.WantBuy), new(big.Int).Set(l.WantSell)
+	expireHeight, oldSortPrice := l.ExpireHeight, l.oldSortPrice
+	var oldSortPriceValue *big.Float
+	if oldSortPrice != nil {
+		oldSortPriceValue = new(big.Float).Copy(oldSortPrice)
+	}
+	l.mu.RUnlock()
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.WantBuy.Set(wantBuy)
+		l.WantSell.Set(wantSell)
+		l.ExpireHeight, l.oldSortPrice = expireHeight, oldSortPrice
+		if oldSortPrice != nil {
+			oldSortPrice.Copy(oldSortPriceValue)
+		}
+	}
+}
+
 type orderList struct {
 	mu   sync.RWMutex
 	list map[uint32]*Limit
@@ -874,6 +954,7 @@ func (l *Limit) reverse() *Limit {
 		WantSell:     l.WantBuy,
 		Owner:        l.Owner,
 		Height:       l.Height,
+		ExpireHeight: l.ExpireHeight,
 		oldSortPrice: l.oldSortPrice,
 		id:           l.id,
 		mu:           l.mu,
@@ -894,6 +975,7 @@ func (l *Limit) Reverse() *Limit {
 		WantSell:     l.WantBuy,
 		Owner:        l.Owner,
 		Height:       l.Height,
+		ExpireHeight: l.ExpireHeight,
 		oldSortPrice: l.oldSortPrice,
 		id:           l.id,
 		mu:           l.mu,
@@ -927,6 +1009,7 @@ func (l *Limit) clone() *Limit {
 		WantSell:     big.NewInt(0).Set(l.WantSell),
 		Owner:        l.Owner,
 		Height:       l.Height,
+		ExpireHeight: l.ExpireHeight,
 		oldSortPrice: new(big.Float).SetPrec(Precision).Set(l.oldSortPrice),
 		id:           l.id,
 		mu:           &sync.RWMutex{},
@@ -1292,6 +1375,21 @@ func (s *Swap) loadBuyOrders(pair *Pair, fromOrder *Limit, limit int) []uint32 {
 	return slice
 }
 
+// GetTriggerOrder returns nil, trigger orders are stored by SwapV2 only
+func (s *Swap) GetTriggerOrder(id uint32) *TriggerOrder {
+	return nil
+}
+
+// GetTriggerOrders returns nil, trigger orders are stored by SwapV2 only
+func (s *Swap) GetTriggerOrders() []*TriggerOrder {
+	return nil
+}
+
+// TWAP returns ErrorObservationNotExist, price observations are stored by SwapV2 only
+func (s *Swap) TWAP(coin0, coin1 types.CoinID, fromHeight, toHeight uint64) (*big.Rat, error) {
+	return nil, ErrorObservationNotExist
+}
+
 func (s *Swap) GetOrder(id uint32) *Limit {
 	order := s.loadOrder(id)
 	if order == nil {
@@ -1334,6 +1432,11 @@ func (s *Swap) loadOrder(id uint32) *Limit {
 		panic(err)
 	}
 
+	// the empty tail is decoded as the empty slice
+	if len(order.ExpireHeight) == 0 {
+		order.ExpireHeight = nil
+	}
+
 	order.reCalcOldSortPrice()
 
 	return order
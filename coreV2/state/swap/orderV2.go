@@ -204,7 +204,42 @@ func (p *PairV2) CalculateBuyForSellWithOrders(amount0In *big.Int) (amount1Out *
 	return amount1Out, orders
 }
 
+// CalculateSellForPriceWithOrders returns the part of amount0In which is matched with the orders and the pool without lowering the price below minPrice
+func (p *PairV2) CalculateSellForPriceWithOrders(amount0In *big.Int, minPrice *big.Rat) (amount0 *big.Int) {
+	if amount0In == nil || amount0In.Sign() != 1 {
+		return big.NewInt(0)
+	}
+	amount0 = big.NewInt(0).Sub(amount0In, calcCommission1000(amount0In))
+
+	p.lockOrders.Lock()
+	defer p.lockOrders.Unlock()
+
+	_, _, amountRest := p.calculateBuyForSellWithOrdersToPrice(amount0, minPrice)
+	if amountRest.Sign() == 0 {
+		return big.NewInt(0).Set(amount0In)
+	}
+
+	amount0.Sub(amount0, amountRest)
+	if amount0.Sign() != 1 {
+		return big.NewInt(0)
+	}
+
+	amount0.Add(amount0, calcCommission0999(amount0))
+	if amount0.Cmp(amount0In) == 1 {
+		amount0.Set(amount0In)
+	}
+
+	return amount0
+}
+
 func (p *PairV2) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *big.Int, orders []*Limit) {
+	amountOut, orders, _ = p.calculateBuyForSellWithOrdersToPrice(amount0In, nil)
+	return amountOut, orders
+}
+
+// calculateBuyForSellWithOrdersToPrice matches amount0In while the price is not less than minPrice, nil minPrice is not limited.
+// The unmatched part of amount0In is returned as amountRest
+func (p *PairV2) calculateBuyForSellWithOrdersToPrice(amount0In *big.Int, minPrice *big.Rat) (amountOut *big.Int, orders []*Limit, amountRest *big.Int) {
 	amountOut = big.NewInt(0)
 	amountIn := big.NewInt(0).Set(amount0In)
 	var pair EditableChecker = p
@@ -213,7 +248,7 @@ func (p *PairV2) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *b
 			log.Println("wrong amountIn.Sign() == -1", pair.GetID(), fmt.Sprint(amountIn, amountOut))
 		}
 		if amountIn.Sign() == 0 {
-			return amountOut, orders
+			return amountOut, orders, big.NewInt(0)
 		}
 
 		limit := p.orderSellByIndex(i)
@@ -221,6 +256,10 @@ func (p *PairV2) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *b
 			break
 		}
 
+		if minPrice != nil && limit.PriceRat().Cmp(minPrice) == -1 {
+			break
+		}
+
 		price := limit.Price()
 		if pair.PriceRatCmp(limit.PriceRat()) == 1 {
 			reserve0diff, reserve1diff := pair.CalculateAddAmountsForPrice(price)
@@ -287,7 +326,7 @@ func (p *PairV2) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *b
 
 			comB := calcCommission1000(amount1)
 			amountOut.Add(amountOut, big.NewInt(0).Sub(amount1, comB)) // 999
-			return amountOut, orders
+			return amountOut, orders, big.NewInt(0)
 		}
 
 		orders = append(orders, &Limit{
@@ -310,6 +349,23 @@ func (p *PairV2) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *b
 		amountIn = big.NewInt(0).Sub(amountIn, big.NewInt(0).Add(limit.WantBuy, comS))
 	}
 
+	amountRest = big.NewInt(0)
+	if minPrice != nil {
+		if pair.PriceRatCmp(minPrice) != 1 {
+			return amountOut, orders, amountIn
+		}
+
+		reserve0diff, _ := pair.CalculateAddAmountsForPrice(new(big.Float).SetPrec(Precision).SetRat(minPrice))
+		if reserve0diff == nil {
+			return amountOut, orders, amountIn
+		}
+
+		if amountIn.Cmp(reserve0diff) == 1 {
+			amountRest.Sub(amountIn, reserve0diff)
+			amountIn = reserve0diff
+		}
+	}
+
 	amount1diff := pair.CalculateBuyForSell(amountIn)
 	if amount1diff != nil {
 		if err := pair.CheckSwap(amountIn, amount1diff); err != nil {
@@ -318,7 +374,7 @@ func (p *PairV2) calculateBuyForSellWithOrders(amount0In *big.Int) (amountOut *b
 		}
 		amountOut.Add(amountOut, amount1diff)
 	}
-	return amountOut, orders
+	return amountOut, orders, amountRest
 }
 
 func (p *PairV2) CalculateAddAmountsForPrice(price *big.Float) (amount0In, amount1Out *big.Int) {
@@ -726,8 +782,13 @@ func (p *PairV2) getDirtyOrdersList() []uint32 {
 }
 
 func (s *SwapV2) PairAddOrder(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64) (uint32, uint32) {
+	return s.PairAddOrderWithExpiry(coinWantBuy, coinWantSell, wantBuyAmount, wantSellAmount, sender, block, 0)
+}
+
+// PairAddOrderWithExpiry adds the order which is expired after expireHeight instead of the global period, 0 expireHeight means the global period
+func (s *SwapV2) PairAddOrderWithExpiry(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64, expireHeight uint64) (uint32, uint32) {
 	pair := s.Pair(coinWantBuy, coinWantSell)
-	order := pair.AddOrder(wantBuyAmount, wantSellAmount, sender, block)
+	order := pair.AddOrderWithExpiry(wantBuyAmount, wantSellAmount, sender, block, expireHeight)
 
 	s.bus.Checker().AddCoin(coinWantSell, wantSellAmount)
 
@@ -778,7 +839,7 @@ func (s *SwapV2) removeLimitOrder(order *Limit) (types.CoinID, *big.Int) {
 
 func (s *SwapV2) pairAddOrderWithID(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, id uint32, height uint64) (uint32, uint32) {
 	pair := s.Pair(coinWantBuy, coinWantSell)
-	order := pair.addOrderWithID(wantBuyAmount, wantSellAmount, sender, id, height)
+	order := pair.addOrderWithID(wantBuyAmount, wantSellAmount, sender, id, height, 0)
 
 	s.bus.Checker().AddCoin(coinWantSell, wantSellAmount)
 
@@ -805,6 +866,10 @@ func (p *PairV2) isOrderAlreadyUsed(id uint32) bool {
 }
 
 func (p *PairV2) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, block uint64) (order *Limit) {
+	return p.AddOrderWithExpiry(wantBuyAmount0, wantSellAmount1, sender, block, 0)
+}
+
+func (p *PairV2) AddOrderWithExpiry(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, block uint64, expireHeight uint64) (order *Limit) {
 	order = &Limit{
 		PairKey:      p.PairKey,
 		IsBuy:        false,
@@ -815,6 +880,7 @@ func (p *PairV2) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types
 		Owner:        sender,
 		mu:           new(sync.RWMutex),
 		Height:       block,
+		ExpireHeight: orderExpireHeight(expireHeight),
 	}
 	sortedOrder := order.sort()
 
@@ -828,7 +894,7 @@ func (p *PairV2) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types
 	return order
 }
 
-func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, id uint32, height uint64) (order *Limit) {
+func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, id uint32, height uint64, expireHeight uint64) (order *Limit) {
 	order = &Limit{
 		PairKey:      p.PairKey,
 		IsBuy:        false,
@@ -838,6 +904,7 @@ func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender
 		oldSortPrice: new(big.Float).SetPrec(Precision),
 		Owner:        sender,
 		Height:       height,
+		ExpireHeight: orderExpireHeight(expireHeight),
 		mu:           new(sync.RWMutex),
 	}
 	sortedOrder := order.sort()
@@ -848,6 +915,13 @@ func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender
 	return order
 }
 
+func orderExpireHeight(expireHeight uint64) []uint64 {
+	if expireHeight == 0 {
+		return nil
+	}
+	return []uint64{expireHeight}
+}
+
 func (p *PairV2) loadAllOrders(immutableTree *iavl.ImmutableTree) (orders []*Limit) {
 	const countFirstBytes = 10
 
@@ -982,6 +1056,11 @@ func (s *SwapV2) loadOrder(id uint32) *Limit {
 		panic(err)
 	}
 
+	// the empty tail is decoded as the empty slice
+	if len(order.ExpireHeight) == 0 {
+		order.ExpireHeight = nil
+	}
+
 	order.reCalcOldSortPrice()
 
 	return order
@@ -54,6 +54,7 @@ type EditableChecker interface {
 	// Deprecated
 	CalculateBuyForSell(amount0In *big.Int) (amount1Out *big.Int)
 	CalculateBuyForSellWithOrders(amount0In *big.Int) (amount1Out *big.Int, orders []*Limit)
+	CalculateSellForPriceWithOrders(amount0In *big.Int, minPrice *big.Rat) (amount0 *big.Int)
 	// Deprecated
 	CalculateSellForBuy(amount1Out *big.Int) (amount0In *big.Int)
 	CalculateSellForBuyWithOrders(amount1Out *big.Int) (amount0In *big.Int, orders []*Limit)
@@ -73,6 +74,9 @@ type RSwap interface {
 
 	SwapPools(context.Context) []EditableChecker
 	GetOrder(id uint32) *Limit
+	GetTriggerOrder(id uint32) *TriggerOrder
+	GetTriggerOrders() []*TriggerOrder
+	TWAP(coin0, coin1 types.CoinID, fromHeight, toHeight uint64) (*big.Rat, error)
 	Export(state *types.AppState)
 	SwapPool(coin0, coin1 types.CoinID) (reserve0, reserve1 *big.Int, id uint32)
 	GetSwapper(coin0, coin1 types.CoinID) EditableChecker
@@ -390,6 +394,10 @@ const pairLimitOrderPrefix = 'l'
 const pairOrdersPrefix = 'o'
 const totalPairIDPrefix = 'i'
 const totalOrdersIDPrefix = 'n'
+const pairOrderExpiryPrefix = 'e'
+const triggerOrderPrefix = 't'
+const triggerOrderPricePrefix = 'p'
+const pairObservationPrefix = 'w'
 
 type pairData struct {
 	mu        *sync.RWMutex
@@ -525,6 +533,13 @@ func pathOrder(id uint32) []byte {
 	return append([]byte{pairLimitOrderPrefix}, byteID...)
 }
 
+// pathOrderExpiry is the key of the order with the explicit expiry height, ordered by the height
+func pathOrderExpiry(height uint64, id uint32) []byte {
+	byteHeight := make([]byte, 8)
+	binary.BigEndian.PutUint64(byteHeight, height)
+	return append(append([]byte{mainPrefix, pairOrderExpiryPrefix}, byteHeight...), id2Bytes(id)...)
+}
+
 func id2Bytes(id uint32) []byte {
 	byteID := make([]byte, 4)
 	binary.BigEndian.PutUint32(byteID, id)
@@ -541,6 +556,19 @@ func id2BytesWithType(id uint32, sale bool) []byte {
 }
 
 func pricePath(key PairKey, price *big.Float, id uint32, isSale bool) []byte {
+	pricePath := sortablePrice(price)
+
+	byteID := id2BytesWithType(id, isSale)
+
+	var saleByte byte = 0
+	if isSale {
+		saleByte = 1
+	}
+	return append(append(append(append([]byte{mainPrefix}, key.pathOrders()...), saleByte), pricePath...), byteID...)
+}
+
+// sortablePrice returns the bytes of the price ordered in the same way as the prices
+func sortablePrice(price *big.Float) []byte {
 	var pricePath []byte
 
 	text := price.Text('e', 38)
@@ -584,13 +612,7 @@ func pricePath(key PairKey, price *big.Float, id uint32, isSale bool) []byte {
 
 	pricePath = append(pricePath, n3...)
 
-	byteID := id2BytesWithType(id, isSale)
-
-	var saleByte byte = 0
-	if isSale {
-		saleByte = 1
-	}
-	return append(append(append(append([]byte{mainPrefix}, key.pathOrders()...), saleByte), pricePath...), byteID...)
+	return pricePath
 }
 
 func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
@@ -39,6 +39,15 @@ type SwapV2 struct {
 	nextOrderID       uint32
 	dirtyNextOrdersID bool
 
+	muTriggerOrders    sync.Mutex
+	triggerOrders      map[uint32]*TriggerOrder
+	dirtyTriggerOrders map[uint32]struct{}
+
+	muObservations    sync.Mutex
+	observations      map[uint32]*Observation
+	dirtyObservations map[uint32]struct{}
+	observationsHeads map[uint32]*observationsHead
+
 	version int
 
 	bus *bus.Bus
@@ -186,11 +195,35 @@ func (s *SwapV2) ExpireOrders(beforeHeight uint64) {
 			return true
 		}
 
+		// orders with the explicit expiry height are expired by ExpireOrdersByHeight
+		if order.GetExpireHeight() != 0 {
+			return false
+		}
+
 		orders = append(orders, order)
 
 		return false
 	})
 
+	s.expireOrders(orders)
+}
+
+// ExpireOrdersByHeight returns to the owners the orders which explicit expiry height is less than height
+func (s *SwapV2) ExpireOrdersByHeight(height uint64) {
+	var orders []*Limit
+	s.immutableTree().IterateRange(pathOrderExpiry(0, 0), pathOrderExpiry(height, 0), true, func(key []byte, value []byte) bool {
+		id := binary.BigEndian.Uint32(key[len(key)-4:])
+		if order := s.loadOrder(id); order != nil {
+			orders = append(orders, order)
+		}
+
+		return false
+	})
+
+	s.expireOrders(orders)
+}
+
+func (s *SwapV2) expireOrders(orders []*Limit) {
 	for _, order := range orders {
 		//fmt.Println(order)
 		coin, volume := s.removeLimitOrder(order)
@@ -236,7 +269,7 @@ func (s *SwapV2) getOrderedDirtyOrderPairs() []PairKey {
 func NewV2(bus *bus.Bus, db *iavl.ImmutableTree) *SwapV2 {
 	immutableTree := atomic.Value{}
 	immutableTree.Store(db)
-	return &SwapV2{trader: &traderV2{}, pairs: map[PairKey]*PairV2{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
+	return &SwapV2{trader: &traderV2{}, pairs: map[PairKey]*PairV2{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}, triggerOrders: map[uint32]*TriggerOrder{}, dirtyTriggerOrders: map[uint32]struct{}{}, observations: map[uint32]*Observation{}, dirtyObservations: map[uint32]struct{}{}, observationsHeads: map[uint32]*observationsHead{}}
 }
 
 func (s *SwapV2) immutableTree() *iavl.ImmutableTree {
@@ -264,12 +297,13 @@ func (s *SwapV2) Export(state *types.AppState) {
 		allOrders := pair.loadAllOrders(s.immutableTree())
 		for _, limit := range allOrders {
 			orders = append(orders, types.Order{
-				IsSale:  !limit.IsBuy,
-				Volume0: limit.WantBuy.String(),
-				Volume1: limit.WantSell.String(),
-				ID:      uint64(limit.id),
-				Owner:   limit.Owner,
-				Height:  limit.Height,
+				IsSale:       !limit.IsBuy,
+				Volume0:      limit.WantBuy.String(),
+				Volume1:      limit.WantSell.String(),
+				ID:           uint64(limit.id),
+				Owner:        limit.Owner,
+				Height:       limit.Height,
+				ExpireHeight: limit.GetExpireHeight(),
 			})
 		}
 
@@ -282,6 +316,11 @@ func (s *SwapV2) Export(state *types.AppState) {
 			ID:       uint64(pair.GetID()),
 			Orders:   orders,
 		}
+		if observation := s.loadObservation(pair.GetID(), math.MaxUint64-1); observation != nil {
+			swap.Price0Cumulative = observation.Price0Cumulative.String()
+			swap.Price1Cumulative = observation.Price1Cumulative.String()
+			swap.PriceCumulativeHeight = observation.Height
+		}
 
 		state.Pools = append(state.Pools, swap)
 		state.NextOrderID = uint64(s.loadNextOrdersID())
@@ -291,6 +330,7 @@ func (s *SwapV2) Export(state *types.AppState) {
 		return strconv.Itoa(int(state.Pools[i].Coin0))+"-"+strconv.Itoa(int(state.Pools[i].Coin1)) < strconv.Itoa(int(state.Pools[j].Coin0))+"-"+strconv.Itoa(int(state.Pools[j].Coin1))
 	})
 
+	s.exportTriggerOrders(state)
 }
 
 func (s *SwapV2) Import(state *types.AppState) {
@@ -307,6 +347,7 @@ func (s *SwapV2) Import(state *types.AppState) {
 		s.bus.Checker().AddCoin(coin1, reserve1)
 		pair.markDirty()
 		s.incID()
+		s.importObservation(pair.GetID(), pool)
 		for _, order := range pool.Orders {
 			v0 := helpers.StringToBigInt(order.Volume0)
 			v1 := helpers.StringToBigInt(order.Volume1)
@@ -316,7 +357,7 @@ func (s *SwapV2) Import(state *types.AppState) {
 				v0, v1 = v1, v0
 			}
 
-			pair0.addOrderWithID(v0, v1, order.Owner, uint32(order.ID), order.Height)
+			pair0.addOrderWithID(v0, v1, order.Owner, uint32(order.ID), order.Height, order.ExpireHeight)
 			s.bus.Checker().AddCoin(pair0.Coin1(), v1)
 		}
 	}
@@ -324,6 +365,8 @@ func (s *SwapV2) Import(state *types.AppState) {
 		s.nextOrderID = uint32(state.NextOrderID)
 		s.dirtyNextOrdersID = true
 	}
+
+	s.importTriggerOrders(state)
 }
 
 func (s *SwapV2) CheckSwap(coin0, coin1 types.CoinID, amount0In, amount1Out *big.Int) error {
@@ -394,6 +437,160 @@ func (p *PairV2) reverse() *PairV2 {
 	}
 }
 
+// Snapshot returns the function which reverts all changes of pools, orders and trigger orders made after the call
+func (s *SwapV2) Snapshot() (revert func()) {
+	s.muPairs.RLock()
+	reverts := make([]func(), 0, len(s.pairs))
+	pairs := make(map[PairKey]*PairV2, len(s.pairs))
+	for key, pair := range s.pairs {
+		pairs[key] = pair
+		if pair != nil {
+			reverts = append(reverts, pair.snapshot())
+		}
+	}
+	dirties := copyPairKeys(s.dirties)
+	dirtiesOrders := copyPairKeys(s.dirtiesOrders)
+	s.muPairs.RUnlock()
+
+	s.muNextID.Lock()
+	nextID, dirtyNextID := s.nextID, s.dirtyNextID
+	s.muNextID.Unlock()
+
+	s.muNextOrdersID.Lock()
+	nextOrderID, dirtyNextOrdersID := s.nextOrderID, s.dirtyNextOrdersID
+	s.muNextOrdersID.Unlock()
+
+	// trigger orders and observations are replaced, never changed in place
+	s.muTriggerOrders.Lock()
+	triggerOrders := make(map[uint32]*TriggerOrder, len(s.triggerOrders))
+	for id, order := range s.triggerOrders {
+		triggerOrders[id] = order
+	}
+	dirtyTriggerOrders := copyIDs(s.dirtyTriggerOrders)
+	s.muTriggerOrders.Unlock()
+
+	s.muObservations.Lock()
+	observations := make(map[uint32]*Observation, len(s.observations))
+	for id, observation := range s.observations {
+		observations[id] = observation
+	}
+	dirtyObservations := copyIDs(s.dirtyObservations)
+	observationsHeads := make(map[uint32]*observationsHead, len(s.observationsHeads))
+	for id, head := range s.observationsHeads {
+		observationsHeads[id] = head
+	}
+	s.muObservations.Unlock()
+
+	s.muLoadPools.Lock()
+	loadedPools := s.loadedPools
+	s.muLoadPools.Unlock()
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		s.muPairs.Lock()
+		s.pairs, s.dirties, s.dirtiesOrders = pairs, dirties, dirtiesOrders
+		s.muPairs.Unlock()
+
+		s.muNextID.Lock()
+		s.nextID, s.dirtyNextID = nextID, dirtyNextID
+		s.muNextID.Unlock()
+
+		s.muNextOrdersID.Lock()
+		s.nextOrderID, s.dirtyNextOrdersID = nextOrderID, dirtyNextOrdersID
+		s.muNextOrdersID.Unlock()
+
+		s.muTriggerOrders.Lock()
+		s.triggerOrders, s.dirtyTriggerOrders = triggerOrders, dirtyTriggerOrders
+		s.muTriggerOrders.Unlock()
+
+		s.muObservations.Lock()
+		s.observations, s.dirtyObservations, s.observationsHeads = observations, dirtyObservations, observationsHeads
+		s.muObservations.Unlock()
+
+		s.muLoadPools.Lock()
+		s.loadedPools = loadedPools
+		s.muLoadPools.Unlock()
+	}
+}
+
+// snapshot returns the function which reverts reserves and orders of the pair,
+// the reversed views of the pair share them, so they are restored in place
+func (p *PairV2) snapshot() (revert func()) {
+	p.pairData.mu.RLock()
+	reserve0, reserve1, id := new(big.Int).Set(p.Reserve0), new(big.Int).Set(p.Reserve1), *p.ID
+	p.pairData.mu.RUnlock()
+
+	p.lockOrders.Lock()
+	sellOrders, buyOrders := p.sellOrders.copyIDs(), p.buyOrders.copyIDs()
+	loadedSellOrders, loadedBuyOrders := p.loadedSellOrders.copyIDs(), p.loadedBuyOrders.copyIDs()
+	p.lockOrders.Unlock()
+
+	p.orders.mu.RLock()
+	reverts := make([]func(), 0, len(p.orders.list))
+	orders := make(map[uint32]*Limit, len(p.orders.list))
+	for orderID, order := range p.orders.list {
+		orders[orderID] = order
+		if order != nil {
+			reverts = append(reverts, order.snapshot())
+		}
+	}
+	p.orders.mu.RUnlock()
+
+	dirties := []*orderDirties{p.dirtyOrders, p.deletedSellOrders, p.deletedBuyOrders, p.unsortedDirtyBuyOrders, p.unsortedDirtySellOrders}
+	dirtiesLists := make([]map[uint32]struct{}, len(dirties))
+	for i, d := range dirties {
+		d.mu.RLock()
+		dirtiesLists[i] = copyIDs(d.list)
+		d.mu.RUnlock()
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		p.pairData.mu.Lock()
+		p.Reserve0.Set(reserve0)
+		p.Reserve1.Set(reserve1)
+		*p.ID = id
+		p.pairData.mu.Unlock()
+
+		p.lockOrders.Lock()
+		p.sellOrders.ids, p.buyOrders.ids = sellOrders, buyOrders
+		p.loadedSellOrders.ids, p.loadedBuyOrders.ids = loadedSellOrders, loadedBuyOrders
+		p.lockOrders.Unlock()
+
+		p.orders.mu.Lock()
+		p.orders.list = orders
+		p.orders.mu.Unlock()
+
+		for i, d := range dirties {
+			d.mu.Lock()
+			d.list = dirtiesLists[i]
+			d.mu.Unlock()
+		}
+	}
+}
+
+func copyPairKeys(keys map[PairKey]struct{}) map[PairKey]struct{} {
+	result := make(map[PairKey]struct{}, len(keys))
+	for key := range keys {
+		result[key] = struct{}{}
+	}
+	return result
+}
+
+func copyIDs(ids map[uint32]struct{}) map[uint32]struct{} {
+	result := make(map[uint32]struct{}, len(ids))
+	for id := range ids {
+		result[id] = struct{}{}
+	}
+	return result
+}
+
 func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 	basePath := []byte{mainPrefix}
 
@@ -422,6 +619,9 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 	}
 	s.muNextOrdersID.Unlock()
 
+	s.commitTriggerOrders(db)
+	s.commitObservations(db)
+
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
@@ -459,6 +659,9 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 				if limit.isEmpty() {
 					db.Remove(pathOrderID)
 					db.Remove(oldPathOrderList)
+					if expireHeight := limit.GetExpireHeight(); expireHeight != 0 {
+						db.Remove(pathOrderExpiry(expireHeight, limit.id))
+					}
 					continue
 				}
 
@@ -480,6 +683,9 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 			}
 
 			db.Set(pathOrderID, pairOrderBytes)
+			if expireHeight := limit.GetExpireHeight(); expireHeight != 0 {
+				db.Set(pathOrderExpiry(expireHeight, limit.id), []byte{})
+			}
 		}
 
 		lenB := len(pair.buyOrders.ids)
@@ -0,0 +1,393 @@
+package swap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+// TriggerOrder is the stop-loss or take-profit order resting off the order book.
+// WantSell of Coin1 is locked until the pool price of Coin1 in Coin0 crosses the trigger price TriggerWantBuy/WantSell,
+// then it is sold through the pool for not less than WantBuy of Coin0
+type TriggerOrder struct {
+	Limit
+	TriggerWantBuy *big.Int
+	StopLoss       bool
+}
+
+// TriggerPrice returns the price of Coin1 in Coin0 triggering the order
+func (o *TriggerOrder) TriggerPrice() *big.Rat {
+	return CalcPriceSellRat(o.WantSell, o.TriggerWantBuy)
+}
+
+// IsTriggered returns true if the pool price has fallen to the trigger price of the stop-loss order
+// or has risen to the trigger price of the take-profit order
+func (o *TriggerOrder) IsTriggered(price *big.Rat) bool {
+	if o.StopLoss {
+		return price.Cmp(o.TriggerPrice()) != 1
+	}
+	return price.Cmp(o.TriggerPrice()) != -1
+}
+
+func pathTriggerOrder(id uint32) []byte {
+	return append([]byte{mainPrefix, triggerOrderPrefix}, id2Bytes(id)...)
+}
+
+// pathTriggerOrders is the prefix of the orders selling key.Coin1 for key.Coin0 on one side of the price,
+// stop-loss orders are on the side 0 and take-profit orders are on the side 1
+func pathTriggerOrders(key PairKey, side byte) []byte {
+	return append(append(append([]byte{mainPrefix, triggerOrderPricePrefix}, key.Coin1.Bytes()...), key.Coin0.Bytes()...), side)
+}
+
+func triggerOrderSide(stopLoss bool) byte {
+	if stopLoss {
+		return 0
+	}
+	return 1
+}
+
+// pathTriggerOrderPrice is the key of the order in the index of the orders of the pool ordered by trigger prices
+func pathTriggerOrderPrice(order *TriggerOrder) []byte {
+	price := new(big.Float).SetPrec(Precision).SetRat(order.TriggerPrice())
+	return append(append(pathTriggerOrders(order.PairKey, triggerOrderSide(order.StopLoss)), sortablePrice(price)...), id2Bytes(order.id)...)
+}
+
+// AddTriggerOrder locks wantSell of coinWantSell until the pool price crosses triggerWantBuy/wantSell.
+// The ID of the order is taken from the sequence of limit orders
+func (s *SwapV2) AddTriggerOrder(coinWantBuy, coinWantSell types.CoinID, wantBuy, wantSell, triggerWantBuy *big.Int, stopLoss bool, sender types.Address, block uint64) uint32 {
+	id := s.incOrdersID()
+	order := &TriggerOrder{
+		Limit: Limit{
+			WantBuy:  new(big.Int).Set(wantBuy),
+			WantSell: new(big.Int).Set(wantSell),
+			Owner:    sender,
+			Height:   block,
+			PairKey:  PairKey{Coin0: coinWantBuy, Coin1: coinWantSell},
+			id:       id,
+		},
+		TriggerWantBuy: new(big.Int).Set(triggerWantBuy),
+		StopLoss:       stopLoss,
+	}
+
+	s.muTriggerOrders.Lock()
+	s.triggerOrders[id] = order
+	s.dirtyTriggerOrders[id] = struct{}{}
+	s.muTriggerOrders.Unlock()
+
+	s.bus.Checker().AddCoin(coinWantSell, wantSell)
+
+	return id
+}
+
+// GetTriggerOrder returns the trigger order or nil if it doesn't exist
+func (s *SwapV2) GetTriggerOrder(id uint32) *TriggerOrder {
+	s.muTriggerOrders.Lock()
+	defer s.muTriggerOrders.Unlock()
+
+	return s.getTriggerOrder(id)
+}
+
+// GetTriggerOrders returns all trigger orders in the order of their creation
+func (s *SwapV2) GetTriggerOrders() []*TriggerOrder {
+	s.muTriggerOrders.Lock()
+	defer s.muTriggerOrders.Unlock()
+
+	return s.getTriggerOrders()
+}
+
+// RemoveTriggerOrder deletes the trigger order and returns its locked coin and volume
+func (s *SwapV2) RemoveTriggerOrder(id uint32) (types.CoinID, *big.Int) {
+	s.muTriggerOrders.Lock()
+	defer s.muTriggerOrders.Unlock()
+
+	order := s.getTriggerOrder(id)
+	if order == nil {
+		return 0, big.NewInt(0)
+	}
+
+	s.deleteTriggerOrder(id)
+	s.bus.Checker().AddCoin(order.Coin1, new(big.Int).Neg(order.WantSell))
+
+	return order.Coin1, new(big.Int).Set(order.WantSell)
+}
+
+// ExecuteTriggerOrders sells the coins of the orders triggered by the prices of all pools having trigger orders,
+// so the orders added when the price is already crossed are executed too, though the pool is not changed in the block.
+// Orders of the pool are executed in the order of their creation, so the price changed by an executed order
+// is seen by the next ones, until the price triggers no more orders.
+// The triggered order is cancelled and its coins are returned if the pool can't give WantBuy for it
+func (s *SwapV2) ExecuteTriggerOrders() {
+	s.muTriggerOrders.Lock()
+	defer s.muTriggerOrders.Unlock()
+
+	for _, key := range s.getTriggerOrdersPairs() {
+		for {
+			orders := s.getTriggeredOrders(key)
+			if len(orders) == 0 {
+				break
+			}
+
+			for _, order := range orders {
+				pair := s.Pair(order.Coin1, order.Coin0)
+				if !order.IsTriggered(pair.PriceRat()) {
+					continue
+				}
+				s.executeTriggerOrder(pair, order)
+			}
+		}
+	}
+}
+
+func (s *SwapV2) executeTriggerOrder(pair *PairV2, order *TriggerOrder) {
+	s.deleteTriggerOrder(order.id)
+	s.bus.Checker().AddCoin(order.Coin1, new(big.Int).Neg(order.WantSell))
+
+	amountOut, _ := pair.CalculateBuyForSellWithOrders(order.WantSell)
+	if amountOut == nil || amountOut.Cmp(order.WantBuy) == -1 {
+		s.bus.Accounts().AddBalance(order.Owner, order.Coin1, order.WantSell)
+		s.bus.Events().AddEvent(&events.OrderExpiredEvent{
+			ID:      uint64(order.id),
+			Address: order.Owner,
+			Coin:    uint64(order.Coin1),
+			Amount:  order.WantSell.String(),
+		})
+		return
+	}
+
+	amountIn, amountOut, _, _, owners := s.PairSellWithOrders(order.Coin1, order.Coin0, order.WantSell, order.WantBuy)
+	for _, value := range owners {
+		s.bus.Accounts().AddBalance(value.Owner, order.Coin1, value.ValueBigInt)
+	}
+	s.bus.Accounts().AddBalance(order.Owner, order.Coin0, amountOut)
+
+	s.bus.Events().AddEvent(&events.TriggerOrderEvent{
+		ID:          uint64(order.id),
+		Address:     order.Owner,
+		CoinToSell:  uint64(order.Coin1),
+		ValueToSell: amountIn.String(),
+		CoinToBuy:   uint64(order.Coin0),
+		ValueToBuy:  amountOut.String(),
+	})
+}
+
+// getTriggeredOrders returns the orders of both directions of the pool triggered by its price in the order of creation.
+// The index is walked from the farthest trigger price to the pool price up to the first not triggered order
+func (s *SwapV2) getTriggeredOrders(key PairKey) []*TriggerOrder {
+	triggered := map[uint32]*TriggerOrder{}
+	for _, direction := range []PairKey{key, key.reverse()} {
+		pair := s.Pair(direction.Coin1, direction.Coin0)
+		if pair == nil {
+			continue
+		}
+		price := pair.PriceRat()
+
+		for _, stopLoss := range []bool{true, false} {
+			side := triggerOrderSide(stopLoss)
+			s.immutableTree().IterateRange(pathTriggerOrders(direction, side), pathTriggerOrders(direction, side+1), !stopLoss, func(key []byte, value []byte) bool {
+				order := s.getTriggerOrder(binary.BigEndian.Uint32(key[len(key)-4:]))
+				if order == nil {
+					return false
+				}
+				if !order.IsTriggered(price) {
+					return true
+				}
+				triggered[order.id] = order
+				return false
+			})
+		}
+
+		for id := range s.dirtyTriggerOrders {
+			order := s.triggerOrders[id]
+			if order != nil && order.PairKey == direction && order.IsTriggered(price) {
+				triggered[id] = order
+			}
+		}
+	}
+
+	orders := make([]*TriggerOrder, 0, len(triggered))
+	for _, order := range triggered {
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].id < orders[j].id
+	})
+
+	return orders
+}
+
+// getTriggerOrdersPairs returns the sorted keys of the pools having trigger orders in any direction.
+// The index of trigger prices is walked by seeking from the orders of one pool to the orders of the next one
+func (s *SwapV2) getTriggerOrdersPairs() []PairKey {
+	pairs := map[PairKey]struct{}{}
+
+	start, end := []byte{mainPrefix, triggerOrderPricePrefix}, []byte{mainPrefix, triggerOrderPricePrefix + 1}
+	for {
+		var next *PairKey
+		s.immutableTree().IterateRange(start, end, true, func(key []byte, value []byte) bool {
+			next = &PairKey{Coin0: types.BytesToCoinID(key[6:10]), Coin1: types.BytesToCoinID(key[2:6])}
+			return true
+		})
+		if next == nil {
+			break
+		}
+		pairs[next.sort()] = struct{}{}
+		start = pathTriggerOrders(*next, triggerOrderSide(false)+1)
+	}
+
+	for id := range s.dirtyTriggerOrders {
+		if order := s.triggerOrders[id]; order != nil {
+			pairs[order.PairKey.sort()] = struct{}{}
+		}
+	}
+
+	keys := make([]PairKey, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].bytes(), keys[j].bytes()) == 1
+	})
+
+	return keys
+}
+
+func (s *SwapV2) getTriggerOrder(id uint32) *TriggerOrder {
+	if order, ok := s.triggerOrders[id]; ok {
+		return order
+	}
+
+	_, value := s.immutableTree().Get(pathTriggerOrder(id))
+	if len(value) == 0 {
+		return nil
+	}
+
+	order := &TriggerOrder{}
+	if err := rlp.DecodeBytes(value, order); err != nil {
+		panic(err)
+	}
+	order.id = id
+
+	s.triggerOrders[id] = order
+	return order
+}
+
+func (s *SwapV2) getTriggerOrders() []*TriggerOrder {
+	ids := map[uint32]struct{}{}
+	s.immutableTree().IterateRange(pathTriggerOrder(0), pathTriggerOrder(math.MaxUint32), true, func(key []byte, value []byte) bool {
+		ids[binary.BigEndian.Uint32(key[2:])] = struct{}{}
+		return false
+	})
+	for id := range s.dirtyTriggerOrders {
+		ids[id] = struct{}{}
+	}
+
+	sortedIDs := make([]uint32, 0, len(ids))
+	for id := range ids {
+		sortedIDs = append(sortedIDs, id)
+	}
+	sort.Slice(sortedIDs, func(i, j int) bool {
+		return sortedIDs[i] < sortedIDs[j]
+	})
+
+	orders := make([]*TriggerOrder, 0, len(sortedIDs))
+	for _, id := range sortedIDs {
+		if order := s.getTriggerOrder(id); order != nil {
+			orders = append(orders, order)
+		}
+	}
+
+	return orders
+}
+
+func (s *SwapV2) deleteTriggerOrder(id uint32) {
+	s.triggerOrders[id] = nil
+	s.dirtyTriggerOrders[id] = struct{}{}
+}
+
+func (s *SwapV2) commitTriggerOrders(db *iavl.MutableTree) {
+	s.muTriggerOrders.Lock()
+	defer s.muTriggerOrders.Unlock()
+
+	ids := make([]uint32, 0, len(s.dirtyTriggerOrders))
+	for id := range s.dirtyTriggerOrders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		order := s.triggerOrders[id]
+		if order == nil {
+			if _, value := s.immutableTree().Get(pathTriggerOrder(id)); len(value) != 0 {
+				removed := &TriggerOrder{}
+				if err := rlp.DecodeBytes(value, removed); err != nil {
+					panic(err)
+				}
+				removed.id = id
+				db.Remove(pathTriggerOrderPrice(removed))
+			}
+			db.Remove(pathTriggerOrder(id))
+			delete(s.triggerOrders, id)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(order)
+		if err != nil {
+			panic(err)
+		}
+		db.Set(pathTriggerOrder(id), data)
+		db.Set(pathTriggerOrderPrice(order), id2Bytes(id))
+	}
+
+	s.dirtyTriggerOrders = map[uint32]struct{}{}
+}
+
+func (s *SwapV2) exportTriggerOrders(state *types.AppState) {
+	for _, order := range s.GetTriggerOrders() {
+		state.TriggerOrders = append(state.TriggerOrders, types.TriggerOrder{
+			ID:                uint64(order.id),
+			Owner:             order.Owner,
+			CoinToSell:        uint64(order.Coin1),
+			ValueToSell:       order.WantSell.String(),
+			CoinToBuy:         uint64(order.Coin0),
+			MinimumValueToBuy: order.WantBuy.String(),
+			TriggerValueToBuy: order.TriggerWantBuy.String(),
+			StopLoss:          order.StopLoss,
+			Height:            order.Height,
+		})
+	}
+}
+
+func (s *SwapV2) importTriggerOrders(state *types.AppState) {
+	s.muTriggerOrders.Lock()
+	defer s.muTriggerOrders.Unlock()
+
+	for _, order := range state.TriggerOrders {
+		id := uint32(order.ID)
+		coinToSell := types.CoinID(order.CoinToSell)
+		valueToSell := helpers.StringToBigInt(order.ValueToSell)
+		s.triggerOrders[id] = &TriggerOrder{
+			Limit: Limit{
+				WantBuy:  helpers.StringToBigInt(order.MinimumValueToBuy),
+				WantSell: valueToSell,
+				Owner:    order.Owner,
+				Height:   order.Height,
+				PairKey:  PairKey{Coin0: types.CoinID(order.CoinToBuy), Coin1: coinToSell},
+				id:       id,
+			},
+			TriggerWantBuy: helpers.StringToBigInt(order.TriggerValueToBuy),
+			StopLoss:       order.StopLoss,
+		}
+		s.dirtyTriggerOrders[id] = struct{}{}
+		s.bus.Checker().AddCoin(coinToSell, valueToSell)
+	}
+}
@@ -15,6 +15,20 @@ type Model struct {
 	lock sync.Mutex
 }
 
+func (m *Model) snapshot() (revert func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	votes := append([]types.Pubkey(nil), m.Votes...)
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		m.Votes = votes
+	}
+}
+
 func (m *Model) addVote(pubkey types.Pubkey) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
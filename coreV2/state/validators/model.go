@@ -41,6 +41,34 @@ func NewValidator(pubKey types.Pubkey, absentTimes *types.BitArray, totalStake *
 	return val
 }
 
+func (v *Validator) snapshot() (revert func()) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	absentTimes := v.AbsentTimes
+	var absent []bool
+	if absentTimes != nil {
+		absent = make([]bool, absentTimes.Size())
+		for i := range absent {
+			absent[i] = absentTimes.GetIndex(i)
+		}
+	}
+	totalStake, accumReward := v.totalStake, v.accumReward
+	isDirty, isTotalStakeDirty, isAccumRewardDirty, toDrop := v.isDirty, v.isTotalStakeDirty, v.isAccumRewardDirty, v.toDrop
+
+	return func() {
+		v.lock.Lock()
+		defer v.lock.Unlock()
+
+		for i, value := range absent {
+			absentTimes.SetIndex(i, value)
+		}
+		v.AbsentTimes = absentTimes
+		v.totalStake, v.accumReward = totalStake, accumReward
+		v.isDirty, v.isTotalStakeDirty, v.isAccumRewardDirty, v.toDrop = isDirty, isTotalStakeDirty, isAccumRewardDirty, toDrop
+	}
+}
+
 func (v *Validator) IsToDrop() bool {
 	v.lock.RLock()
 	defer v.lock.RUnlock()
@@ -109,7 +137,11 @@ func (v *Validator) AddAccumReward(amount *big.Int) {
 func (v *Validator) CountAbsentTimes() int {
 	count := 0
 
-	for i := 0; i < ValidatorMaxAbsentWindow; i++ {
+	v.lock.RLock()
+	size := int(v.AbsentTimes.Size())
+	v.lock.RUnlock()
+
+	for i := 0; i < size; i++ {
 		v.lock.RLock()
 		if v.AbsentTimes.GetIndex(i) {
 			count++
@@ -134,12 +166,24 @@ func (v *Validator) setTmAddress() {
 	v.lock.Unlock()
 }
 
-func (v *Validator) SetPresent(height uint64) {
-	index := int(height) % ValidatorMaxAbsentWindow
+// resizeAbsentTimes clears the missed blocks if the window was changed by validators
+func (v *Validator) resizeAbsentTimes(window int) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if int(v.AbsentTimes.Size()) == window {
+		return
+	}
+	v.AbsentTimes = types.NewBitArray(window)
+	v.isDirty = true
+}
 
+func (v *Validator) SetPresent(height uint64) {
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
+	index := int(height) % int(v.AbsentTimes.Size())
+
 	if v.AbsentTimes.GetIndex(index) {
 		v.isDirty = true
 	}
@@ -147,11 +191,11 @@ func (v *Validator) SetPresent(height uint64) {
 }
 
 func (v *Validator) SetAbsent(height uint64) {
-	index := int(height) % ValidatorMaxAbsentWindow
-
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
+	index := int(height) % int(v.AbsentTimes.Size())
+
 	if !v.AbsentTimes.GetIndex(index) {
 		v.isDirty = true
 	}
@@ -45,6 +45,7 @@ type Validators struct {
 // RValidators interface represents Validator state
 type RValidators interface {
 	GetValidators() []*Validator
+	TotalStakes() *big.Int
 	Export(state *types.AppState)
 	GetByPublicKey(pubKey types.Pubkey) *Validator
 	LoadValidators()
@@ -133,6 +134,34 @@ func (v *Validators) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// Snapshot returns the function which reverts all changes of validators made after the call
+func (v *Validators) Snapshot() (revert func()) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	list := append([]*Validator(nil), v.list...)
+	reverts := make([]func(), 0, len(list))
+	for _, val := range list {
+		reverts = append(reverts, val.snapshot())
+	}
+	removed := make(map[types.Pubkey]struct{}, len(v.removed))
+	for pubkey := range v.removed {
+		removed[pubkey] = struct{}{}
+	}
+	loaded := v.loaded
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		v.lock.Lock()
+		defer v.lock.Unlock()
+
+		v.list, v.removed, v.loaded = list, removed, loaded
+	}
+}
+
 func (v *Validators) Count() int {
 	v.lock.Lock()
 	defer v.lock.Unlock()
@@ -186,6 +215,7 @@ func (v *Validators) SetValidatorPresent(height uint64, address types.TmAddress)
 	if validator == nil {
 		return
 	}
+	validator.resizeAbsentTimes(v.absentWindow())
 	validator.SetPresent(height)
 }
 
@@ -197,9 +227,11 @@ func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress,
 		return
 	}
 
+	window := v.absentWindow()
+	validator.resizeAbsentTimes(window)
 	validator.SetAbsent(height)
 
-	if validator.CountAbsentTimes() > validatorMaxAbsentTimes {
+	if validator.CountAbsentTimes() > window*validatorMaxAbsentTimes/ValidatorMaxAbsentWindow {
 		if !grace.IsGraceBlock(height) {
 			v.punishValidator(height, address)
 		}
@@ -208,6 +240,44 @@ func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress,
 	}
 }
 
+// SetValidatorAbsentV2 marks validator as absent at current height
+// if validator misses signs of more than validatorMaxAbsentTimes, it will receive graduated penalty for the downtime and will be swithed off
+func (v *Validators) SetValidatorAbsentV2(height uint64, address types.TmAddress, grace *upgrades.Grace) {
+	validator := v.GetByTmAddress(address)
+	if validator == nil {
+		return
+	}
+
+	window := v.absentWindow()
+	validator.resizeAbsentTimes(window)
+	validator.SetAbsent(height)
+
+	if validator.CountAbsentTimes() > window*validatorMaxAbsentTimes/ValidatorMaxAbsentWindow {
+		if !grace.IsGraceBlock(height) {
+			v.bus.Candidates().PunishDowntime(height, address)
+		}
+
+		v.turnValidatorOff(address)
+	}
+}
+
+func (v *Validators) rewardPools() []types.RewardPool {
+	if v.bus.Params() == nil {
+		return []types.RewardPool{
+			{Name: dao.Pool, Address: dao.Address, Commission: uint32(dao.Commission)},
+			{Name: developers.Pool, Address: developers.Address, Commission: uint32(developers.Commission)},
+		}
+	}
+	return v.bus.Params().RewardPools()
+}
+
+func (v *Validators) absentWindow() int {
+	if v.bus.Params() == nil {
+		return ValidatorMaxAbsentWindow
+	}
+	return int(v.bus.Params().ValidatorMaxAbsentWindow())
+}
+
 // GetValidators returns list of validators
 func (v *Validators) GetValidators() []*Validator {
 	v.lock.RLock()
@@ -230,7 +300,7 @@ func (v *Validators) SetNewValidators(candidates []*candidates.Candidate) {
 	var newVals []*Validator
 	for _, candidate := range candidates {
 		accumReward := big.NewInt(0)
-		absentTimes := types.NewBitArray(ValidatorMaxAbsentWindow)
+		absentTimes := types.NewBitArray(v.absentWindow())
 
 		for _, oldVal := range old {
 			if oldVal.GetAddress() == candidate.GetTmAddress() {
@@ -281,7 +351,7 @@ func (v *Validators) PunishByzantineValidator(tmAddress [20]byte) {
 func (v *Validators) Create(pubkey types.Pubkey, stake *big.Int) {
 	val := &Validator{
 		PubKey:             pubkey,
-		AbsentTimes:        types.NewBitArray(ValidatorMaxAbsentWindow),
+		AbsentTimes:        types.NewBitArray(v.absentWindow()),
 		totalStake:         big.NewInt(0).Set(stake),
 		accumReward:        big.NewInt(0),
 		isDirty:            true,
@@ -433,8 +503,32 @@ func (v *Validators) PayRewardsV3(height uint64, period int64) (moreRewards *big
 
 // PayRewardsV5Fix distributes accumulated rewards between validator, delegators, DAO and developers addresses
 func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *big.Int) {
+	return v.payRewardsV5Fix(height, period, false)
+}
+
+// PayRewardsV6 distributes rewards as PayRewardsV5Fix, but rewards of delegators with disabled auto-compound
+// and rewards which can't be added to the stake by the stake limits are paid to the balance
+func (v *Validators) PayRewardsV6(height uint64, period int64) (moreRewards *big.Int) {
+	return v.payRewardsV5Fix(height, period, true)
+}
+
+func (v *Validators) payRewardsV5Fix(height uint64, period int64, withAutoCompound bool) (moreRewards *big.Int) {
 	moreRewards = big.NewInt(0)
 
+	var daoPool, developersPool types.RewardPool
+	var otherPools []types.RewardPool
+	for _, pool := range v.rewardPools() {
+		switch pool.Name {
+		case dao.Pool:
+			daoPool = pool
+		case developers.Pool:
+			developersPool = pool
+		default:
+			otherPools = append(otherPools, pool)
+		}
+	}
+	daoCommission, developersCommission := uint64(daoPool.Commission), uint64(developersPool.Commission)
+
 	vals := v.GetValidators()
 
 	calcReward, safeReward := v.bus.App().Reward()
@@ -459,19 +553,33 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 		// pay commission to DAO
 
 		DAOReward := big.NewInt(0).Set(totalReward)
-		DAOReward.Mul(DAOReward, big.NewInt(int64(dao.Commission)))
+		DAOReward.Mul(DAOReward, big.NewInt(int64(daoCommission)))
 		DAOReward.Div(DAOReward, big.NewInt(100))
 
 		// pay commission to Developers
 
 		DevelopersReward := big.NewInt(0).Set(totalReward)
-		DevelopersReward.Mul(DevelopersReward, big.NewInt(int64(developers.Commission)))
+		DevelopersReward.Mul(DevelopersReward, big.NewInt(int64(developersCommission)))
 		DevelopersReward.Div(DevelopersReward, big.NewInt(100))
 
+		// pay commission to other reward pools
+
+		poolRewards := make([]*big.Int, 0, len(otherPools))
+		for _, pool := range otherPools {
+			poolReward := big.NewInt(0).Set(totalReward)
+			poolReward.Mul(poolReward, big.NewInt(int64(pool.Commission)))
+			poolReward.Div(poolReward, big.NewInt(100))
+			poolRewards = append(poolRewards, poolReward)
+		}
+
 		totalReward.Sub(totalReward, DevelopersReward)
 		totalReward.Sub(totalReward, DAOReward)
 		remainder.Sub(remainder, DAOReward)
 		remainder.Sub(remainder, DevelopersReward)
+		for _, poolReward := range poolRewards {
+			totalReward.Sub(totalReward, poolReward)
+			remainder.Sub(remainder, poolReward)
+		}
 
 		// pay commission to validator
 		validatorReward := big.NewInt(0).Set(totalReward)
@@ -514,8 +622,8 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 					safeRewards.Div(safeRewards, validator.GetTotalBipStake())
 					safeRewards.Div(safeRewards, totalAccumRewards)
 
-					taxDAOx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
-					taxDEVx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(dao.Commission))), big.NewInt(100))
+					taxDAOx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developersCommission))), big.NewInt(100))
+					taxDEVx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(daoCommission))), big.NewInt(100))
 
 					safeRewards.Sub(safeRewards, taxDAOx3)
 					safeRewards.Sub(safeRewards, taxDEVx3)
@@ -527,12 +635,12 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 					calcRewards.Div(calcRewards, validator.GetTotalBipStake())
 					calcRewards.Div(calcRewards, totalAccumRewards)
 
-					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
-					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(dao.Commission))), big.NewInt(100))
+					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developersCommission))), big.NewInt(100))
+					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(daoCommission))), big.NewInt(100))
 
 					calcRewards.Sub(calcRewards, taxDAO)
 					calcRewards.Sub(calcRewards, taxDEV)
-					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developers.Commission+dao.Commission))), big.NewInt(100)))
+					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developersCommission+daoCommission))), big.NewInt(100)))
 					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(candidate.Commission))), big.NewInt(100)))
 
 					diffDAO := big.NewInt(0).Sub(taxDAOx3, taxDAO)
@@ -551,8 +659,8 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 					safeRewards.Mul(safeRewards, big.NewInt(3))
 					safeRewards.Div(safeRewards, totalStakes)
 
-					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
-					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(dao.Commission))), big.NewInt(100))
+					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developersCommission))), big.NewInt(100))
+					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(daoCommission))), big.NewInt(100))
 
 					DAOReward.Add(DAOReward, taxDAO)
 					DevelopersReward.Add(DevelopersReward, taxDEV)
@@ -578,42 +686,65 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 				continue
 			}
 
-			candidate.AddUpdate(types.GetBaseCoinID(), safeRewardVariable, safeRewardVariable, stake.Owner)
-			v.bus.Checker().AddCoin(types.GetBaseCoinID(), safeRewardVariable)
-
-			v.bus.Events().AddEvent(&eventsdb.RewardEvent{
+			event := &eventsdb.RewardEvent{
 				Role:            eventsdb.RoleDelegator.String(),
 				Address:         stake.Owner,
 				Amount:          safeRewardVariable.String(),
 				ValidatorPubKey: validator.PubKey,
 				ForCoin:         uint64(stake.Coin),
-			})
+			}
+
+			if withAutoCompound {
+				tooLow, tooBig := v.bus.Candidates().IsDelegatorStakeAllowed(stake.Owner, validator.PubKey, types.GetBaseCoinID(), safeRewardVariable)
+				event.Restaked = !tooLow && !tooBig && v.bus.Candidates().IsAutoCompound(validator.PubKey, stake.Owner)
+			}
+
+			if !withAutoCompound || event.Restaked {
+				candidate.AddUpdate(types.GetBaseCoinID(), safeRewardVariable, safeRewardVariable, stake.Owner)
+				v.bus.Checker().AddCoin(types.GetBaseCoinID(), safeRewardVariable)
+			} else {
+				v.bus.Accounts().AddBalance(stake.Owner, types.GetBaseCoinID(), safeRewardVariable)
+			}
+
+			v.bus.Events().AddEvent(event)
 		}
 
-		{
-			candidate.AddUpdate(types.GetBaseCoinID(), DAOReward, DAOReward, dao.Address)
+		if daoPool.Name != "" {
+			candidate.AddUpdate(types.GetBaseCoinID(), DAOReward, DAOReward, daoPool.Address)
 			v.bus.Checker().AddCoin(types.GetBaseCoinID(), DAOReward)
 			v.bus.Events().AddEvent(&eventsdb.RewardEvent{
 				Role:            eventsdb.RoleDAO.String(),
-				Address:         dao.Address,
+				Address:         daoPool.Address,
 				Amount:          DAOReward.String(),
 				ValidatorPubKey: validator.PubKey,
 				ForCoin:         0,
 			})
 		}
 
-		{
-			candidate.AddUpdate(types.GetBaseCoinID(), DevelopersReward, DevelopersReward, developers.Address)
+		if developersPool.Name != "" {
+			candidate.AddUpdate(types.GetBaseCoinID(), DevelopersReward, DevelopersReward, developersPool.Address)
 			v.bus.Checker().AddCoin(types.GetBaseCoinID(), DevelopersReward)
 			v.bus.Events().AddEvent(&eventsdb.RewardEvent{
 				Role:            eventsdb.RoleDevelopers.String(),
-				Address:         developers.Address,
+				Address:         developersPool.Address,
 				Amount:          DevelopersReward.String(),
 				ValidatorPubKey: validator.PubKey,
 				ForCoin:         0,
 			})
 		}
 
+		for i, pool := range otherPools {
+			candidate.AddUpdate(types.GetBaseCoinID(), poolRewards[i], poolRewards[i], pool.Address)
+			v.bus.Checker().AddCoin(types.GetBaseCoinID(), poolRewards[i])
+			v.bus.Events().AddEvent(&eventsdb.RewardEvent{
+				Role:            eventsdb.RolePool.String(),
+				Address:         pool.Address,
+				Amount:          poolRewards[i].String(),
+				ValidatorPubKey: validator.PubKey,
+				ForCoin:         0,
+			})
+		}
+
 		validator.SetAccumReward(big.NewInt(0))
 
 		if remainder.Sign() != -1 {
@@ -1145,7 +1276,7 @@ func (v *Validators) turnValidatorOff(tmAddress types.TmAddress) {
 	validator.lock.Lock()
 	defer validator.lock.Unlock()
 
-	validator.AbsentTimes = types.NewBitArray(ValidatorMaxAbsentWindow)
+	validator.AbsentTimes = types.NewBitArray(v.absentWindow())
 	validator.toDrop = true
 	validator.isDirty = true
 	v.bus.Candidates().SetOffline(validator.PubKey)
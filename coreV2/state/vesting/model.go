@@ -0,0 +1,115 @@
+package vesting
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+type Item struct {
+	ID          uint32
+	Coin        types.CoinID
+	Value       *big.Int
+	Claimed     *big.Int
+	StartHeight uint64
+	CliffHeight uint64
+	EndHeight   uint64
+	Revoker     *types.Address `rlp:"nil"`
+}
+
+// Unlocked returns the vested part of the total value at given height,
+// nothing is unlocked before the cliff and the value is unlocked linearly from start to end
+func (i *Item) Unlocked(height uint64) *big.Int {
+	if height < i.CliffHeight || height <= i.StartHeight {
+		return big.NewInt(0)
+	}
+	if height >= i.EndHeight {
+		return new(big.Int).Set(i.Value)
+	}
+
+	unlocked := new(big.Int).Mul(i.Value, new(big.Int).SetUint64(height-i.StartHeight))
+	return unlocked.Div(unlocked, new(big.Int).SetUint64(i.EndHeight-i.StartHeight))
+}
+
+// Claimable returns unlocked and not yet claimed value at given height
+func (i *Item) Claimable(height uint64) *big.Int {
+	return new(big.Int).Sub(i.Unlocked(height), i.Claimed)
+}
+
+// Remaining returns value which is still held by the vesting
+func (i *Item) Remaining() *big.Int {
+	return new(big.Int).Sub(i.Value, i.Claimed)
+}
+
+func (i *Item) IsRevocable() bool {
+	return i.Revoker != nil
+}
+
+type Model struct {
+	List []*Item
+
+	address   types.Address
+	markDirty func(address types.Address)
+	lock      sync.RWMutex
+}
+
+func (m *Model) snapshot() (revert func()) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	list := append([]*Item(nil), m.List...)
+	claimed := make([]*big.Int, 0, len(list))
+	for _, item := range list {
+		claimed = append(claimed, item.Claimed)
+	}
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		for i, item := range list {
+			item.Claimed = claimed[i]
+		}
+		m.List = list
+	}
+}
+
+func (m *Model) add(item *Item) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.List = append(m.List, item)
+	m.markDirty(m.address)
+}
+
+func (m *Model) get(id uint32) *Item {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, item := range m.List {
+		if item.ID == id {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func (m *Model) remove(id uint32) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	items := make([]*Item, 0, len(m.List))
+	for _, item := range m.List {
+		if item.ID != id {
+			items = append(items, item)
+		}
+	}
+	m.List = items
+	m.markDirty(m.address)
+}
+
+func (m *Model) Address() types.Address {
+	return m.address
+}
@@ -0,0 +1,349 @@
+package vesting
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('l')
+
+// RVesting is an interface of vesting schedules for read only states
+type RVesting interface {
+	Export(state *types.AppState)
+	GetByAddress(address types.Address) *Model
+	Get(address types.Address, id uint32) *Item
+}
+
+// Vesting keeps schedules of coins unlocked linearly for beneficiaries
+type Vesting struct {
+	list  map[types.Address]*Model
+	dirty map[types.Address]struct{}
+
+	nextID      uint32
+	dirtyNextID bool
+
+	db atomic.Value
+
+	bus *bus.Bus
+
+	lock sync.RWMutex
+}
+
+func NewVesting(stateBus *bus.Bus, db *iavl.ImmutableTree) *Vesting {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Vesting{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[types.Address]*Model{},
+		dirty: map[types.Address]struct{}{},
+	}
+}
+
+func (v *Vesting) immutableTree() *iavl.ImmutableTree {
+	db := v.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (v *Vesting) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	v.db.Store(immutableTree)
+}
+
+func (v *Vesting) Export(state *types.AppState) {
+	v.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) != 1+types.AddressLength {
+			return false
+		}
+		address := types.BytesToAddress(key[1:])
+
+		model := v.GetByAddress(address)
+		if model == nil {
+			return false
+		}
+
+		for _, item := range model.List {
+			state.Vestings = append(state.Vestings, types.Vesting{
+				ID:          uint64(item.ID),
+				Beneficiary: address,
+				Coin:        uint64(item.Coin),
+				Value:       item.Value.String(),
+				Claimed:     item.Claimed.String(),
+				StartHeight: item.StartHeight,
+				CliffHeight: item.CliffHeight,
+				EndHeight:   item.EndHeight,
+				Revoker:     item.Revoker,
+			})
+		}
+
+		return false
+	})
+
+	state.NextVestingID = uint64(v.getNextID())
+}
+
+func (v *Vesting) Commit(db *iavl.MutableTree, version int64) error {
+	v.lock.Lock()
+	if v.dirtyNextID {
+		v.dirtyNextID = false
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, v.nextID)
+		db.Set([]byte{mainPrefix}, b)
+	}
+	v.lock.Unlock()
+
+	dirty := v.getOrderedDirty()
+	for _, address := range dirty {
+		m := v.getFromMap(address)
+		path := getPath(address)
+
+		v.lock.Lock()
+		delete(v.dirty, address)
+		v.lock.Unlock()
+
+		m.lock.RLock()
+		if len(m.List) != 0 {
+			data, err := rlp.EncodeToBytes(m)
+			if err != nil {
+				return fmt.Errorf("can't encode object at %s: %v", address.String(), err)
+			}
+			db.Set(path, data)
+		} else {
+			db.Remove(path)
+			v.lock.Lock()
+			delete(v.list, address)
+			v.lock.Unlock()
+		}
+		m.lock.RUnlock()
+	}
+
+	return nil
+}
+
+// Snapshot returns the function which reverts all changes of vestings made after the call
+func (v *Vesting) Snapshot() (revert func()) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	reverts := make([]func(), 0, len(v.list))
+	list := make(map[types.Address]*Model, len(v.list))
+	for address, m := range v.list {
+		list[address] = m
+		reverts = append(reverts, m.snapshot())
+	}
+	dirty := make(map[types.Address]struct{}, len(v.dirty))
+	for address := range v.dirty {
+		dirty[address] = struct{}{}
+	}
+	nextID, dirtyNextID := v.nextID, v.dirtyNextID
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		v.lock.Lock()
+		defer v.lock.Unlock()
+
+		v.list, v.dirty = list, dirty
+		v.nextID, v.dirtyNextID = nextID, dirtyNextID
+	}
+}
+
+func (v *Vesting) GetByAddress(address types.Address) *Model {
+	return v.get(address)
+}
+
+func (v *Vesting) Get(address types.Address, id uint32) *Item {
+	m := v.get(address)
+	if m == nil {
+		return nil
+	}
+
+	return m.get(id)
+}
+
+// Create locks value for beneficiary and returns id of the vesting
+func (v *Vesting) Create(beneficiary types.Address, coin types.CoinID, value *big.Int, start, cliff, end uint64, revoker *types.Address) uint32 {
+	id := v.getNextID()
+	v.setNextID(id + 1)
+
+	v.CreateWithID(id, beneficiary, coin, value, big.NewInt(0), start, cliff, end, revoker)
+
+	return id
+}
+
+func (v *Vesting) CreateWithID(id uint32, beneficiary types.Address, coin types.CoinID, value, claimed *big.Int, start, cliff, end uint64, revoker *types.Address) {
+	item := &Item{
+		ID:          id,
+		Coin:        coin,
+		Value:       new(big.Int).Set(value),
+		Claimed:     new(big.Int).Set(claimed),
+		StartHeight: start,
+		CliffHeight: cliff,
+		EndHeight:   end,
+		Revoker:     revoker,
+	}
+
+	v.getOrNew(beneficiary).add(item)
+	v.bus.Checker().AddCoin(coin, item.Remaining())
+}
+
+// Claim releases claimable value at given height, fully claimed vesting is removed
+func (v *Vesting) Claim(beneficiary types.Address, id uint32, height uint64) (types.CoinID, *big.Int) {
+	m := v.get(beneficiary)
+	if m == nil {
+		log.Panicf("Vesting not found for %s", beneficiary.String())
+	}
+	item := m.get(id)
+	if item == nil {
+		log.Panicf("Vesting %d not found for %s", id, beneficiary.String())
+	}
+
+	value := item.Claimable(height)
+	m.lock.Lock()
+	item.Claimed = new(big.Int).Add(item.Claimed, value)
+	m.lock.Unlock()
+
+	if item.Remaining().Sign() == 0 {
+		m.remove(id)
+	} else {
+		m.markDirty(beneficiary)
+	}
+	v.bus.Checker().AddCoin(item.Coin, new(big.Int).Neg(value))
+
+	return item.Coin, value
+}
+
+// Revoke removes the vesting and returns its claimable value for the beneficiary and locked value for the revoker
+func (v *Vesting) Revoke(beneficiary types.Address, id uint32, height uint64) (coin types.CoinID, claimable *big.Int, locked *big.Int) {
+	m := v.get(beneficiary)
+	if m == nil {
+		log.Panicf("Vesting not found for %s", beneficiary.String())
+	}
+	item := m.get(id)
+	if item == nil {
+		log.Panicf("Vesting %d not found for %s", id, beneficiary.String())
+	}
+
+	claimable = item.Claimable(height)
+	locked = new(big.Int).Sub(item.Remaining(), claimable)
+
+	m.remove(id)
+	v.bus.Checker().AddCoin(item.Coin, new(big.Int).Neg(item.Remaining()))
+
+	return item.Coin, claimable, locked
+}
+
+func (v *Vesting) SetNextID(id uint32) {
+	v.setNextID(id)
+}
+
+func (v *Vesting) getNextID() uint32 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.nextID == 0 {
+		v.nextID = 1
+		if _, value := v.immutableTree().Get([]byte{mainPrefix}); len(value) == 4 {
+			v.nextID = binary.BigEndian.Uint32(value)
+		}
+	}
+
+	return v.nextID
+}
+
+func (v *Vesting) setNextID(id uint32) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.nextID = id
+	v.dirtyNextID = true
+}
+
+func (v *Vesting) getOrNew(address types.Address) *Model {
+	m := v.get(address)
+	if m == nil {
+		m = &Model{List: make([]*Item, 0), address: address, markDirty: v.markDirty}
+		v.setToMap(address, m)
+	}
+
+	return m
+}
+
+func (v *Vesting) get(address types.Address) *Model {
+	if m := v.getFromMap(address); m != nil {
+		return m
+	}
+
+	_, enc := v.immutableTree().Get(getPath(address))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	m := new(Model)
+	if err := rlp.DecodeBytes(enc, m); err != nil {
+		panic(fmt.Sprintf("failed to decode vesting for address %s: %s", address.String(), err))
+	}
+
+	m.address = address
+	m.markDirty = v.markDirty
+	v.setToMap(address, m)
+
+	return m
+}
+
+func (v *Vesting) getFromMap(address types.Address) *Model {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return v.list[address]
+}
+
+func (v *Vesting) setToMap(address types.Address, model *Model) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.list[address] = model
+}
+
+func (v *Vesting) markDirty(address types.Address) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.dirty[address] = struct{}{}
+}
+
+func (v *Vesting) getOrderedDirty() []types.Address {
+	v.lock.Lock()
+	keys := make([]types.Address, 0, len(v.dirty))
+	for k := range v.dirty {
+		keys = append(keys, k)
+	}
+	v.lock.Unlock()
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
+
+func getPath(address types.Address) []byte {
+	return append([]byte{mainPrefix}, address.Bytes()...)
+}
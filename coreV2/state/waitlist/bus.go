@@ -16,6 +16,13 @@ func (b *Bus) AddToWaitList(address types.Address, pubkey types.Pubkey, coin typ
 func (b *Bus) Delete(address types.Address, pubkey types.Pubkey, coin types.CoinID) {
 	b.waitlist.Delete(address, pubkey, coin)
 }
+func (b *Bus) AutoRoute(address types.Address) (candidateID uint32, enabled bool) {
+	route := b.waitlist.GetRoute(address)
+	if route == nil {
+		return 0, false
+	}
+	return route.CandidateID, true
+}
 func (b *Bus) GetByAddressAndPubKey(address types.Address, pubkey types.Pubkey) (res []*bus.WaitListItem) {
 	waitlist := b.waitlist.GetByAddressAndPubKey(address, pubkey)
 	for _, item := range waitlist {
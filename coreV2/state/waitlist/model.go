@@ -20,6 +20,27 @@ type Model struct {
 	lock      sync.RWMutex
 }
 
+func (m *Model) snapshot() (revert func()) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	list := append([]*Item(nil), m.List...)
+	values := make([]*big.Int, 0, len(list))
+	for _, item := range list {
+		values = append(values, new(big.Int).Set(item.Value))
+	}
+
+	return func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		for i, item := range list {
+			item.Value.Set(values[i])
+		}
+		m.List = list
+	}
+}
+
 func (m *Model) AddToList(candidateId uint32, coin types.CoinID, value *big.Int) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -0,0 +1,118 @@
+package waitlist
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+// routePrefix is followed by the address of the delegator
+const routePrefix = mainPrefix + 'r'
+
+// Route is the auto-route preference of the delegator: stakes kicked from full candidates
+// are frozen to be moved to the CandidateID, or returned to the balance if it is zero
+type Route struct {
+	CandidateID uint32
+
+	deleted bool
+}
+
+func routePath(address types.Address) []byte {
+	return append([]byte{routePrefix}, address.Bytes()...)
+}
+
+// GetRoute returns the auto-route preference of the address, nil if kicked stakes are kept in the waitlist
+func (wl *WaitList) GetRoute(address types.Address) *Route {
+	wl.lock.RLock()
+	defer wl.lock.RUnlock()
+
+	return wl.getRoute(address)
+}
+
+func (wl *WaitList) getRoute(address types.Address) *Route {
+	if route, ok := wl.routes[address]; ok {
+		if route.deleted {
+			return nil
+		}
+		return route
+	}
+
+	_, enc := wl.immutableTree().Get(routePath(address))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	route := &Route{}
+	if err := rlp.DecodeBytes(enc, route); err != nil {
+		panic(fmt.Sprintf("failed to decode route of address %s: %s", address.String(), err))
+	}
+
+	return route
+}
+
+// SetRoute sets the candidate which stakes of the address kicked from full candidates are moved to, zero candidateID returns them to the balance
+func (wl *WaitList) SetRoute(address types.Address, candidateID uint32) {
+	wl.lock.Lock()
+	defer wl.lock.Unlock()
+
+	wl.routes[address] = &Route{CandidateID: candidateID}
+}
+
+// DeleteRoute turns off the auto-route of the address, kicked stakes are kept in the waitlist
+func (wl *WaitList) DeleteRoute(address types.Address) {
+	wl.lock.Lock()
+	defer wl.lock.Unlock()
+
+	wl.routes[address] = &Route{deleted: true}
+}
+
+func (wl *WaitList) commitRoutes(db *iavl.MutableTree) error {
+	wl.lock.Lock()
+	defer wl.lock.Unlock()
+
+	addresses := make([]types.Address, 0, len(wl.routes))
+	for address := range wl.routes {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) == -1
+	})
+
+	for _, address := range addresses {
+		route := wl.routes[address]
+		if route.deleted {
+			db.Remove(routePath(address))
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(route)
+		if err != nil {
+			return fmt.Errorf("can't encode route of address %s: %v", address.String(), err)
+		}
+		db.Set(routePath(address), data)
+	}
+
+	wl.routes = map[types.Address]*Route{}
+
+	return nil
+}
+
+func (wl *WaitList) exportRoutes(state *types.AppState) {
+	wl.immutableTree().IterateRange([]byte{routePrefix}, []byte{routePrefix + 1}, true, func(key []byte, value []byte) bool {
+		address := types.BytesToAddress(key[1:])
+		route := &Route{}
+		if err := rlp.DecodeBytes(value, route); err != nil {
+			panic(fmt.Sprintf("failed to decode route of address %s: %s", address.String(), err))
+		}
+
+		state.AutoRoutes = append(state.AutoRoutes, types.AutoRoute{
+			Owner:       address,
+			CandidateID: uint64(route.CandidateID),
+		})
+		return false
+	})
+}
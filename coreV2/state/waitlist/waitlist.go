@@ -24,6 +24,7 @@ type RWaitList interface {
 	Get(address types.Address, pubkey types.Pubkey, coin types.CoinID) *Item
 	GetByAddress(address types.Address) *Model
 	GetByAddressAndPubKey(address types.Address, pubkey types.Pubkey) []*Item
+	GetRoute(address types.Address) *Route
 	Export(state *types.AppState)
 }
 
@@ -31,6 +32,8 @@ type WaitList struct {
 	list  map[types.Address]*Model
 	dirty map[types.Address]struct{}
 
+	routes map[types.Address]*Route
+
 	db atomic.Value
 
 	bus *bus.Bus
@@ -44,10 +47,11 @@ func NewWaitList(stateBus *bus.Bus, db *iavl.ImmutableTree) *WaitList {
 		immutableTree.Store(db)
 	}
 	waitlist := &WaitList{
-		bus:   stateBus,
-		db:    immutableTree,
-		list:  map[types.Address]*Model{},
-		dirty: map[types.Address]struct{}{},
+		bus:    stateBus,
+		db:     immutableTree,
+		list:   map[types.Address]*Model{},
+		dirty:  map[types.Address]struct{}{},
+		routes: map[types.Address]*Route{},
 	}
 	waitlist.bus.SetWaitList(NewBus(waitlist))
 
@@ -88,6 +92,8 @@ func (wl *WaitList) Export(state *types.AppState) {
 	sort.SliceStable(state.Waitlist, func(i, j int) bool {
 		return bytes.Compare(state.Waitlist[i].Owner.Bytes(), state.Waitlist[j].Owner.Bytes()) == 1
 	})
+
+	wl.exportRoutes(state)
 }
 
 // Deprecated
@@ -104,7 +110,7 @@ func (wl *WaitList) ExportV1(state *types.AppState, droppedIDs []uint32, height
 			for _, w := range model.List {
 				if _, ok := dropped[w.CandidateId]; ok {
 					state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-						Height:       height + types.GetUnbondPeriod(),
+						Height:       height + wl.unbondPeriod(),
 						CandidateID:  0,
 						CandidateKey: nil,
 						Address:      address,
@@ -156,7 +162,39 @@ func (wl *WaitList) Commit(db *iavl.MutableTree, version int64) error {
 		w.lock.RUnlock()
 	}
 
-	return nil
+	return wl.commitRoutes(db)
+}
+
+// Snapshot returns the function which reverts all changes of waitlists and routes made after the call
+func (wl *WaitList) Snapshot() (revert func()) {
+	wl.lock.RLock()
+	defer wl.lock.RUnlock()
+
+	reverts := make([]func(), 0, len(wl.list))
+	list := make(map[types.Address]*Model, len(wl.list))
+	for address, w := range wl.list {
+		list[address] = w
+		reverts = append(reverts, w.snapshot())
+	}
+	dirty := make(map[types.Address]struct{}, len(wl.dirty))
+	for address := range wl.dirty {
+		dirty[address] = struct{}{}
+	}
+	routes := make(map[types.Address]*Route, len(wl.routes))
+	for address, route := range wl.routes {
+		routes[address] = route
+	}
+
+	return func() {
+		for _, revert := range reverts {
+			revert()
+		}
+
+		wl.lock.Lock()
+		defer wl.lock.Unlock()
+
+		wl.list, wl.dirty, wl.routes = list, dirty, routes
+	}
 }
 
 func (wl *WaitList) GetByAddress(address types.Address) *Model {
@@ -316,3 +354,10 @@ func (wl *WaitList) getOrderedDirty() []types.Address {
 
 	return keys
 }
+
+func (wl *WaitList) unbondPeriod() uint64 {
+	if wl.bus.Params() == nil {
+		return types.GetUnbondPeriod()
+	}
+	return wl.bus.Params().UnbondPeriod()
+}
@@ -86,3 +86,50 @@ func TestWaitListToPartialDelete(t *testing.T) {
 		t.Fatal("Invalid waitlist data")
 	}
 }
+
+func TestWaitListRoutes(t *testing.T) {
+	t.Parallel()
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+
+	wl := NewWaitList(b, mutableTree.GetLastImmutable())
+
+	addr, other := types.Address{0}, types.Address{1}
+	if wl.GetRoute(addr) != nil {
+		t.Fatal("Route is enabled by default")
+	}
+
+	wl.SetRoute(addr, 2)
+	wl.SetRoute(other, 0)
+
+	_, _, err := mutableTree.Commit(wl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wl = NewWaitList(b, mutableTree.GetLastImmutable())
+	if route := wl.GetRoute(addr); route == nil || route.CandidateID != 2 {
+		t.Fatal("Route is not saved")
+	}
+	if candidateID, enabled := NewBus(wl).AutoRoute(other); !enabled || candidateID != 0 {
+		t.Fatal("Route to the balance is not saved")
+	}
+
+	wl.DeleteRoute(addr)
+	_, _, err = mutableTree.Commit(wl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wl = NewWaitList(b, mutableTree.GetLastImmutable())
+	if wl.GetRoute(addr) != nil {
+		t.Fatal("Route is not deleted")
+	}
+
+	appState := new(types.AppState)
+	wl.Export(appState)
+	if len(appState.AutoRoutes) != 1 || appState.AutoRoutes[0].Owner != other {
+		t.Fatalf("Invalid exported routes: %+v", appState.AutoRoutes)
+	}
+}
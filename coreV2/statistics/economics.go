@@ -0,0 +1,302 @@
+package statistics
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const otherLabel = "other"
+
+// Economics publishes chain level metrics of every committed block
+type Economics struct {
+	maxValidators int
+	maxPools      int
+	maxCoins      int
+
+	stateForHeight func(height uint64) (*state.CheckState, error)
+
+	block struct {
+		sync.Mutex
+		txs         map[string]int
+		failed      map[string]int
+		commissions map[types.CoinID]*big.Int
+	}
+	coins struct {
+		sync.Mutex
+		labels map[types.CoinID]string
+	}
+	cC chan uint64
+
+	txs            *prometheus.CounterVec
+	txsFailed      *prometheus.CounterVec
+	commission     *prometheus.CounterVec
+	rewardPool     prometheus.Gauge
+	emission       prometheus.Gauge
+	totalStake     prometheus.Gauge
+	mempoolSize    prometheus.Gauge
+	accumReward    *prometheus.GaugeVec
+	missedBlocks   *prometheus.GaugeVec
+	poolReserve    *prometheus.GaugeVec
+	poolOpenOrders *prometheus.GaugeVec
+	poolsHeight    prometheus.Gauge
+}
+
+// CommitRequest contains values of the deliver state at the moment of commit
+type CommitRequest struct {
+	Height      uint64
+	RewardPool  *big.Int
+	Emission    *big.Int
+	TotalStake  *big.Int
+	MempoolSize int
+	Validators  []*ValidatorInfo
+}
+
+// ValidatorInfo is a copy of validator values at the moment of commit
+type ValidatorInfo struct {
+	PubKey       types.Pubkey
+	TotalStake   *big.Int
+	AccumReward  *big.Int
+	MissedBlocks int
+}
+
+// NewEconomics registers chain metrics, labels of validators, pools and coins are limited by given values
+func NewEconomics(maxValidators, maxPools, maxCoins int, stateForHeight func(height uint64) (*state.CheckState, error)) *Economics {
+	e := &Economics{
+		maxValidators:  maxValidators,
+		maxPools:       maxPools,
+		maxCoins:       maxCoins,
+		stateForHeight: stateForHeight,
+		cC:             make(chan uint64, 1),
+		txs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chain_txs_total",
+			Help: "Delivered transactions by type",
+		}, []string{"type"}),
+		txsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chain_txs_failed_total",
+			Help: "Delivered transactions with non-zero code by type, fail rate is chain_txs_failed_total / chain_txs_total",
+		}, []string{"type"}),
+		commission: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chain_commission_total",
+			Help: "Commission collected by coin",
+		}, []string{"coin"}),
+		rewardPool: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "chain_reward_pool",
+			Help: "Reward pool of the last block",
+		}),
+		emission: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "chain_emission",
+			Help: "Total emission",
+		}),
+		totalStake: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "chain_total_stake",
+			Help: "Total stake of candidates in base coin",
+		}),
+		mempoolSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "chain_mempool_size",
+			Help: "Number of transactions in mempool",
+		}),
+		accumReward: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "chain_validator_accum_reward",
+			Help: "Accumulated rewards of validators with the biggest stake",
+		}, []string{"pubkey"}),
+		missedBlocks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "chain_validator_missed_blocks",
+			Help: "Missed blocks of validators with the biggest stake",
+		}, []string{"pubkey"}),
+		poolReserve: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "chain_pool_reserve",
+			Help: "Reserves of swap pools with the biggest base coin reserve",
+		}, []string{"pool", "coin"}),
+		poolOpenOrders: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "chain_pool_open_orders",
+			Help: "Open limit orders of swap pools with the biggest base coin reserve",
+		}, []string{"pool"}),
+		poolsHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "chain_pools_height",
+			Help: "Height of the state used for pool metrics",
+		}),
+	}
+	prometheus.MustRegister(e.txs, e.txsFailed, e.commission, e.rewardPool, e.emission, e.totalStake, e.mempoolSize,
+		e.accumReward, e.missedBlocks, e.poolReserve, e.poolOpenOrders, e.poolsHeight)
+
+	e.resetBlock()
+	e.coins.labels = map[types.CoinID]string{}
+
+	return e
+}
+
+func (e *Economics) resetBlock() {
+	e.block.txs = map[string]int{}
+	e.block.failed = map[string]int{}
+	e.block.commissions = map[types.CoinID]*big.Int{}
+}
+
+// PushTx accumulates delivered transaction, metrics are published on commit
+func (e *Economics) PushTx(txType string, failed bool, commissionCoin types.CoinID, commission *big.Int) {
+	if e == nil {
+		return
+	}
+
+	e.block.Lock()
+	defer e.block.Unlock()
+
+	e.block.txs[txType]++
+	if failed {
+		e.block.failed[txType]++
+	}
+
+	if commission == nil || commission.Sign() != 1 {
+		return
+	}
+	value, ok := e.block.commissions[commissionCoin]
+	if !ok {
+		value = big.NewInt(0)
+		e.block.commissions[commissionCoin] = value
+	}
+	value.Add(value, commission)
+}
+
+// PushCommit publishes metrics of committed block, pool metrics are collected in background from the committed state
+func (e *Economics) PushCommit(req *CommitRequest) {
+	if e == nil {
+		return
+	}
+
+	e.block.Lock()
+	for txType, count := range e.block.txs {
+		e.txs.WithLabelValues(txType).Add(float64(count))
+		e.txsFailed.WithLabelValues(txType).Add(float64(e.block.failed[txType]))
+	}
+	for coin, value := range e.block.commissions {
+		e.commission.WithLabelValues(e.coinLabel(coin)).Add(pipToFloat(value))
+	}
+	e.resetBlock()
+	e.block.Unlock()
+
+	e.rewardPool.Set(pipToFloat(req.RewardPool))
+	e.emission.Set(pipToFloat(req.Emission))
+	e.totalStake.Set(pipToFloat(req.TotalStake))
+	e.mempoolSize.Set(float64(req.MempoolSize))
+
+	validators := append([]*ValidatorInfo(nil), req.Validators...)
+	sort.SliceStable(validators, func(i, j int) bool {
+		return validators[i].TotalStake.Cmp(validators[j].TotalStake) == 1
+	})
+	if len(validators) > e.maxValidators {
+		validators = validators[:e.maxValidators]
+	}
+	e.accumReward.Reset()
+	e.missedBlocks.Reset()
+	for _, validator := range validators {
+		e.accumReward.WithLabelValues(validator.PubKey.String()).Set(pipToFloat(validator.AccumReward))
+		e.missedBlocks.WithLabelValues(validator.PubKey.String()).Set(float64(validator.MissedBlocks))
+	}
+
+	// skip the height if previous one is still collecting
+	select {
+	case e.cC <- req.Height:
+	default:
+	}
+}
+
+// coinLabel returns coin id as label, coins above the limit share one label
+func (e *Economics) coinLabel(coin types.CoinID) string {
+	e.coins.Lock()
+	defer e.coins.Unlock()
+
+	if label, ok := e.coins.labels[coin]; ok {
+		return label
+	}
+	if len(e.coins.labels) >= e.maxCoins {
+		return otherLabel
+	}
+	label := coin.String()
+	e.coins.labels[coin] = label
+	return label
+}
+
+// Collect handles committed heights until ctx is done
+func (e *Economics) Collect(ctx context.Context) {
+	if e == nil {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case height := <-e.cC:
+			e.collectPools(ctx, height)
+		}
+	}
+}
+
+func (e *Economics) collectPools(ctx context.Context, height uint64) {
+	if e.maxPools <= 0 {
+		return
+	}
+
+	cState, err := e.stateForHeight(height)
+	if err != nil {
+		return
+	}
+
+	type poolReserve struct {
+		pool      swap.EditableChecker
+		reserve   *big.Int
+		coin      types.CoinID
+		reserveIn *big.Int
+	}
+	var pools []*poolReserve
+	for _, pool := range cState.Swap().SwapPools(ctx) {
+		reserve0, reserve1 := pool.Reserves()
+		switch types.GetBaseCoinID() {
+		case pool.Coin0():
+			pools = append(pools, &poolReserve{pool: pool, reserve: reserve0, coin: pool.Coin1(), reserveIn: reserve1})
+		case pool.Coin1():
+			pools = append(pools, &poolReserve{pool: pool, reserve: reserve1, coin: pool.Coin0(), reserveIn: reserve0})
+		}
+	}
+	sort.SliceStable(pools, func(i, j int) bool {
+		return pools[i].reserve.Cmp(pools[j].reserve) == 1
+	})
+	if len(pools) > e.maxPools {
+		pools = pools[:e.maxPools]
+	}
+
+	e.poolReserve.Reset()
+	e.poolOpenOrders.Reset()
+	for _, p := range pools {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		id := strconv.Itoa(int(p.pool.GetID()))
+		e.poolReserve.WithLabelValues(id, types.GetBaseCoinID().String()).Set(pipToFloat(p.reserve))
+		e.poolReserve.WithLabelValues(id, p.coin.String()).Set(pipToFloat(p.reserveIn))
+
+		orders := len(p.pool.OrdersSell(^uint32(0))) + len(p.pool.Reverse().OrdersSell(^uint32(0)))
+		e.poolOpenOrders.WithLabelValues(id).Set(float64(orders))
+	}
+	e.poolsHeight.Set(float64(height))
+}
+
+var pipInBip = new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+func pipToFloat(value *big.Int) float64 {
+	if value == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(value), pipInBip).Float64()
+	return f
+}
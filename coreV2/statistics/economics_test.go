@@ -0,0 +1,60 @@
+package statistics
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEconomics_PushCommit(t *testing.T) {
+	e := NewEconomics(1, 0, 2, nil)
+
+	e.PushTx("0x01", false, 0, helpers.BipToPip(big.NewInt(1)))
+	e.PushTx("0x01", true, 1, helpers.BipToPip(big.NewInt(2)))
+	e.PushTx("0x02", false, 2, helpers.BipToPip(big.NewInt(3)))
+	e.PushTx("0x02", false, 3, helpers.BipToPip(big.NewInt(4)))
+
+	if got := testutil.ToFloat64(e.txs.WithLabelValues("0x01")); got != 0 {
+		t.Fatalf("metrics are published before commit: %f", got)
+	}
+
+	e.PushCommit(&CommitRequest{
+		Height:     1,
+		RewardPool: helpers.BipToPip(big.NewInt(10)),
+		Emission:   helpers.BipToPip(big.NewInt(100)),
+		TotalStake: helpers.BipToPip(big.NewInt(1000)),
+		Validators: []*ValidatorInfo{
+			{PubKey: types.Pubkey{1}, TotalStake: big.NewInt(1), AccumReward: big.NewInt(0)},
+			{PubKey: types.Pubkey{2}, TotalStake: big.NewInt(2), AccumReward: helpers.BipToPip(big.NewInt(5)), MissedBlocks: 3},
+		},
+	})
+
+	if got := testutil.ToFloat64(e.txs.WithLabelValues("0x01")); got != 2 {
+		t.Errorf("txs of type 0x01 want 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(e.txsFailed.WithLabelValues("0x01")); got != 1 {
+		t.Errorf("failed txs of type 0x01 want 1, got %f", got)
+	}
+	if got := testutil.CollectAndCount(e.commission); got != 3 {
+		t.Errorf("commission labels want 3 (2 coins and other), got %d", got)
+	}
+	total := testutil.ToFloat64(e.commission.WithLabelValues(otherLabel))
+	for _, label := range e.coins.labels {
+		total += testutil.ToFloat64(e.commission.WithLabelValues(label))
+	}
+	if total != 10 {
+		t.Errorf("total commission want 10, got %f", total)
+	}
+	if got := testutil.ToFloat64(e.rewardPool); got != 10 {
+		t.Errorf("reward pool want 10, got %f", got)
+	}
+	if got := testutil.CollectAndCount(e.accumReward); got != 1 {
+		t.Errorf("validator labels want 1, got %d", got)
+	}
+	if got := testutil.ToFloat64(e.missedBlocks.WithLabelValues(types.Pubkey{2}.String())); got != 3 {
+		t.Errorf("missed blocks of validator with the biggest stake want 3, got %f", got)
+	}
+}
@@ -0,0 +1,277 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// Modes of the limit order
+const (
+	// LimitOrderModeDefault matches the part of the order crossing the pool price and adds the rest to the order book
+	LimitOrderModeDefault uint8 = iota
+	// LimitOrderModeImmediateOrCancel matches the order against the pool and orders and returns the rest to the sender
+	LimitOrderModeImmediateOrCancel
+	// LimitOrderModePostOnly adds the order to the order book only if it doesn't cross the pool price
+	LimitOrderModePostOnly
+)
+
+// AddLimitOrderV2Data is the limit order with the optional expire height and the mode of execution.
+// Zero ExpireHeight means the order is expired by the global period
+type AddLimitOrderV2Data struct {
+	CoinToSell   types.CoinID
+	ValueToSell  *big.Int
+	CoinToBuy    types.CoinID
+	ValueToBuy   *big.Int
+	ExpireHeight uint64
+	Mode         uint8
+}
+
+func (data AddLimitOrderV2Data) Gas() int64 {
+	return gasAddLimitOrder
+}
+func (data AddLimitOrderV2Data) TxType() TxType {
+	return TypeAddLimitOrderV2
+}
+
+func (data AddLimitOrderV2Data) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if data.Mode > LimitOrderModePostOnly {
+		return &Response{
+			Code: code.WrongOrderMode,
+			Log:  fmt.Sprintf("unknown order mode %d", data.Mode),
+			Info: EncodeError(code.NewWrongOrderMode(strconv.Itoa(int(data.Mode)))),
+		}
+	}
+
+	if data.ExpireHeight != 0 {
+		minHeight, maxHeight := block+1, block+context.Params().Get(params.ExpireOrdersPeriod)
+		if data.Mode == LimitOrderModeImmediateOrCancel {
+			minHeight, maxHeight = 0, 0
+		}
+		if data.ExpireHeight < minHeight || data.ExpireHeight > maxHeight {
+			return &Response{
+				Code: code.WrongOrderExpireHeight,
+				Log:  fmt.Sprintf("expire height %d must be from %d to %d", data.ExpireHeight, minHeight, maxHeight),
+				Info: EncodeError(code.NewWrongOrderExpireHeight(strconv.FormatUint(data.ExpireHeight, 10), strconv.FormatUint(minHeight, 10), strconv.FormatUint(maxHeight, 10))),
+			}
+		}
+	}
+
+	return AddLimitOrderData{
+		CoinToSell:  data.CoinToSell,
+		ValueToSell: data.ValueToSell,
+		CoinToBuy:   data.CoinToBuy,
+		ValueToBuy:  data.ValueToBuy,
+	}.basicCheck(tx, context)
+}
+
+func (data AddLimitOrderV2Data) String() string {
+	return fmt.Sprintf("ADD ORDER V2 mode: %d expire: %d", data.Mode, data.ExpireHeight)
+}
+
+func (data AddLimitOrderV2Data) CommissionData(price *commission.Price) *big.Int {
+	return price.AddLimitOrder
+}
+
+func (data AddLimitOrderV2Data) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	const precision = 34
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	amountSell := new(big.Int).Set(data.ValueToSell)
+	if tx.GasCoin != data.CoinToSell {
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	} else {
+		amountSell.Add(amountSell, commission)
+	}
+	coinToSell := checkState.Coins().GetCoin(data.CoinToSell)
+	if checkState.Accounts().GetBalance(sender, data.CoinToSell).Cmp(amountSell) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), amountSell.String(), coinToSell.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), amountSell.String(), coinToSell.GetFullSymbol(), coinToSell.ID().String())),
+		}
+	}
+
+	swapper := checkState.Swap().GetSwapper(data.CoinToSell, data.CoinToBuy)
+	if isGasCommissionFromPoolSwap && swapper.GetID() == commissionPoolSwapper.GetID() {
+		commissionInBaseCoin, _ = commissionPoolSwapper.CalculateBuyForSellWithOrders(commission)
+		if tx.GasCoin == data.CoinToSell && data.CoinToBuy.IsBaseCoin() {
+			swapper = swapper.AddLastSwapStepWithOrders(commission, commissionInBaseCoin, true)
+		}
+		if tx.GasCoin == data.CoinToBuy && data.CoinToSell.IsBaseCoin() {
+			swapper = swapper.AddLastSwapStepWithOrders(big.NewInt(0).Neg(commissionInBaseCoin), big.NewInt(0).Neg(commission), true)
+		}
+	}
+	currentPrice := swapper.Reverse().PriceRat()
+	orderPrice := swap.CalcPriceSellRat(data.ValueToBuy, data.ValueToSell)
+	crossing := currentPrice.Cmp(orderPrice) == -1
+
+	if crossing && data.Mode == LimitOrderModePostOnly {
+		return Response{
+			Code: code.OrderWouldCross,
+			Log:  fmt.Sprintf("order price is %s, but post-only order must not exceed %s", orderPrice.FloatString(precision), currentPrice.FloatString(precision)),
+			Info: EncodeError(code.NewOrderWouldCross(currentPrice.FloatString(precision), orderPrice.FloatString(precision))),
+		}
+	}
+
+	if !crossing {
+		if data.Mode == LimitOrderModeImmediateOrCancel {
+			return Response{
+				Code: code.OrderNotMatched,
+				Log:  fmt.Sprintf("order price is %s, but must exceed %s to be matched", orderPrice.FloatString(precision), currentPrice.FloatString(precision)),
+				Info: EncodeError(code.NewOrderNotMatched(currentPrice.FloatString(precision), orderPrice.FloatString(precision))),
+			}
+		}
+
+		maxPrice := new(big.Rat).Quo(currentPrice, big.NewRat(5, 1))
+		if maxPrice.Cmp(orderPrice) == 1 {
+			return Response{
+				Code: code.WrongOrderPrice,
+				Log:  fmt.Sprintf("order price is %s, but must not exceed %s and more than %s", orderPrice.FloatString(precision), currentPrice.FloatString(precision), maxPrice.FloatString(precision)),
+				Info: EncodeError(code.NewWrongOrderPrice(currentPrice.FloatString(precision), maxPrice.FloatString(precision), orderPrice.FloatString(precision))),
+			}
+		}
+	}
+
+	matched, minAmountOut := big.NewInt(0), big.NewInt(0)
+	if crossing {
+		matched = swapper.CalculateSellForPriceWithOrders(data.ValueToSell, swap.CalcPriceSellRat(data.ValueToSell, data.ValueToBuy))
+		if matched.Sign() == 1 {
+			// the average price of the matched part must not be worse than the price of the order
+			minAmountOut.Mul(data.ValueToBuy, matched)
+			minAmountOut.Quo(minAmountOut, data.ValueToSell)
+			coinToBuy := checkState.Coins().GetCoin(data.CoinToBuy)
+			errResp, amountOut, _ := CheckSwap(swapper, coinToSell, coinToBuy, matched, big.NewInt(0), false)
+			if errResp != nil {
+				return *errResp
+			}
+			if amountOut == nil || amountOut.Sign() != 1 || amountOut.Cmp(minAmountOut) == -1 {
+				matched = big.NewInt(0)
+			}
+		}
+		if matched.Sign() != 1 {
+			return Response{
+				Code: code.OrderNotMatched,
+				Log:  fmt.Sprintf("order with price %s can't be matched with the pool price %s", orderPrice.FloatString(precision), currentPrice.FloatString(precision)),
+				Info: EncodeError(code.NewOrderNotMatched(currentPrice.FloatString(precision), orderPrice.FloatString(precision))),
+			}
+		}
+	}
+
+	// the rest of the order is added to the order book if its volumes are not less than minimal
+	rest := new(big.Int).Sub(data.ValueToSell, matched)
+	restToBuy := new(big.Int).Mul(data.ValueToBuy, rest)
+	restToBuy.Add(restToBuy, new(big.Int).Sub(data.ValueToSell, big.NewInt(1)))
+	restToBuy.Quo(restToBuy, data.ValueToSell)
+	addRest := data.Mode != LimitOrderModeImmediateOrCancel &&
+		rest.Cmp(big.NewInt(swap.MinimumOrderVolume())) != -1 &&
+		restToBuy.Cmp(big.NewInt(swap.MinimumOrderVolume())) != -1
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		var poolIDs tagPoolsChange
+		amountOut := big.NewInt(0)
+		if matched.Sign() == 1 {
+			amountIn, out, poolID, details, owners := deliverState.Swapper().PairSellWithOrders(data.CoinToSell, data.CoinToBuy, matched, minAmountOut)
+			for _, value := range owners {
+				deliverState.Accounts.AddBalance(value.Owner, data.CoinToSell, value.ValueBigInt)
+			}
+			deliverState.Accounts.SubBalance(sender, data.CoinToSell, amountIn)
+			deliverState.Accounts.AddBalance(sender, data.CoinToBuy, out)
+			amountOut = out
+
+			poolIDs = append(poolIDs, &tagPoolChange{
+				PoolID:   poolID,
+				CoinIn:   data.CoinToSell,
+				ValueIn:  amountIn.String(),
+				CoinOut:  data.CoinToBuy,
+				ValueOut: out.String(),
+				Orders:   details,
+			})
+		}
+
+		var orderID, poolID uint32
+		if addRest {
+			deliverState.Accounts.SubBalance(sender, data.CoinToSell, rest)
+			orderID, poolID = deliverState.SwapV2.PairAddOrderWithExpiry(data.CoinToBuy, data.CoinToSell, restToBuy, rest, sender, currentBlock, data.ExpireHeight)
+		}
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.pool_id"), Value: []byte(strconv.Itoa(int(poolID)))},
+			{Key: []byte("tx.order_id"), Value: []byte(strconv.Itoa(int(orderID)))},
+			{Key: []byte("tx.order_mode"), Value: []byte(strconv.Itoa(int(data.Mode)))},
+			{Key: []byte("tx.return"), Value: []byte(amountOut.String())},
+			{Key: []byte("tx.pools"), Value: []byte(poolIDs.string())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,172 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	db "github.com/tendermint/tm-db"
+)
+
+func getStateV3() *state.State {
+	s, err := state.NewStateV3(0, db.NewMemDB(), &events.MockEvents{}, 1, 1, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	s.Validators.Create(types.Pubkey{}, big.NewInt(1))
+	s.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, types.Pubkey{}, 10, 0, 0)
+	s.Commission.SetNewCommissions(commissionPrice.Encode())
+	return s
+}
+
+func TestAddOrderV2_ImmediateOrCancel(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	coin1 := createNonReserveCoin(cState)
+	coin := types.GetBaseCoinID()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.SubBalance(types.Address{}, coin1, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.AddBalance(addr, coin1, helpers.BipToPip(big.NewInt(100000)))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   coin,
+		Volume0: helpers.BipToPip(big.NewInt(10)),
+		Coin1:   coin1,
+		Volume1: helpers.BipToPip(big.NewInt(10)),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	data := AddLimitOrderV2Data{
+		CoinToSell:  coin,
+		ValueToSell: helpers.BipToPip(big.NewInt(5)),
+		CoinToBuy:   coin1,
+		ValueToBuy:  helpers.BipToPip(big.NewInt(4)),
+		Mode:        LimitOrderModePostOnly,
+	}
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddLimitOrderV2, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OrderWouldCross {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.OrderWouldCross, response.Log)
+	}
+
+	data.Mode = LimitOrderModeImmediateOrCancel
+	data.ExpireHeight = 10
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddLimitOrderV2, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongOrderExpireHeight {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongOrderExpireHeight, response.Log)
+	}
+
+	balanceBefore := cState.Accounts.GetBalance(addr, coin)
+	balance1Before := cState.Accounts.GetBalance(addr, coin1)
+
+	data.ExpireHeight = 0
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddLimitOrderV2, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	spent := big.NewInt(0).Sub(balanceBefore, cState.Accounts.GetBalance(addr, coin))
+	spent.Sub(spent, cState.Commission.GetCommissions().AddLimitOrder)
+	if spent.Sign() != 1 || spent.Cmp(data.ValueToSell) != -1 {
+		t.Fatalf("Spent value %s must be positive and less than %s", spent, data.ValueToSell)
+	}
+
+	bought := big.NewInt(0).Sub(cState.Accounts.GetBalance(addr, coin1), balance1Before)
+	if new(big.Rat).SetFrac(bought, spent).Cmp(new(big.Rat).SetFrac(data.ValueToBuy, data.ValueToSell)) == -1 {
+		t.Fatalf("Bought %s for %s, the price is worse than the limit", bought, spent)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 3, TypeAddLimitOrderV2, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OrderNotMatched {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.OrderNotMatched, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddOrderV2_ExpireHeight(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	coin1 := createNonReserveCoin(cState)
+	coin := types.GetBaseCoinID()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.SubBalance(types.Address{}, coin1, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.AddBalance(addr, coin1, helpers.BipToPip(big.NewInt(100000)))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   coin,
+		Volume0: helpers.BipToPip(big.NewInt(10)),
+		Coin1:   coin1,
+		Volume1: helpers.BipToPip(big.NewInt(10)),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	data := AddLimitOrderV2Data{
+		CoinToSell:   coin,
+		ValueToSell:  helpers.BipToPip(big.NewInt(5)),
+		CoinToBuy:    coin1,
+		ValueToBuy:   helpers.BipToPip(big.NewInt(10)),
+		ExpireHeight: 1,
+		Mode:         LimitOrderModePostOnly,
+	}
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddLimitOrderV2, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongOrderExpireHeight {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongOrderExpireHeight, response.Log)
+	}
+
+	data.ExpireHeight = 5
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddLimitOrderV2, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	order := cState.SwapV2.GetOrder(1)
+	if order == nil || order.GetExpireHeight() != 5 {
+		t.Fatalf("Order with expire height is not found: %v", order)
+	}
+
+	balance := cState.Accounts.GetBalance(addr, coin)
+
+	cState.SwapV2.ExpireOrdersByHeight(5)
+	if cState.SwapV2.GetOrder(1) == nil {
+		t.Fatal("Order is expired at its expire height")
+	}
+
+	cState.SwapV2.ExpireOrdersByHeight(6)
+	if cState.SwapV2.GetOrder(1) != nil {
+		t.Fatal("Order is not expired after its expire height")
+	}
+
+	expectedBalance := big.NewInt(0).Add(balance, data.ValueToSell)
+	if newBalance := cState.Accounts.GetBalance(addr, coin); newBalance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Balance is not correct. Expected %s, got %s", expectedBalance, newBalance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
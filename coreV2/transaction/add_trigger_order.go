@@ -0,0 +1,188 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// AddTriggerOrderData locks ValueToSell off the order book until the pool price of CoinToSell in CoinToBuy
+// crosses TriggerValueToBuy/ValueToSell, then ValueToSell is sold through the pool for not less than MinimumValueToBuy.
+// The order is the stop-loss if its trigger price is below the current pool price and the take-profit if it is above
+type AddTriggerOrderData struct {
+	CoinToSell        types.CoinID
+	ValueToSell       *big.Int
+	CoinToBuy         types.CoinID
+	TriggerValueToBuy *big.Int
+	MinimumValueToBuy *big.Int
+}
+
+func (data AddTriggerOrderData) Gas() int64 {
+	return gasAddLimitOrder
+}
+func (data AddTriggerOrderData) TxType() TxType {
+	return TypeAddTriggerOrder
+}
+
+func (data AddTriggerOrderData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.CoinToSell == data.CoinToBuy {
+		return &Response{
+			Code: code.CrossConvert,
+			Log:  "\"From\" coin equals to \"to\" coin",
+			Info: EncodeError(code.NewCrossConvert(
+				data.CoinToBuy.String(),
+				data.CoinToSell.String(), "", "")),
+		}
+	}
+
+	if data.ValueToSell.Cmp(big.NewInt(swap.MinimumOrderVolume())) == -1 ||
+		data.TriggerValueToBuy.Sign() != 1 || data.MinimumValueToBuy.Sign() != 1 {
+		return &Response{
+			Code: code.WrongOrderVolume,
+			Log:  fmt.Sprintf("minimum volume to sell is %d, trigger and minimum volumes to buy must be positive", swap.MinimumOrderVolume()),
+			Info: EncodeError(code.NewWrongOrderVolume(data.MinimumValueToBuy.String(), data.ValueToSell.String())),
+		}
+	}
+
+	swapper := context.Swap().GetSwapper(data.CoinToSell, data.CoinToBuy)
+	if !swapper.Exists() {
+		return &Response{
+			Code: code.PairNotExists,
+			Log:  "swap pool not found",
+			Info: EncodeError(code.NewPairNotExists(
+				data.CoinToSell.String(),
+				data.CoinToBuy.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data AddTriggerOrderData) String() string {
+	return fmt.Sprintf("ADD TRIGGER ORDER")
+}
+
+func (data AddTriggerOrderData) CommissionData(price *commission.Price) *big.Int {
+	return price.AddLimitOrder
+}
+
+func (data AddTriggerOrderData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	const precision = 34
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	amountSell := new(big.Int).Set(data.ValueToSell)
+	if tx.GasCoin != data.CoinToSell {
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	} else {
+		amountSell.Add(amountSell, commission)
+	}
+	if checkState.Accounts().GetBalance(sender, data.CoinToSell).Cmp(amountSell) < 0 {
+		coin := checkState.Coins().GetCoin(data.CoinToSell)
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), amountSell.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), amountSell.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	swapper := checkState.Swap().GetSwapper(data.CoinToSell, data.CoinToBuy)
+	if isGasCommissionFromPoolSwap && swapper.GetID() == commissionPoolSwapper.GetID() {
+		commissionInBaseCoin, _ = commissionPoolSwapper.CalculateBuyForSellWithOrders(commission)
+		if tx.GasCoin == data.CoinToSell && data.CoinToBuy.IsBaseCoin() {
+			swapper = swapper.AddLastSwapStepWithOrders(commission, commissionInBaseCoin, true)
+		}
+		if tx.GasCoin == data.CoinToBuy && data.CoinToSell.IsBaseCoin() {
+			swapper = swapper.AddLastSwapStepWithOrders(big.NewInt(0).Neg(commissionInBaseCoin), big.NewInt(0).Neg(commission), true)
+		}
+	}
+	currentPrice := swapper.PriceRat()
+	triggerPrice := swap.CalcPriceSellRat(data.ValueToSell, data.TriggerValueToBuy)
+	if currentPrice.Cmp(triggerPrice) == 0 {
+		return Response{
+			Code: code.WrongTriggerPrice,
+			Log:  fmt.Sprintf("trigger price %s must differ from the pool price", triggerPrice.FloatString(precision)),
+			Info: EncodeError(code.NewWrongTriggerPrice(currentPrice.FloatString(precision), triggerPrice.FloatString(precision))),
+		}
+	}
+	stopLoss := currentPrice.Cmp(triggerPrice) == 1
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Accounts.SubBalance(sender, data.CoinToSell, data.ValueToSell)
+		orderID := deliverState.SwapV2.AddTriggerOrder(data.CoinToBuy, data.CoinToSell, data.MinimumValueToBuy, data.ValueToSell, data.TriggerValueToBuy, stopLoss, sender, currentBlock)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.pool_id"), Value: []byte(strconv.Itoa(int(swapper.GetID())))},
+			{Key: []byte("tx.order_id"), Value: []byte(strconv.Itoa(int(orderID)))},
+			{Key: []byte("tx.stop_loss"), Value: []byte(strconv.FormatBool(stopLoss))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
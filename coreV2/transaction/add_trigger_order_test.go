@@ -0,0 +1,318 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestAddTriggerOrder_StopLoss(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	coin1 := createNonReserveCoin(cState)
+	coin := types.GetBaseCoinID()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.SubBalance(types.Address{}, coin1, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.AddBalance(addr, coin1, helpers.BipToPip(big.NewInt(100000)))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   coin,
+		Volume0: helpers.BipToPip(big.NewInt(10)),
+		Coin1:   coin1,
+		Volume1: helpers.BipToPip(big.NewInt(10)),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	data := AddTriggerOrderData{
+		CoinToSell:        coin1,
+		ValueToSell:       helpers.BipToPip(big.NewInt(1)),
+		CoinToBuy:         coin,
+		TriggerValueToBuy: helpers.BipToPip(big.NewInt(1)),
+		MinimumValueToBuy: big.NewInt(5e17),
+	}
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddTriggerOrder, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongTriggerPrice {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongTriggerPrice, response.Log)
+	}
+
+	data.TriggerValueToBuy = big.NewInt(8e17)
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddTriggerOrder, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	order := cState.SwapV2.GetTriggerOrder(1)
+	if order == nil || !order.StopLoss {
+		t.Fatalf("Stop-loss order is not found: %v", order)
+	}
+
+	cState.SwapV2.ExecuteTriggerOrders()
+	if cState.SwapV2.GetTriggerOrder(1) == nil {
+		t.Fatal("Order is executed before its trigger price")
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 3, TypeSellSwapPool, SellSwapPoolDataV260{
+		Coins:             []types.CoinID{coin1, coin},
+		ValueToSell:       helpers.BipToPip(big.NewInt(3)),
+		MinimumValueToBuy: big.NewInt(1),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+	balance := cState.Accounts.GetBalance(addr, coin)
+
+	cState.SwapV2.ExecuteTriggerOrders()
+	if cState.SwapV2.GetTriggerOrder(1) != nil {
+		t.Fatal("Order is not executed after its trigger price")
+	}
+
+	if bought := big.NewInt(0).Sub(cState.Accounts.GetBalance(addr, coin), balance); bought.Cmp(data.MinimumValueToBuy) == -1 {
+		t.Fatalf("Bought %s, less than minimum %s", bought, data.MinimumValueToBuy)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCancelTriggerOrder(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	coin1 := createNonReserveCoin(cState)
+	coin := types.GetBaseCoinID()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.SubBalance(types.Address{}, coin1, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.AddBalance(addr, coin1, helpers.BipToPip(big.NewInt(100000)))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   coin,
+		Volume0: helpers.BipToPip(big.NewInt(10)),
+		Coin1:   coin1,
+		Volume1: helpers.BipToPip(big.NewInt(10)),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	data := AddTriggerOrderData{
+		CoinToSell:        coin1,
+		ValueToSell:       helpers.BipToPip(big.NewInt(1)),
+		CoinToBuy:         coin,
+		TriggerValueToBuy: helpers.BipToPip(big.NewInt(2)),
+		MinimumValueToBuy: helpers.BipToPip(big.NewInt(1)),
+	}
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeAddTriggerOrder, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if order := cState.SwapV2.GetTriggerOrder(1); order == nil || order.StopLoss {
+		t.Fatalf("Take-profit order is not found: %v", order)
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	cState.Accounts.AddBalance(crypto.PubkeyToAddress(otherKey.PublicKey), coin, helpers.BipToPip(big.NewInt(1)))
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, otherKey, 1, TypeCancelTriggerOrder, CancelTriggerOrderData{ID: 1}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfOrder {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.IsNotOwnerOfOrder, response.Log)
+	}
+
+	balance := cState.Accounts.GetBalance(addr, coin1)
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 3, TypeCancelTriggerOrder, CancelTriggerOrderData{ID: 1}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if cState.SwapV2.GetTriggerOrder(1) != nil {
+		t.Fatal("Order is not canceled")
+	}
+
+	expectedBalance := big.NewInt(0).Add(balance, data.ValueToSell)
+	if newBalance := cState.Accounts.GetBalance(addr, coin1); newBalance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Balance is not correct. Expected %s, got %s", expectedBalance, newBalance)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 4, TypeCancelTriggerOrder, CancelTriggerOrderData{ID: 1}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OrderNotExists {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.OrderNotExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddTriggerOrder_NotFilled(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	coin1 := createNonReserveCoin(cState)
+	coin := types.GetBaseCoinID()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.SubBalance(types.Address{}, coin1, helpers.BipToPip(big.NewInt(100000)))
+	cState.Accounts.AddBalance(addr, coin1, helpers.BipToPip(big.NewInt(100000)))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   coin,
+		Volume0: helpers.BipToPip(big.NewInt(10)),
+		Coin1:   coin1,
+		Volume1: helpers.BipToPip(big.NewInt(10)),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	orders := []AddTriggerOrderData{
+		{CoinToSell: coin1, ValueToSell: helpers.BipToPip(big.NewInt(1)), CoinToBuy: coin, TriggerValueToBuy: big.NewInt(8e17), MinimumValueToBuy: big.NewInt(5e17)},
+		{CoinToSell: coin1, ValueToSell: helpers.BipToPip(big.NewInt(1)), CoinToBuy: coin, TriggerValueToBuy: big.NewInt(9e17), MinimumValueToBuy: big.NewInt(85e16)},
+	}
+	for i, data := range orders {
+		response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, uint64(i+2), TypeAddTriggerOrder, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != 0 {
+			t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+		}
+	}
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if count := countTriggerOrderPrices(cState); count != len(orders) {
+		t.Fatalf("%d orders are indexed by price, want %d", count, len(orders))
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 4, TypeSellSwapPool, SellSwapPoolDataV260{
+		Coins:             []types.CoinID{coin1, coin},
+		ValueToSell:       helpers.BipToPip(big.NewInt(3)),
+		MinimumValueToBuy: big.NewInt(1),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+	balance, balance1 := cState.Accounts.GetBalance(addr, coin), cState.Accounts.GetBalance(addr, coin1)
+
+	cState.SwapV2.ExecuteTriggerOrders()
+	if cState.SwapV2.GetTriggerOrder(1) != nil || cState.SwapV2.GetTriggerOrder(2) != nil {
+		t.Fatal("Triggered orders are not removed")
+	}
+
+	if bought := big.NewInt(0).Sub(cState.Accounts.GetBalance(addr, coin), balance); bought.Cmp(orders[0].MinimumValueToBuy) == -1 || bought.Cmp(orders[1].MinimumValueToBuy) != -1 {
+		t.Fatalf("Bought %s, only the first order must be executed", bought)
+	}
+
+	expectedBalance := big.NewInt(0).Add(balance1, orders[1].ValueToSell)
+	if newBalance := cState.Accounts.GetBalance(addr, coin1); newBalance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Not filled order is not returned. Expected %s, got %s", expectedBalance, newBalance)
+	}
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if count := countTriggerOrderPrices(cState); count != 0 {
+		t.Fatalf("%d removed orders are indexed by price", count)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExecuteTriggerOrders_NotChangedPool(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	coin := types.GetBaseCoinID()
+	coins := []types.CoinID{createNonReserveCoin(cState), createNonReserveCoin(cState)}
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100000)))
+	nonce := uint64(1)
+	for _, coin1 := range coins {
+		cState.Accounts.SubBalance(types.Address{}, coin1, helpers.BipToPip(big.NewInt(100000)))
+		cState.Accounts.AddBalance(addr, coin1, helpers.BipToPip(big.NewInt(100000)))
+
+		response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, nonce, TypeCreateSwapPool, CreateSwapPoolData{
+			Coin0:   coin,
+			Volume0: helpers.BipToPip(big.NewInt(10)),
+			Coin1:   coin1,
+			Volume1: helpers.BipToPip(big.NewInt(10)),
+		}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != 0 {
+			t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+		}
+
+		response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, nonce+1, TypeAddTriggerOrder, AddTriggerOrderData{
+			CoinToSell:        coin1,
+			ValueToSell:       helpers.BipToPip(big.NewInt(1)),
+			CoinToBuy:         coin,
+			TriggerValueToBuy: big.NewInt(8e17),
+			MinimumValueToBuy: big.NewInt(5e17),
+		}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != 0 {
+			t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+		}
+		nonce += 2
+	}
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the price of the second pool crosses the trigger price in the block before the orders are executed
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, nonce, TypeSellSwapPool, SellSwapPoolDataV260{
+		Coins:             []types.CoinID{coins[1], coin},
+		ValueToSell:       helpers.BipToPip(big.NewInt(3)),
+		MinimumValueToBuy: big.NewInt(1),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	cState.SwapV2.ExecuteTriggerOrders()
+	if cState.SwapV2.GetTriggerOrder(1) == nil {
+		t.Fatal("Order of the first pool is executed before its trigger price")
+	}
+	if cState.SwapV2.GetTriggerOrder(2) != nil {
+		t.Fatal("Order of the not changed pool is not executed after its trigger price")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func countTriggerOrderPrices(cState *state.State) int {
+	count := 0
+	cState.Tree().GetLastImmutable().IterateRange([]byte{'s', 'p'}, []byte{'s', 'q'}, true, func(key []byte, value []byte) bool {
+		count++
+		return false
+	})
+	return count
+}
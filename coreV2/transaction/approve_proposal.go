@@ -0,0 +1,195 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/proposals"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// ApproveProposalData adds approval of the signer to the proposal,
+// the proposed tx is sent when approvals reach the threshold of the multisig
+type ApproveProposalData struct {
+	ID uint32
+
+	decodeTxFunc func(txType TxType) (Data, bool)
+}
+
+func (data ApproveProposalData) TxType() TxType {
+	return TypeApproveProposal
+}
+
+func (data ApproveProposalData) Gas() int64 {
+	return gasApproveProposal
+}
+
+func (data *ApproveProposalData) decode(decodeTxFunc func(txType TxType) (Data, bool)) error {
+	data.decodeTxFunc = decodeTxFunc
+	return nil
+}
+
+func (data ApproveProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	return checkProposalVote(tx, context, data.ID, block)
+}
+
+func (data ApproveProposalData) String() string {
+	return fmt.Sprintf("APPROVE PROPOSAL id:%d", data.ID)
+}
+
+func (data ApproveProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.ApproveProposalPrice()
+}
+
+func (data ApproveProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	proposal := checkState.Proposals().Get(data.ID)
+	multisig := checkState.Accounts().GetAccount(proposal.Multisig).Multisig()
+	isExecuted := proposalWeight(&multisig, proposal.Approvals)+multisig.GetWeight(sender) >= multisig.Threshold
+	var proposalTags []abcTypes.EventAttribute
+	if isExecuted {
+		response := data.runProposalTx(tx, proposal, context, rewardPool, currentBlock)
+		if response.Code != code.OK {
+			return response
+		}
+		proposalTags = response.Tags
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		if isExecuted {
+			deliverState.Proposals.Delete(data.ID)
+		} else {
+			deliverState.Proposals.Approve(data.ID, sender)
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.proposal_id"), Value: []byte(strconv.Itoa(int(data.ID))), Index: true},
+			{Key: []byte("tx.proposal_executed"), Value: []byte(strconv.FormatBool(isExecuted))},
+		}
+		tags = append(tags, proposalTags...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+func (data ApproveProposalData) runProposalTx(tx *Transaction, proposal *proposals.Model, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	txType := TxType(proposal.TxType)
+	d, err := decodeProposalTx(txType, proposal.TxData, data.decodeTxFunc)
+	if err != nil {
+		return Response{
+			Code: code.ProposalTxFailed,
+			Log:  fmt.Sprintf("Tx of proposal %d failed: %s", proposal.ID, err),
+			Info: EncodeError(code.NewProposalTxFailed(proposal.ID, txType.String(), code.DecodeError, "")),
+		}
+	}
+
+	return runProposalTx(tx, proposal.ID, proposal.Multisig, proposal.GasCoin, txType, proposal.TxData, d, context, rewardPool, currentBlock)
+}
+
+// checkProposalVote checks the proposal is pending and the sender is a signer of its multisig who has not voted yet
+func checkProposalVote(tx *Transaction, context *state.CheckState, id uint32, block uint64) *Response {
+	proposal := context.Proposals().Get(id)
+	if proposal == nil || proposal.ExpireHeight <= block {
+		return &Response{
+			Code: code.ProposalNotExists,
+			Log:  fmt.Sprintf("Proposal %d not exists", id),
+			Info: EncodeError(code.NewProposalNotExists(id)),
+		}
+	}
+
+	multisig := context.Accounts().GetAccount(proposal.Multisig)
+	if !multisig.IsMultisig() {
+		return &Response{
+			Code: code.MultisigNotExists,
+			Log:  "Multisig does not exists",
+			Info: EncodeError(code.NewMultisigNotExists(proposal.Multisig.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if multisigData := multisig.Multisig(); multisigData.GetWeight(sender) == 0 {
+		return &Response{
+			Code: code.IsNotSignerOfMultisig,
+			Log:  "Sender is not a signer of the multisig",
+			Info: EncodeError(code.NewIsNotSignerOfMultisig(proposal.Multisig.String(), sender.String())),
+		}
+	}
+
+	if proposal.HasVoted(sender) {
+		return &Response{
+			Code: code.ProposalAlreadyVoted,
+			Log:  fmt.Sprintf("Sender already voted for proposal %d", id),
+			Info: EncodeError(code.NewProposalAlreadyVoted(id, sender.String())),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const maxBatchTxs = 16
+
+type BatchTx struct {
+	Type TxType
+	Data RawData
+}
+
+// BatchData contains transactions executed one by one on behalf of the sender of batch,
+// either all of them succeed or the state is not changed
+type BatchData struct {
+	Txs []BatchTx
+
+	decodedTxs []Data
+}
+
+func (data BatchData) TxType() TxType {
+	return TypeBatch
+}
+
+func (data BatchData) Gas() int64 {
+	var gas int64
+	for _, d := range data.decodedTxs {
+		gas += d.Gas()
+	}
+	return gas
+}
+
+// DecodedTxs returns data of the batch transactions
+func (data BatchData) DecodedTxs() []Data {
+	return data.decodedTxs
+}
+
+func (data *BatchData) decode(decodeTxFunc func(txType TxType) (Data, bool)) error {
+	if len(data.Txs) == 0 || len(data.Txs) > maxBatchTxs {
+		return fmt.Errorf("batch should contain from 1 to %d txs", maxBatchTxs)
+	}
+
+	data.decodedTxs = make([]Data, 0, len(data.Txs))
+	for i, tx := range data.Txs {
+		if !canBeNested(tx.Type) {
+			return fmt.Errorf("tx type %x is not allowed in batch", tx.Type)
+		}
+
+		if tx.Data == nil {
+			return errors.New("incorrect tx data")
+		}
+
+		d, ok := decodeTxFunc(tx.Type)
+		if !ok {
+			return fmt.Errorf("tx type %x is not registered", tx.Type)
+		}
+
+		if err := rlp.DecodeBytes(tx.Data, d); err != nil {
+			return fmt.Errorf("tx %d of batch: %s", i, err)
+		}
+
+		data.decodedTxs = append(data.decodedTxs, d)
+	}
+
+	return nil
+}
+
+func (data BatchData) String() string {
+	return fmt.Sprintf("BATCH txs:%d", len(data.Txs))
+}
+
+func (data BatchData) CommissionData(price *commission.Price) *big.Int {
+	total := big.NewInt(0)
+	for _, d := range data.decodedTxs {
+		total.Add(total, d.CommissionData(price))
+	}
+	return total
+}
+
+// Run runs the txs of the batch one by one on a snapshot of the state, either all of them succeed or the snapshot is reverted.
+// On the check state all of them are tried on the branch of the state, which is discarded, as the next ones could depend on the changes made by the previous ones
+func (data BatchData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, _ *big.Int) Response {
+	if checkState, isCheck := context.(*state.CheckState); isCheck {
+		branch, discard := checkState.Branch()
+		defer discard()
+
+		if response := data.run(tx, branch, big.NewInt(0), currentBlock); response.Code != code.OK {
+			return response
+		}
+		return Response{Code: code.OK}
+	}
+
+	deliverState := context.(*state.State)
+	revert, keep := deliverState.Snapshot()
+	reward := big.NewInt(0).Set(rewardPool)
+
+	response := data.run(tx, deliverState, rewardPool, currentBlock)
+	if response.Code != code.OK {
+		// the commission of the failed batch is charged by executor
+		revert()
+		rewardPool.Set(reward)
+		return response
+	}
+
+	keep()
+	return response
+}
+
+// run runs the txs of the batch one by one and stops on the first failed one
+func (data BatchData) run(tx *Transaction, deliverState *state.State, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	checkState := state.NewCheckState(deliverState)
+	commissions := checkState.Commission().GetCommissions()
+
+	commissionInBaseCoin := big.NewInt(0)
+	commissionAmount := big.NewInt(0)
+
+	var tags []abcTypes.EventAttribute
+	for i, d := range data.decodedTxs {
+		innerTx := &Transaction{
+			Nonce:         tx.Nonce,
+			ChainID:       tx.ChainID,
+			GasPrice:      tx.GasPrice,
+			GasCoin:       tx.GasCoin,
+			Type:          data.Txs[i].Type,
+			Data:          data.Txs[i].Data,
+			SignatureType: tx.SignatureType,
+			SignatureData: tx.SignatureData,
+			decodedData:   d,
+			sig:           tx.sig,
+			multisig:      tx.multisig,
+			sender:        &sender,
+		}
+		// payload is paid once, with the first tx
+		if i == 0 {
+			innerTx.Payload = tx.Payload
+			innerTx.ServiceData = tx.ServiceData
+		}
+
+		response := runInnerTx(innerTx, deliverState, rewardPool, currentBlock, commissions)
+		if response.Code != code.OK {
+			return batchTxFailed(i, innerTx, response)
+		}
+
+		// tags of batch txs are namespaced by index: tx.batch.<index>.<key>
+		prefix := "tx.batch." + strconv.Itoa(i) + "."
+		for _, tag := range response.Tags {
+			key := string(tag.Key)
+			switch key {
+			case "tx.commission_in_base_coin":
+				commissionInBaseCoin.Add(commissionInBaseCoin, helpers.StringToBigInt(string(tag.Value)))
+			case "tx.commission_amount":
+				commissionAmount.Add(commissionAmount, helpers.StringToBigInt(string(tag.Value)))
+			}
+			tag.Key = []byte(prefix + strings.TrimPrefix(key, "tx."))
+			tags = append(tags, tag)
+		}
+		tags = append(tags, abcTypes.EventAttribute{Key: []byte(prefix + "type"), Value: []byte(hex.EncodeToString([]byte{byte(innerTx.Type)})), Index: true})
+	}
+
+	tags = append(tags,
+		abcTypes.EventAttribute{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+		abcTypes.EventAttribute{Key: []byte("tx.commission_amount"), Value: []byte(commissionAmount.String())},
+	)
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// canBeNested reports whether tx of the type can be run as a part of another tx
+func canBeNested(txType TxType) bool {
+	switch txType {
+	case TypeRedeemCheck, TypeRedeemReusableCheck, TypeBatch, TypeCreateProposal, TypeApproveProposal, TypeRejectProposal:
+		return false
+	}
+	return true
+}
+
+// runInnerTx runs the tx, which is a part of another tx. As any tx, it changes the state only if it succeeds
+func runInnerTx(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, commissions *commission.Price) Response {
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	price, errResp := innerTxPrice(tx, checkState, commissions)
+	if errResp != nil {
+		return *errResp
+	}
+
+	response := tx.decodedData.Run(tx, context, rewardPool, currentBlock, price)
+	if response.Code != code.OK {
+		return response
+	}
+
+	if deliverState, ok := context.(*state.State); ok && (tx.Type == TypeCreateCoin || tx.Type == TypeCreateToken) {
+		tag, errResp := burnForSymbol(tx, checkState, deliverState, rewardPool, commissions)
+		if errResp != nil {
+			return *errResp
+		}
+		response.Tags = append(response.Tags, tag)
+	}
+
+	return response
+}
+
+// innerTxPrice returns commission in base coin of the inner tx, as it is calculated by executor
+func innerTxPrice(tx *Transaction, checkState *state.CheckState, commissions *commission.Price) (*big.Int, *Response) {
+	price := tx.MulGasPrice(tx.Price(commissions))
+	if price.Sign() == 0 {
+		return price, nil
+	}
+
+	if !commissions.Coin.IsBaseCoin() {
+		var resp *Response
+		resp, price, _ = CheckSwap(checkState.Swap().GetSwapper(commissions.Coin, types.GetBaseCoinID()), checkState.Coins().GetCoin(commissions.Coin), checkState.Coins().GetCoin(0), price, big.NewInt(0), false)
+		if resp != nil {
+			return nil, resp
+		}
+	}
+	if price == nil || price.Sign() != 1 {
+		return nil, &Response{
+			Code: code.CommissionCoinNotSufficient,
+			Log:  fmt.Sprint("Not possible to pay commission"),
+			Info: EncodeError(code.NewCommissionCoinNotSufficient("", "")),
+		}
+	}
+
+	return price, nil
+}
+
+func batchTxFailed(index int, tx *Transaction, response Response) Response {
+	return Response{
+		Code: code.BatchTxFailed,
+		Log:  fmt.Sprintf("Tx %d of batch failed: %s", index, response.Log),
+		Info: EncodeError(code.NewBatchTxFailed(index, tx.Type.String(), response.Code, response.Info)),
+	}
+}
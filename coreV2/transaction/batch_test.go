@@ -0,0 +1,142 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func makeBatchData(t *testing.T, txs ...Data) BatchData {
+	data := BatchData{}
+	for _, d := range txs {
+		encodedData, err := rlp.EncodeToBytes(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data.Txs = append(data.Txs, BatchTx{Type: d.TxType(), Data: encodedData})
+	}
+	return data
+}
+
+func TestBatchTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	to1 := types.Address{1}
+	to2 := types.Address{2}
+	value := helpers.BipToPip(big.NewInt(10))
+
+	data := makeBatchData(t,
+		SendData{Coin: coin, To: to1, Value: value},
+		SendData{Coin: coin, To: to2, Value: value},
+	)
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeBatch, data), big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	for _, to := range []types.Address{to1, to2} {
+		if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(value) != 0 {
+			t.Fatalf("Target %s balance is not correct. Expected %s, got %s", to.String(), value, balance)
+		}
+	}
+
+	commission := big.NewInt(0).Mul(commissionPrice.Send, big.NewInt(2))
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000-20)), commission)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	tags := map[string]string{}
+	for _, tag := range response.Tags {
+		tags[string(tag.Key)] = string(tag.Value)
+	}
+	if tags["tx.batch.1.to"] != to2.String()[2:] {
+		t.Fatalf("Tag of second tx is not correct: %s", tags["tx.batch.1.to"])
+	}
+	if tags["tx.commission_in_base_coin"] != commission.String() {
+		t.Fatalf("Commission tag is not correct: %s", tags["tx.commission_in_base_coin"])
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxRollback(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	to := types.Address{1}
+	data := makeBatchData(t,
+		SendData{Coin: coin, To: to, Value: helpers.BipToPip(big.NewInt(10))},
+		SendData{Coin: coin, To: to, Value: helpers.BipToPip(big.NewInt(1000))},
+	)
+
+	// all txs are checked on the check state, the changes of the checked ones are discarded
+	response := NewExecutorV3(GetData).RunTx(state.NewCheckState(cState), encodeTestTx(t, privateKey, 1, TypeBatch, data), big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.BatchTxFailed {
+		t.Fatalf("Response code is not %d. Error: %s", code.BatchTxFailed, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Sign() != 0 {
+		t.Fatalf("First tx of batch is not discarded on the check state, target balance %s", balance)
+	}
+
+	rewardPool := big.NewInt(0)
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeBatch, data), rewardPool, 0, &sync.Map{}, 0, false)
+	if response.Code != code.BatchTxFailed {
+		t.Fatalf("Response code is not %d. Error: %s", code.BatchTxFailed, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Sign() != 0 {
+		t.Fatalf("First tx of batch is not rolled back, target balance %s", balance)
+	}
+
+	failedTx := cState.Commission.GetCommissions().FailedTx
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), failedTx)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if rewardPool.Cmp(failedTx) != 0 {
+		t.Fatalf("Reward pool is not correct. Expected %s, got %s", failedTx, rewardPool)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxNested(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	data := makeBatchData(t, makeBatchData(t, SendData{Coin: types.GetBaseCoinID(), To: types.Address{1}, Value: big.NewInt(1)}))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeBatch, data), big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error: %s", code.DecodeError, response.Log)
+	}
+}
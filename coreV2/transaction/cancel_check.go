@@ -0,0 +1,188 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CancelCheckData forbids redeeming of the check and returns its escrow to the issuer.
+// The issuer can cancel the check at any time, anyone else only the escrowed check after its due block
+type CancelCheckData struct {
+	RawCheck []byte
+}
+
+func (data CancelCheckData) TxType() TxType {
+	return TypeCancelCheck
+}
+
+func (data CancelCheckData) Gas() int64 {
+	return gasCancelCheck
+}
+
+func (data CancelCheckData) basicCheck(tx *Transaction, context *state.CheckState, decodedCheck *check.Check, block uint64) *Response {
+	hash := decodedCheck.Hash()
+	checkSender, err := decodedCheck.Sender()
+	if err != nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if context.Checks().IsCheckCancelled(hash) {
+		return &Response{
+			Code: code.CheckCancelled,
+			Log:  "Check is already cancelled",
+			Info: EncodeError(code.NewCheckCancelled(hash.String())),
+		}
+	}
+
+	if context.Checks().IsCheckUsed(decodedCheck) {
+		return &Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if checkSender == sender {
+		return nil
+	}
+
+	escrow := context.Checks().GetEscrow(hash)
+	if escrow == nil {
+		return &Response{
+			Code: code.IsNotIssuerOfCheck,
+			Log:  "Sender is not an issuer of the check",
+			Info: EncodeError(code.NewIsNotIssuerOfCheck(hash.String(), sender.String())),
+		}
+	}
+
+	if !escrow.IsExpired(block) {
+		return &Response{
+			Code: code.CheckEscrowNotExpired,
+			Log:  fmt.Sprintf("Escrow of the check can be returned from height %d", escrow.DueBlock+1),
+			Info: EncodeError(code.NewCheckEscrowNotExpired(hash.String(), strconv.FormatUint(escrow.DueBlock, 10))),
+		}
+	}
+
+	return nil
+}
+
+func (data CancelCheckData) String() string {
+	return fmt.Sprintf("CANCEL CHECK check: %x", data.RawCheck)
+}
+
+func (data CancelCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.CancelCheckPrice()
+}
+
+func (data CancelCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	response := data.basicCheck(tx, checkState, decodedCheck, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	hash := decodedCheck.Hash()
+
+	// returned escrow can be used by the issuer to pay the commission
+	balance := checkState.Accounts().GetBalance(sender, tx.GasCoin)
+	if escrow := checkState.Checks().GetEscrow(hash); escrow != nil && escrow.Issuer == sender && escrow.Coin == tx.GasCoin {
+		balance.Add(balance, escrow.Value)
+	}
+	if balance.Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		returned := big.NewInt(0)
+		if escrow := deliverState.Checks.GetEscrow(hash); escrow != nil {
+			deliverState.Checks.DeleteEscrow(hash)
+			deliverState.Accounts.AddBalance(escrow.Issuer, escrow.Coin, escrow.Value)
+			returned = escrow.Value
+		}
+		deliverState.Checks.CancelCheckHash(hash)
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(hash[:])), Index: true},
+			{Key: []byte("tx.return"), Value: []byte(returned.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,179 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CancelUnbondData returns the pending unbond of the sender unfrozen at Height back to the candidate.
+// The stake goes to the waitlist if the candidate's stake list is full
+type CancelUnbondData struct {
+	Height uint64
+	PubKey types.Pubkey
+	Coin   types.CoinID
+	Value  *big.Int
+}
+
+func (data CancelUnbondData) Gas() int64 {
+	return gasCancelUnbond
+}
+
+func (data CancelUnbondData) TxType() TxType {
+	return TypeCancelUnbond
+}
+
+func (data CancelUnbondData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if !context.Candidates().Exists(data.PubKey) {
+		return &Response{
+			Code: code.CandidateNotFound,
+			Log:  "Candidate with such public key not found",
+			Info: EncodeError(code.NewCandidateNotFound(data.PubKey.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if funds := context.FrozenFunds().GetFrozenFunds(data.Height); funds == nil || funds.FindUnbond(sender, data.PubKey, data.Coin, data.Value) == -1 {
+		return &Response{
+			Code: code.UnbondNotFound,
+			Log:  "Unbond of current user not found",
+			Info: EncodeError(code.NewUnbondNotFound(strconv.FormatUint(data.Height, 10), sender.String(), data.PubKey.String(), data.Coin.String(), data.Value.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CancelUnbondData) String() string {
+	return fmt.Sprintf("CANCEL UNBOND pubkey: %x, height: %d", data.PubKey, data.Height)
+}
+
+func (data CancelUnbondData) CommissionData(price *commission.Price) *big.Int {
+	return price.CancelUnbondPrice()
+}
+
+func (data CancelUnbondData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	value := big.NewInt(0).Set(data.Value)
+	if waitList := checkState.WaitList().Get(sender, data.PubKey, data.Coin); waitList != nil {
+		value.Add(value, waitList.Value)
+	}
+
+	toWaitList, tooBig := checkState.Candidates().IsDelegatorStakeAllowed(sender, data.PubKey, data.Coin, value)
+	if tooBig {
+		return Response{
+			Code: code.TooBigStake,
+			Log:  "Cannot be delegated to a candidate, and his total stake exceeds 20% of the network",
+			Info: EncodeError(code.NewTooBigStake(sender.String(), data.PubKey.String(), value.String(), data.Coin.String(), checkState.Coins().GetCoin(data.Coin).GetFullSymbol())),
+		}
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission, gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		unbond := deliverState.FrozenFunds.CancelUnbond(data.Height, sender, data.PubKey, data.Coin, data.Value)
+		if toWaitList {
+			deliverState.Waitlist.AddWaitList(sender, data.PubKey, data.Coin, unbond)
+		} else {
+			if waitList := deliverState.Waitlist.Get(sender, data.PubKey, data.Coin); waitList != nil {
+				unbond = big.NewInt(0).Add(unbond, waitList.Value)
+				deliverState.Waitlist.Delete(sender, data.PubKey, data.Coin)
+			}
+			deliverState.Candidates.Delegate(sender, data.PubKey, data.Coin, unbond, big.NewInt(0))
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(data.PubKey[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.to_waitlist"), Value: []byte(strconv.FormatBool(toWaitList))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
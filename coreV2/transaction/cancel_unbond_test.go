@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestCancelUnbondTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	pubkey := createTestCandidate(cState)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	cState.Candidates.Delegate(addr, pubkey, coin, value, big.NewInt(0))
+	cState.Candidates.RecalculateStakes(109000)
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeUnbond, UnbondDataV3{
+		PubKey: pubkey,
+		Coin:   coin,
+		Value:  value,
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	unbondHeight := 1 + types.GetUnbondPeriod()
+	if stake := cState.Candidates.GetStakeValueOfAddress(pubkey, addr, coin); stake != nil && stake.Sign() != 0 {
+		t.Fatalf("Stake %s is not unbonded", stake)
+	}
+
+	data := CancelUnbondData{
+		Height: unbondHeight,
+		PubKey: pubkey,
+		Coin:   coin,
+		Value:  helpers.BipToPip(big.NewInt(50)),
+	}
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeCancelUnbond, data), big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.UnbondNotFound {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.UnbondNotFound, response.Log)
+	}
+
+	data.Value = value
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeCancelUnbond, data), big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if funds := cState.FrozenFunds.GetFrozenFunds(unbondHeight); funds != nil && len(funds.List) != 0 {
+		t.Fatalf("Unbond is not cancelled: %v", funds.List)
+	}
+
+	cState.Candidates.RecalculateStakes(109000)
+	if stake := cState.Candidates.GetStakeValueOfAddress(pubkey, addr, coin); stake == nil || stake.Cmp(value) != 0 {
+		t.Fatalf("Stake %s is not returned to the candidate", stake)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,139 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type ClaimVestingData struct {
+	ID uint32
+}
+
+func (data ClaimVestingData) TxType() TxType {
+	return TypeClaimVesting
+}
+
+func (data ClaimVestingData) Gas() int64 {
+	return gasClaimVesting
+}
+
+func (data ClaimVestingData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	sender, _ := tx.Sender()
+	item := context.Vesting().Get(sender, data.ID)
+	if item == nil {
+		return &Response{
+			Code: code.VestingNotExists,
+			Log:  fmt.Sprintf("Vesting %d of %s not exists", data.ID, sender.String()),
+			Info: EncodeError(code.NewVestingNotExists(sender.String(), data.ID)),
+		}
+	}
+
+	if item.Claimable(block).Sign() != 1 {
+		return &Response{
+			Code: code.NothingToClaimOfVesting,
+			Log:  fmt.Sprintf("Vesting %d has nothing to claim", data.ID),
+			Info: EncodeError(code.NewNothingToClaimOfVesting(data.ID, strconv.FormatUint(item.CliffHeight, 10))),
+		}
+	}
+
+	return nil
+}
+
+func (data ClaimVestingData) String() string {
+	return fmt.Sprintf("CLAIM VESTING id:%d", data.ID)
+}
+
+func (data ClaimVestingData) CommissionData(price *commission.Price) *big.Int {
+	return price.ClaimVestingPrice()
+}
+
+func (data ClaimVestingData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	// claimed value can be used to pay the commission
+	balance := checkState.Accounts().GetBalance(sender, tx.GasCoin)
+	if item := checkState.Vesting().Get(sender, data.ID); item.Coin == tx.GasCoin {
+		balance = big.NewInt(0).Add(balance, item.Claimable(currentBlock))
+	}
+	if balance.Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		coin, value := deliverState.Vesting.Claim(sender, data.ID, currentBlock)
+		deliverState.Accounts.AddBalance(sender, coin, value)
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(coin.String()), Index: true},
+			{Key: []byte("tx.vesting_id"), Value: []byte(strconv.Itoa(int(data.ID))), Index: true},
+			{Key: []byte("tx.return"), Value: []byte(value.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
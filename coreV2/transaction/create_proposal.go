@@ -0,0 +1,262 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// maxProposalLifetime is the max number of blocks the proposal waits for approvals
+const maxProposalLifetime = 518400
+
+// CreateProposalData proposes the tx to be sent on behalf of the multisig once it is approved by its signers
+type CreateProposalData struct {
+	Multisig     types.Address
+	GasCoin      types.CoinID
+	Type         TxType
+	Data         RawData
+	ExpireHeight uint64
+
+	decodedData Data
+}
+
+func (data CreateProposalData) TxType() TxType {
+	return TypeCreateProposal
+}
+
+func (data CreateProposalData) Gas() int64 {
+	return gasCreateProposal
+}
+
+// DecodedData returns data of the proposed tx
+func (data CreateProposalData) DecodedData() Data {
+	return data.decodedData
+}
+
+func (data *CreateProposalData) decode(decodeTxFunc func(txType TxType) (Data, bool)) error {
+	d, err := decodeProposalTx(data.Type, data.Data, decodeTxFunc)
+	if err != nil {
+		return err
+	}
+
+	data.decodedData = d
+	return nil
+}
+
+func (data CreateProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	multisig := context.Accounts().GetAccount(data.Multisig)
+	if !multisig.IsMultisig() {
+		return &Response{
+			Code: code.MultisigNotExists,
+			Log:  "Multisig does not exists",
+			Info: EncodeError(code.NewMultisigNotExists(data.Multisig.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if multisigData := multisig.Multisig(); multisigData.GetWeight(sender) == 0 {
+		return &Response{
+			Code: code.IsNotSignerOfMultisig,
+			Log:  "Sender is not a signer of the multisig",
+			Info: EncodeError(code.NewIsNotSignerOfMultisig(data.Multisig.String(), sender.String())),
+		}
+	}
+
+	if data.ExpireHeight <= block || data.ExpireHeight > block+maxProposalLifetime {
+		return &Response{
+			Code: code.WrongProposalExpireHeight,
+			Log:  fmt.Sprintf("Expire height should be higher than the current one and not higher than %d", block+maxProposalLifetime),
+			Info: EncodeError(code.NewWrongProposalExpireHeight(strconv.FormatUint(data.ExpireHeight, 10), strconv.FormatUint(block, 10), strconv.FormatUint(block+maxProposalLifetime, 10))),
+		}
+	}
+
+	if !context.Coins().Exists(data.GasCoin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.GasCoin),
+			Info: EncodeError(code.NewCoinNotExists("", data.GasCoin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateProposalData) String() string {
+	return fmt.Sprintf("CREATE PROPOSAL multisig:%s type:%s expire:%d", data.Multisig.String(), data.Type.String(), data.ExpireHeight)
+}
+
+func (data CreateProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateProposalPrice()
+}
+
+func (data CreateProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	// the proposer approves the proposal, so the tx is sent at once if the weight of the proposer is enough
+	multisig := checkState.Accounts().GetAccount(data.Multisig).Multisig()
+	isExecuted := multisig.GetWeight(sender) >= multisig.Threshold
+	var proposalTags []abcTypes.EventAttribute
+	if isExecuted {
+		response := runProposalTx(tx, checkState.Proposals().NextID(), data.Multisig, data.GasCoin, data.Type, data.Data, data.decodedData, context, rewardPool, currentBlock)
+		if response.Code != code.OK {
+			return response
+		}
+		proposalTags = response.Tags
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		id := deliverState.Proposals.Create(data.Multisig, sender, data.GasCoin, byte(data.Type), data.Data, data.ExpireHeight)
+		if isExecuted {
+			deliverState.Proposals.Delete(id)
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.multisig"), Value: []byte(hex.EncodeToString(data.Multisig[:])), Index: true},
+			{Key: []byte("tx.proposal_id"), Value: []byte(strconv.Itoa(int(id))), Index: true},
+			{Key: []byte("tx.proposal_executed"), Value: []byte(strconv.FormatBool(isExecuted))},
+		}
+		tags = append(tags, proposalTags...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+func decodeProposalTx(txType TxType, txData RawData, decodeTxFunc func(txType TxType) (Data, bool)) (Data, error) {
+	if !canBeNested(txType) {
+		return nil, fmt.Errorf("tx type %x is not allowed in proposal", txType)
+	}
+
+	if txData == nil {
+		return nil, errors.New("incorrect tx data")
+	}
+
+	d, ok := decodeTxFunc(txType)
+	if !ok {
+		return nil, fmt.Errorf("tx type %x is not registered", txType)
+	}
+
+	if err := rlp.DecodeBytes(txData, d); err != nil {
+		return nil, fmt.Errorf("tx of proposal: %s", err)
+	}
+
+	return d, nil
+}
+
+// proposalWeight returns total weight of the addresses which are current signers of the multisig
+func proposalWeight(multisig *accounts.Multisig, addresses []types.Address) uint32 {
+	var weight uint32
+	for _, address := range addresses {
+		weight += multisig.GetWeight(address)
+	}
+	return weight
+}
+
+// runProposalTx sends the proposed tx on behalf of the multisig, tags of the tx are namespaced as tx.proposal.<key>
+func runProposalTx(tx *Transaction, id uint32, multisig types.Address, gasCoin types.CoinID, txType TxType, txData RawData, d Data, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	innerTx := &Transaction{
+		Nonce:         checkState.Accounts().GetNonce(multisig) + 1,
+		ChainID:       tx.ChainID,
+		GasPrice:      1,
+		GasCoin:       gasCoin,
+		Type:          txType,
+		Data:          txData,
+		SignatureType: SigTypeMulti,
+		decodedData:   d,
+		multisig:      &SignatureMulti{Multisig: multisig},
+		sender:        &multisig,
+	}
+
+	response := runInnerTx(innerTx, context, rewardPool, currentBlock, checkState.Commission().GetCommissions())
+	if response.Code != code.OK {
+		return Response{
+			Code: code.ProposalTxFailed,
+			Log:  fmt.Sprintf("Tx of proposal %d failed: %s", id, response.Log),
+			Info: EncodeError(code.NewProposalTxFailed(id, txType.String(), response.Code, response.Info)),
+		}
+	}
+
+	for i, tag := range response.Tags {
+		response.Tags[i].Key = []byte("tx.proposal." + strings.TrimPrefix(string(tag.Key), "tx."))
+	}
+
+	return response
+}
@@ -79,7 +79,60 @@ func GetDataV1(txType TxType) (Data, bool) {
 }
 
 func GetData(txType TxType) (Data, bool) {
-	return GetDataV3(txType)
+	return GetDataV340(txType)
+}
+
+func GetDataV340(txType TxType) (Data, bool) {
+	switch txType {
+	case TypeCreateVesting:
+		return &CreateVestingData{}, true
+	case TypeClaimVesting:
+		return &ClaimVestingData{}, true
+	case TypeRevokeVesting:
+		return &RevokeVestingData{}, true
+	case TypeBatch:
+		return &BatchData{}, true
+	case TypeCreateProposal:
+		return &CreateProposalData{}, true
+	case TypeApproveProposal:
+		return &ApproveProposalData{}, true
+	case TypeRejectProposal:
+		return &RejectProposalData{}, true
+	case TypeCreateHTLC:
+		return &CreateHTLCData{}, true
+	case TypeClaimHTLC:
+		return &ClaimHTLCData{}, true
+	case TypeRefundHTLC:
+		return &RefundHTLCData{}, true
+	case TypeRedeemReusableCheck:
+		return &RedeemReusableCheckData{}, true
+	case TypeRevokeReusableCheck:
+		return &RevokeReusableCheckData{}, true
+	case TypeEscrowCheck:
+		return &EscrowCheckData{}, true
+	case TypeCancelCheck:
+		return &CancelCheckData{}, true
+	case TypeAddLimitOrderV2:
+		return &AddLimitOrderV2Data{}, true
+	case TypeAddTriggerOrder:
+		return &AddTriggerOrderData{}, true
+	case TypeCancelTriggerOrder:
+		return &CancelTriggerOrderData{}, true
+	case TypeSetAutoCompound:
+		return &SetAutoCompoundData{}, true
+	case TypeCancelUnbond:
+		return &CancelUnbondData{}, true
+	case TypeVoteParam:
+		return &VoteParamData{}, true
+	case TypeVoteRewardPool:
+		return &VoteRewardPoolData{}, true
+	case TypeSetAutoRoute:
+		return &SetAutoRouteData{}, true
+	case TypeEditCandidateCommission:
+		return &EditCandidateCommissionV340{}, true
+	default:
+		return GetDataV3(txType)
+	}
 }
 
 func GetDataV260(txType TxType) (Data, bool) {
@@ -182,18 +235,33 @@ func (e *Executor) DecodeFromBytes(buf []byte) (*Transaction, error) {
 }
 
 func DecodeSig(tx *Transaction) (*Transaction, error) {
+	signatureData := tx.SignatureData
+	if tx.IsSponsored() {
+		sponsored := &SignatureSponsored{}
+		if err := rlp.DecodeBytes(tx.SignatureData, sponsored); err != nil {
+			return nil, err
+		}
+		if sponsored.Sponsor == nil {
+			return nil, errors.New("sponsor signature is missing")
+		}
+
+		tx.sponsorSig = sponsored.Sponsor
+		tx.validUntil = sponsored.ValidUntil
+		signatureData = sponsored.Data
+	}
+
 	switch tx.SignatureType {
-	case SigTypeMulti:
+	case SigTypeMulti, SigTypeMultiSponsored:
 		{
 			tx.multisig = &SignatureMulti{}
-			if err := rlp.DecodeBytes(tx.SignatureData, tx.multisig); err != nil {
+			if err := rlp.DecodeBytes(signatureData, tx.multisig); err != nil {
 				return nil, err
 			}
 		}
-	case SigTypeSingle:
+	case SigTypeSingle, SigTypeSingleSponsored:
 		{
 			tx.sig = &Signature{}
-			if err := rlp.DecodeBytes(tx.SignatureData, tx.sig); err != nil {
+			if err := rlp.DecodeBytes(signatureData, tx.sig); err != nil {
 				return nil, err
 			}
 		}
@@ -228,6 +296,12 @@ func (e *Executor) DecodeFromBytesWithoutSig(buf []byte) (*Transaction, error) {
 		return nil, err
 	}
 
+	if nested, ok := d.(nestedDataDecoder); ok {
+		if err := nested.decode(e.decodeTxFunc); err != nil {
+			return nil, err
+		}
+	}
+
 	tx.SetDecodedData(d)
 
 	return &tx, nil
@@ -0,0 +1,294 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checks"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// EscrowCheckData locks the value of the check issued by the sender, so the check is redeemed from the escrow
+type EscrowCheckData struct {
+	RawCheck []byte
+}
+
+func (data EscrowCheckData) TxType() TxType {
+	return TypeEscrowCheck
+}
+
+func (data EscrowCheckData) Gas() int64 {
+	return gasEscrowCheck
+}
+
+func (data EscrowCheckData) basicCheck(tx *Transaction, context *state.CheckState, decodedCheck *check.Check, block uint64) *Response {
+	hash := decodedCheck.Hash()
+	if decodedCheck.ChainID != types.CurrentChainID {
+		return &Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", decodedCheck.ChainID))),
+		}
+	}
+
+	checkSender, err := decodedCheck.Sender()
+	if err != nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if checkSender != sender {
+		return &Response{
+			Code: code.IsNotIssuerOfCheck,
+			Log:  "Sender is not an issuer of the check",
+			Info: EncodeError(code.NewIsNotIssuerOfCheck(hash.String(), sender.String())),
+		}
+	}
+
+	if !context.Coins().Exists(decodedCheck.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", decodedCheck.Coin.String())),
+		}
+	}
+
+	if decodedCheck.DueBlock < block {
+		return &Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", decodedCheck.DueBlock), fmt.Sprintf("%d", block))),
+		}
+	}
+
+	if context.Checks().IsCheckCancelled(hash) {
+		return &Response{
+			Code: code.CheckCancelled,
+			Log:  "Check is cancelled",
+			Info: EncodeError(code.NewCheckCancelled(hash.String())),
+		}
+	}
+
+	if context.Checks().IsCheckUsed(decodedCheck) {
+		return &Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	if context.Checks().GetEscrow(hash) != nil {
+		return &Response{
+			Code: code.CheckEscrowed,
+			Log:  "Check is already escrowed",
+			Info: EncodeError(code.NewCheckEscrowed(hash.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data EscrowCheckData) String() string {
+	return fmt.Sprintf("ESCROW CHECK check: %x", data.RawCheck)
+}
+
+func (data EscrowCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.EscrowCheckPrice()
+}
+
+func (data EscrowCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	response := data.basicCheck(tx, checkState, decodedCheck, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	coin := checkState.Coins().GetCoin(decodedCheck.Coin)
+	if checkState.Accounts().GetBalance(sender, decodedCheck.Coin).Cmp(decodedCheck.Value) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), decodedCheck.Value.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), decodedCheck.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if decodedCheck.Coin == tx.GasCoin {
+		totalTxCost := big.NewInt(0).Add(decodedCheck.Value, commission)
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(totalTxCost) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), totalTxCost.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		hash := decodedCheck.Hash()
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SubBalance(sender, decodedCheck.Coin, decodedCheck.Value)
+		deliverState.Checks.CreateEscrow(hash, sender, decodedCheck.Coin, decodedCheck.Value, decodedCheck.DueBlock)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(decodedCheck.Coin.String()), Index: true},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(hash[:])), Index: true},
+			{Key: []byte("tx.due_block"), Value: []byte(strconv.FormatUint(decodedCheck.DueBlock, 10))},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// redeemEscrowedCheck sends the escrowed value of the check to the sender, who pays the commission and can use the value for it
+func redeemEscrowedCheck(tx *Transaction, context state.Interface, checkState *state.CheckState, decodedCheck *check.Check, escrow *checks.Escrow, rewardPool *big.Int, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	hash := decodedCheck.Hash()
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	balance := checkState.Accounts().GetBalance(sender, tx.GasCoin)
+	if escrow.Coin == tx.GasCoin {
+		balance.Add(balance, escrow.Value)
+	}
+	if balance.Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		deliverState.Checks.UseCheck(decodedCheck)
+		deliverState.Checks.DeleteEscrow(hash)
+		deliverState.Accounts.AddBalance(sender, escrow.Coin, escrow.Value)
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(sender[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(escrow.Coin.String()), Index: true},
+			{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(escrow.Issuer[:])), Index: true},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(hash[:])), Index: true},
+			{Key: []byte("tx.escrow"), Value: []byte{49}}, // "1"
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func createTestCheck(t *testing.T, issuerKey, passphraseKey *ecdsa.PrivateKey, nonce []byte, value *big.Int, dueBlock uint64) []byte {
+	check := c.Check{
+		Nonce:    nonce,
+		ChainID:  types.CurrentChainID,
+		DueBlock: dueBlock,
+		Coin:     types.GetBaseCoinID(),
+		Value:    value,
+		GasCoin:  types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphraseKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuerKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rawCheck
+}
+
+func TestEscrowCheckTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphraseKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := helpers.BipToPip(big.NewInt(100))
+	rawCheck := createTestCheck(t, issuerKey, passphraseKey, []byte{1}, value, 10)
+	decodedCheck, _ := c.DecodeFromBytes(rawCheck)
+
+	redeemerKey, _ := crypto.GenerateKey()
+	redeemer := crypto.PubkeyToAddress(redeemerKey.PublicKey)
+	proof := createTestCheckProof(t, passphraseKey, redeemer)
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, redeemerKey, 1, TypeEscrowCheck, EscrowCheckData{RawCheck: rawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotIssuerOfCheck {
+		t.Fatalf("Response code is not %d. Error: %s", code.IsNotIssuerOfCheck, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, issuerKey, 1, TypeEscrowCheck, EscrowCheckData{RawCheck: rawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if escrow := cState.Checks.GetEscrow(decodedCheck.Hash()); escrow == nil || escrow.Value.Cmp(value) != 0 {
+		t.Fatalf("Escrow of the check is not correct: %v", escrow)
+	}
+
+	// issuer spends the rest of the balance, the check is still redeemable from the escrow
+	cState.Accounts.SubBalance(issuer, coin, cState.Accounts.GetBalance(issuer, coin))
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, redeemerKey, 1, TypeRedeemCheck, RedeemCheckData{RawCheck: rawCheck, Proof: proof}), big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(value, cState.Commission.GetCommissions().RedeemCheck)
+	if balance := cState.Accounts.GetBalance(redeemer, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Redeemer balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if cState.Checks.GetEscrow(decodedCheck.Hash()) != nil {
+		t.Fatal("Escrow of the redeemed check is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCancelCheckTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphraseKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := helpers.BipToPip(big.NewInt(100))
+	rawCheck := createTestCheck(t, issuerKey, passphraseKey, []byte{1}, value, 10)
+	decodedCheck, _ := c.DecodeFromBytes(rawCheck)
+
+	anotherKey, _ := crypto.GenerateKey()
+	another := crypto.PubkeyToAddress(anotherKey.PublicKey)
+	cState.Accounts.AddBalance(another, coin, helpers.BipToPip(big.NewInt(10)))
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, issuerKey, 1, TypeEscrowCheck, EscrowCheckData{RawCheck: rawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, anotherKey, 1, TypeCancelCheck, CancelCheckData{RawCheck: rawCheck}), big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.CheckEscrowNotExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.CheckEscrowNotExpired, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, anotherKey, 1, TypeCancelCheck, CancelCheckData{RawCheck: rawCheck}), big.NewInt(0), 11, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000)), cState.Commission.GetCommissions().EscrowCheckPrice())
+	if balance := cState.Accounts.GetBalance(issuer, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Issuer balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if !cState.Checks.IsCheckCancelled(decodedCheck.Hash()) {
+		t.Fatal("Check is not cancelled")
+	}
+
+	// not escrowed check is cancelled by the issuer before redeeming
+	rawCheck = createTestCheck(t, issuerKey, passphraseKey, []byte{2}, value, 10)
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, issuerKey, 2, TypeCancelCheck, CancelCheckData{RawCheck: rawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	proof := createTestCheckProof(t, passphraseKey, another)
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, anotherKey, 2, TypeRedeemCheck, RedeemCheckData{RawCheck: rawCheck, Proof: proof}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CheckCancelled {
+		t.Fatalf("Response code is not %d. Error: %s", code.CheckCancelled, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
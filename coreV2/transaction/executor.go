@@ -124,7 +124,7 @@ func (e *Executor) RunTx(context state.Interface, rawTx []byte, rewardPool *big.
 	}
 
 	// check multi-signature
-	if tx.SignatureType == SigTypeMulti {
+	if tx.isMultisig() {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
 
 		if !multisig.IsMultisig() {
@@ -112,7 +112,7 @@ func (e *ExecutorV250) RunTx(context state.Interface, rawTx []byte, rewardPool *
 	}
 
 	// check multi-signature
-	if tx.SignatureType == SigTypeMulti {
+	if tx.isMultisig() {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
 
 		if !multisig.IsMultisig() {
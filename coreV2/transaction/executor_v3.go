@@ -7,24 +7,30 @@ import (
 	"strconv"
 	"sync"
 
-	"github.com/MinterTeam/minter-go-node/coreV2/check"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
 	abcTypes "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/code"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 )
 
 type ExecutorV3 struct {
 	*Executor
 	decodeTxFunc func(txType TxType) (Data, bool)
+	sponsorship  bool
 }
 
 func NewExecutorV3(decodeTxFunc func(txType TxType) (Data, bool)) ExecutorTx {
 	return &ExecutorV3{decodeTxFunc: decodeTxFunc, Executor: &Executor{decodeTxFunc: decodeTxFunc}}
 }
 
+// NewExecutorV340 returns the executor which also accepts sponsored txs
+func NewExecutorV340(decodeTxFunc func(txType TxType) (Data, bool)) ExecutorTx {
+	return &ExecutorV3{decodeTxFunc: decodeTxFunc, Executor: &Executor{decodeTxFunc: decodeTxFunc}, sponsorship: true}
+}
+
 func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *big.Int, currentBlock uint64, currentMempool *sync.Map, minGasPrice uint32, notSaveTags bool) Response {
 	lenRawTx := len(rawTx)
 	if lenRawTx > maxTxLength {
@@ -44,6 +50,14 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
+	if tx.IsSponsored() && !e.sponsorship {
+		return Response{
+			Code: code.DecodeError,
+			Log:  "unknown signature type",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
 	if tx.Type == TypeLockStake && currentBlock <= 10197360 {
 		return Response{
 			Code: code.Unavailable,
@@ -109,8 +123,34 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
+	sponsor, err := tx.CommissionPayer()
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+	isSponsored := sponsor != sender
+
+	if _, ok := tx.decodedData.(checkIssuer); ok && isSponsored {
+		return Response{
+			Code: code.TxCanNotBeSponsored,
+			Log:  fmt.Sprintf("Tx of type %s can not be sponsored", tx.Type.String()),
+			Info: EncodeError(code.NewTxCanNotBeSponsored(tx.Type.String())),
+		}
+	}
+
+	if isSponsored && tx.SponsorValidUntil() < currentBlock {
+		return Response{
+			Code: code.SponsorshipExpired,
+			Log:  fmt.Sprintf("Sponsor signature is valid until block %d, current block %d", tx.SponsorValidUntil(), currentBlock),
+			Info: EncodeError(code.NewSponsorshipExpired(strconv.Itoa(int(tx.SponsorValidUntil())), strconv.Itoa(int(currentBlock)))),
+		}
+	}
+
 	// check multi-signature
-	if tx.SignatureType == SigTypeMulti {
+	if tx.isMultisig() {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
 
 		if !multisig.IsMultisig() {
@@ -197,9 +237,32 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
-	response := tx.decodedData.Run(tx, context, rewardPool, currentBlock, price)
+	runContext := context
+	var sponsoredCommission *big.Int
+	if isSponsored {
+		var resp *Response
+		runContext, sponsoredCommission, resp = creditSponsoredCommission(tx, context, checkState, sender, sponsor, price)
+		if resp != nil {
+			return *resp
+		}
+	}
+
+	response := tx.decodedData.Run(tx, runContext, rewardPool, currentBlock, price)
+	if deliverState, ok := context.(*state.State); ok && isSponsored {
+		sponsoredCommission = settleSponsoredCommission(deliverState, tx.CommissionCoin(), sender, sponsor, sponsoredCommission, response.Code == code.OK)
+	}
+
 	if response.Code == code.OK && isCheck {
-		// check if mempool already has transactions from this address
+		// check if mempool already has transactions from this address or sponsored by the sponsor of the tx
+		if isSponsored {
+			if _, has := currentMempool.Load(sponsor); has {
+				return Response{
+					Code: code.TxFromSenderAlreadyInMempool,
+					Log:  fmt.Sprintf("Tx from %s already exists in mempool", sponsor.String()),
+					Info: EncodeError(code.NewTxFromSenderAlreadyInMempool(sponsor.String(), strconv.Itoa(int(currentBlock)))),
+				}
+			}
+		}
 		if _, has := currentMempool.LoadOrStore(sender, true); has {
 			return Response{
 				Code: code.TxFromSenderAlreadyInMempool,
@@ -207,6 +270,9 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 				Info: EncodeError(code.NewTxFromSenderAlreadyInMempool(sender.String(), strconv.Itoa(int(currentBlock)))),
 			}
 		}
+		if isSponsored {
+			currentMempool.Store(sponsor, true)
+		}
 	}
 
 	if !isCheck {
@@ -236,17 +302,9 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 				return *errResp
 			}
 
-			var intruder = sender
-			if tx.Type == TypeRedeemCheck {
-				decodedCheck, err := check.DecodeFromBytes(tx.decodedData.(*RedeemCheckData).RawCheck)
-				if err != nil {
-					return Response{
-						Code: code.DecodeError,
-						Log:  err.Error(),
-						Info: EncodeError(code.NewDecodeError()),
-					}
-				}
-				checkSender, err := decodedCheck.Sender()
+			var intruder = sponsor
+			if issued, ok := tx.decodedData.(checkIssuer); ok && !isEscrowedCheck(tx.decodedData, checkState) {
+				checkSender, err := issued.checkIssuer()
 				if err != nil {
 					return Response{
 						Code: code.DecodeError,
@@ -329,27 +387,11 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 			}
 		} else if deliverState, ok := context.(*state.State); ok {
 			if tx.Type == TypeCreateCoin || tx.Type == TypeCreateToken {
-				dataCreateSymbol := tx.decodedData.(symbolCreator)
-				symbolPrice := tx.MulGasPrice(dataCreateSymbol.PayForSymbol(commissions))
-				if !commissions.Coin.IsBaseCoin() {
-					var resp *Response
-					resp, symbolPrice, _ = CheckSwap(checkState.Swap().GetSwapper(commissions.Coin, types.GetBaseCoinID()), checkState.Coins().GetCoin(commissions.Coin), checkState.Coins().GetCoin(0), symbolPrice, big.NewInt(0), false)
-					if resp != nil {
-						return *resp
-					}
-				}
-				if symbolPrice == nil || symbolPrice.Sign() != 1 {
-					return Response{
-						Code: code.CommissionCoinNotSufficient,
-						Log:  fmt.Sprint("Not possible to pay commission"),
-						Info: EncodeError(code.NewCommissionCoinNotSufficient("", "")),
-					}
+				tag, resp := burnForSymbol(tx, checkState, deliverState, rewardPool, commissions)
+				if resp != nil {
+					return *resp
 				}
-				rewardPool.Sub(rewardPool, symbolPrice)
-				deliverState.Accounts.AddBalance([20]byte{}, 0, symbolPrice)
-				response.Tags = append(response.Tags,
-					abcTypes.EventAttribute{Key: []byte("tx.burned_for_symbol"), Value: []byte(symbolPrice.String())},
-				)
+				response.Tags = append(response.Tags, tag)
 			}
 		}
 	}
@@ -363,9 +405,15 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 			abcTypes.EventAttribute{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(tx.decodedData.TxType())})), Index: true},
 			abcTypes.EventAttribute{Key: []byte("tx.commission_coin"), Value: []byte(tx.CommissionCoin().String()), Index: true},
 		)
-		if tx.Type != TypeRedeemCheck {
+		if _, ok := tx.decodedData.(checkIssuer); !ok {
 			response.Tags = append(response.Tags, abcTypes.EventAttribute{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:])), Index: true})
 		}
+		if isSponsored {
+			response.Tags = append(response.Tags,
+				abcTypes.EventAttribute{Key: []byte("tx.sponsor"), Value: []byte(hex.EncodeToString(sponsor[:])), Index: true},
+				abcTypes.EventAttribute{Key: []byte("tx.sponsor_commission"), Value: []byte(sponsoredCommission.String())},
+			)
+		}
 	}
 
 	response.GasUsed = tx.Gas()
@@ -374,3 +422,27 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 
 	return response
 }
+
+// burnForSymbol moves the part of commission paid for the ticker of created coin from the reward pool to the zero address
+func burnForSymbol(tx *Transaction, checkState *state.CheckState, deliverState *state.State, rewardPool *big.Int, commissions *commission.Price) (abcTypes.EventAttribute, *Response) {
+	dataCreateSymbol := tx.decodedData.(symbolCreator)
+	symbolPrice := tx.MulGasPrice(dataCreateSymbol.PayForSymbol(commissions))
+	if !commissions.Coin.IsBaseCoin() {
+		var resp *Response
+		resp, symbolPrice, _ = CheckSwap(checkState.Swap().GetSwapper(commissions.Coin, types.GetBaseCoinID()), checkState.Coins().GetCoin(commissions.Coin), checkState.Coins().GetCoin(0), symbolPrice, big.NewInt(0), false)
+		if resp != nil {
+			return abcTypes.EventAttribute{}, resp
+		}
+	}
+	if symbolPrice == nil || symbolPrice.Sign() != 1 {
+		return abcTypes.EventAttribute{}, &Response{
+			Code: code.CommissionCoinNotSufficient,
+			Log:  fmt.Sprint("Not possible to pay commission"),
+			Info: EncodeError(code.NewCommissionCoinNotSufficient("", "")),
+		}
+	}
+	rewardPool.Sub(rewardPool, symbolPrice)
+	deliverState.Accounts.AddBalance([20]byte{}, 0, symbolPrice)
+
+	return abcTypes.EventAttribute{Key: []byte("tx.burned_for_symbol"), Value: []byte(symbolPrice.String())}, nil
+}
@@ -0,0 +1,120 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestHTLCTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateWithLockPrice()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	recipientKey, _ := crypto.GenerateKey()
+	recipient := crypto.PubkeyToAddress(recipientKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	preimage := []byte("secret")
+	value := helpers.BipToPip(big.NewInt(100))
+	create := CreateHTLCData{
+		Recipient: recipient,
+		Coin:      coin,
+		Value:     value,
+		Hashlock:  sha256.Sum256(preimage),
+		Timeout:   100,
+	}
+
+	response := NewExecutor(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateHTLC, create), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if cState.HTLC.Get(1) == nil {
+		t.Fatal("HTLC not found")
+	}
+
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeRefundHTLC, RefundHTLCData{ID: 1}), big.NewInt(0), 50, &sync.Map{}, 0, false)
+	if response.Code != code.HTLCNotExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.HTLCNotExpired, response.Log)
+	}
+
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, recipientKey, 1, TypeClaimHTLC, ClaimHTLCData{ID: 1, Preimage: []byte("wrong")}), big.NewInt(0), 50, &sync.Map{}, 0, false)
+	if response.Code != code.WrongHTLCPreimage {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongHTLCPreimage, response.Log)
+	}
+
+	// the recipient without balance pays the commission from the claimed value
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, recipientKey, 1, TypeClaimHTLC, ClaimHTLCData{ID: 1, Preimage: preimage}), big.NewInt(0), 50, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if cState.HTLC.Get(1) != nil {
+		t.Fatal("HTLC is not removed")
+	}
+
+	expectedBalance := big.NewInt(0).Sub(value, commissionPrice.Send)
+	if balance := cState.Accounts.GetBalance(recipient, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRefundHTLCTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateWithLockPrice()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	recipientKey, _ := crypto.GenerateKey()
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	preimage := []byte("secret")
+	create := CreateHTLCData{
+		Recipient: crypto.PubkeyToAddress(recipientKey.PublicKey),
+		Coin:      coin,
+		Value:     helpers.BipToPip(big.NewInt(100)),
+		Hashlock:  sha256.Sum256(preimage),
+		Timeout:   100,
+	}
+
+	response := NewExecutor(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateHTLC, create), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	balance := cState.Accounts.GetBalance(addr, coin)
+
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, recipientKey, 1, TypeClaimHTLC, ClaimHTLCData{ID: 1, Preimage: preimage}), big.NewInt(0), 100, &sync.Map{}, 0, false)
+	if response.Code != code.HTLCExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.HTLCExpired, response.Log)
+	}
+
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeRefundHTLC, RefundHTLCData{ID: 1}), big.NewInt(0), 100, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(big.NewInt(0).Add(balance, create.Value), commissionPrice.Send)
+	if b := cState.Accounts.GetBalance(addr, coin); b.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sender balance is not correct. Expected %s, got %s", expectedBalance, b)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
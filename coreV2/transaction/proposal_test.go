@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func createTestMultisig(cState *state.State, signers int, threshold uint32) (types.Address, []*ecdsa.PrivateKey) {
+	var keys []*ecdsa.PrivateKey
+	var addresses []types.Address
+	var weights []uint32
+	for i := 0; i < signers; i++ {
+		privateKey, _ := crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+		cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(10)))
+
+		keys = append(keys, privateKey)
+		addresses = append(addresses, addr)
+		weights = append(weights, 1)
+	}
+
+	multisig := cState.Accounts.CreateMultisig(weights, addresses, threshold, accounts.CreateMultisigAddress(addresses[0], 1))
+	cState.Accounts.AddBalance(multisig, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(100)))
+
+	return multisig, keys
+}
+
+func TestProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin := types.GetBaseCoinID()
+	multisig, keys := createTestMultisig(cState, 3, 2)
+
+	to := types.Address{1}
+	value := helpers.BipToPip(big.NewInt(10))
+	encodedData, err := rlp.EncodeToBytes(SendData{Coin: coin, To: to, Value: value})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := CreateProposalData{
+		Multisig:     multisig,
+		GasCoin:      coin,
+		Type:         TypeSend,
+		Data:         encodedData,
+		ExpireHeight: 100,
+	}
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, keys[0], 1, TypeCreateProposal, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	proposals := cState.Proposals.GetByMultisig(multisig)
+	if len(proposals) != 1 || len(proposals[0].Approvals) != 1 {
+		t.Fatalf("Proposal is not created")
+	}
+	id := proposals[0].ID
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Sign() != 0 {
+		t.Fatalf("Proposal is executed before approval, target balance %s", balance)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, keys[0], 2, TypeApproveProposal, ApproveProposalData{ID: id}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.ProposalAlreadyVoted {
+		t.Fatalf("Response code is not %d. Error: %s", code.ProposalAlreadyVoted, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, keys[1], 1, TypeApproveProposal, ApproveProposalData{ID: id}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(value) != 0 {
+		t.Fatalf("Target balance is not correct. Expected %s, got %s", value, balance)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(90)), commissionPrice.Send)
+	if balance := cState.Accounts.GetBalance(multisig, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Multisig balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if nonce := cState.Accounts.GetNonce(multisig); nonce != 1 {
+		t.Fatalf("Multisig nonce is not correct. Expected 1, got %d", nonce)
+	}
+
+	if cState.Proposals.Get(id) != nil {
+		t.Fatalf("Executed proposal is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRejectProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin := types.GetBaseCoinID()
+	multisig, keys := createTestMultisig(cState, 3, 2)
+
+	encodedData, err := rlp.EncodeToBytes(SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := CreateProposalData{
+		Multisig:     multisig,
+		GasCoin:      coin,
+		Type:         TypeSend,
+		Data:         encodedData,
+		ExpireHeight: 100,
+	}
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, keys[0], 1, TypeCreateProposal, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	id := cState.Proposals.GetByMultisig(multisig)[0].ID
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, keys[1], 1, TypeRejectProposal, RejectProposalData{ID: id}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	if proposal := cState.Proposals.Get(id); proposal == nil || len(proposal.Rejections) != 1 {
+		t.Fatalf("Proposal is deleted while the threshold is reachable")
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, keys[2], 1, TypeRejectProposal, RejectProposalData{ID: id}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	if cState.Proposals.Get(id) != nil {
+		t.Fatalf("Rejected proposal is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
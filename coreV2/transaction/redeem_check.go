@@ -53,6 +53,25 @@ func (data RedeemCheckData) basicCheck(tx *Transaction, context *state.CheckStat
 	return nil
 }
 
+func (data RedeemCheckData) checkIssuer() (types.Address, error) {
+	decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return decodedCheck.Sender()
+}
+
+// isEscrowed reports whether the check is redeemed from the escrow, so the commission is paid by the sender
+func (data RedeemCheckData) isEscrowed(context *state.CheckState) bool {
+	decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+	if err != nil {
+		return false
+	}
+
+	return context.Checks().GetEscrow(decodedCheck.Hash()) != nil
+}
+
 func (data RedeemCheckData) String() string {
 	return fmt.Sprintf("REDEEM CHECK proof: %x", data.Proof)
 }
@@ -141,6 +160,14 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
+	if checkState.Checks().IsCheckCancelled(decodedCheck.Hash()) {
+		return Response{
+			Code: code.CheckCancelled,
+			Log:  "Check is cancelled",
+			Info: EncodeError(code.NewCheckCancelled(decodedCheck.Hash().String())),
+		}
+	}
+
 	if checkState.Checks().IsCheckUsed(decodedCheck) {
 		return Response{
 			Code: code.CheckUsed,
@@ -159,29 +186,12 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
-	var senderAddressHash types.Hash
-	hw := sha3.NewLegacyKeccak256()
-	_ = rlp.Encode(hw, []interface{}{
-		sender,
-	})
-	hw.Sum(senderAddressHash[:0])
-
-	pub, err := crypto.Ecrecover(senderAddressHash[:], data.Proof[:])
-
-	if err != nil {
-		return Response{
-			Code: code.DecodeError,
-			Log:  err.Error(),
-			Info: EncodeError(code.NewDecodeError()),
-		}
+	if response := checkRedeemProof(sender, data.Proof, lockPublicKey); response != nil {
+		return *response
 	}
 
-	if !bytes.Equal(lockPublicKey, pub) {
-		return Response{
-			Code: code.CheckInvalidLock,
-			Log:  "Invalid proof",
-			Info: EncodeError(code.NewCheckInvalidLock()),
-		}
+	if escrow := checkState.Checks().GetEscrow(decodedCheck.Hash()); escrow != nil {
+		return redeemEscrowedCheck(tx, context, checkState, decodedCheck, escrow, rewardPool, price)
 	}
 
 	commissionInBaseCoin := price
@@ -269,3 +279,48 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		Tags: tags,
 	}
 }
+
+// checkIssuer is implemented by data of txs which commission is paid by the issuer of the check
+type checkIssuer interface {
+	checkIssuer() (types.Address, error)
+}
+
+// escrowedCheck is implemented by data of txs which can redeem the check from the escrow
+type escrowedCheck interface {
+	isEscrowed(context *state.CheckState) bool
+}
+
+func isEscrowedCheck(data Data, context *state.CheckState) bool {
+	escrowed, ok := data.(escrowedCheck)
+	return ok && escrowed.isEscrowed(context)
+}
+
+// checkRedeemProof checks that the proof is the sender address signed by the key of the check lock
+func checkRedeemProof(sender types.Address, proof [65]byte, lockPublicKey []byte) *Response {
+	var senderAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		sender,
+	})
+	hw.Sum(senderAddressHash[:0])
+
+	pub, err := crypto.Ecrecover(senderAddressHash[:], proof[:])
+
+	if err != nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !bytes.Equal(lockPublicKey, pub) {
+		return &Response{
+			Code: code.CheckInvalidLock,
+			Log:  "Invalid proof",
+			Info: EncodeError(code.NewCheckInvalidLock()),
+		}
+	}
+
+	return nil
+}
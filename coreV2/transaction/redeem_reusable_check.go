@@ -0,0 +1,276 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// RedeemReusableCheckData redeems the part of the reusable check
+type RedeemReusableCheckData struct {
+	RawCheck []byte
+	Proof    [65]byte
+	Value    *big.Int
+}
+
+func (data RedeemReusableCheckData) Gas() int64 {
+	return gasRedeemReusableCheck
+}
+
+func (data RedeemReusableCheckData) TxType() TxType {
+	return TypeRedeemReusableCheck
+}
+
+func (data RedeemReusableCheckData) checkIssuer() (types.Address, error) {
+	decodedCheck, err := check.DecodeReusableFromBytes(data.RawCheck)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return decodedCheck.Sender()
+}
+
+func (data RedeemReusableCheckData) basicCheck(tx *Transaction, context *state.CheckState, decodedCheck *check.ReusableCheck, currentBlock uint64) *Response {
+	// fixed potential problem with making too high commission for sender
+	if tx.GasPrice != 1 {
+		return &Response{
+			Code: code.TooHighGasPrice,
+			Log:  "Gas price for check is limited to 1",
+			Info: EncodeError(code.NewTooHighGasPrice("1", strconv.Itoa(int(tx.GasPrice)))),
+		}
+	}
+
+	if decodedCheck.ChainID != types.CurrentChainID {
+		return &Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", decodedCheck.ChainID))),
+		}
+	}
+
+	if len(decodedCheck.Nonce) > 16 {
+		return &Response{
+			Code: code.TooLongNonce,
+			Log:  "Nonce is too big. Should be up to 16 bytes.",
+			Info: EncodeError(code.NewTooLongNonce(strconv.Itoa(len(decodedCheck.Nonce)), "16")),
+		}
+	}
+
+	if !context.Coins().Exists(decodedCheck.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", decodedCheck.Coin.String())),
+		}
+	}
+
+	if !context.Coins().Exists(decodedCheck.GasCoin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Gas coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", decodedCheck.GasCoin.String())),
+		}
+	}
+
+	if tx.GasCoin != decodedCheck.GasCoin {
+		return &Response{
+			Code: code.WrongGasCoin,
+			Log:  fmt.Sprintf("CommissionData coin for redeem check transaction can only be %s", decodedCheck.GasCoin),
+			Info: EncodeError(code.NewWrongGasCoin(context.Coins().GetCoin(tx.GasCoin).GetFullSymbol(), tx.GasCoin.String(), context.Coins().GetCoin(decodedCheck.GasCoin).GetFullSymbol(), decodedCheck.GasCoin.String())),
+		}
+	}
+
+	if decodedCheck.DueBlock < currentBlock {
+		return &Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", decodedCheck.DueBlock), fmt.Sprintf("%d", currentBlock))),
+		}
+	}
+
+	hash := decodedCheck.Hash()
+	remaining := decodedCheck.Value
+	if checkState := context.Checks().GetReusableCheck(hash); checkState != nil {
+		if checkState.Revoked {
+			return &Response{
+				Code: code.ReusableCheckRevoked,
+				Log:  "Check is revoked",
+				Info: EncodeError(code.NewReusableCheckRevoked(hash.String())),
+			}
+		}
+		remaining = checkState.Remaining()
+	}
+
+	if data.Value == nil || data.Value.Sign() != 1 || data.Value.Cmp(remaining) == 1 {
+		return &Response{
+			Code: code.WrongRedeemValue,
+			Log:  fmt.Sprintf("Value should be positive and not greater than the remaining value %s", remaining),
+			Info: EncodeError(code.NewWrongRedeemValue(data.Value.String(), remaining.String())),
+		}
+	}
+
+	if decodedCheck.MaxPerRedeemer.Sign() == 1 {
+		sender, _ := tx.Sender()
+		redeemed := context.Checks().GetRedeemedBy(hash, sender)
+		if big.NewInt(0).Add(redeemed, data.Value).Cmp(decodedCheck.MaxPerRedeemer) == 1 {
+			return &Response{
+				Code: code.RedeemerLimitExceeded,
+				Log:  fmt.Sprintf("Redeemer can redeem not more than %s in total, already redeemed %s", decodedCheck.MaxPerRedeemer, redeemed),
+				Info: EncodeError(code.NewRedeemerLimitExceeded(data.Value.String(), redeemed.String(), decodedCheck.MaxPerRedeemer.String())),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (data RedeemReusableCheckData) String() string {
+	return fmt.Sprintf("REDEEM REUSABLE CHECK value: %s proof: %x", data.Value, data.Proof)
+}
+
+func (data RedeemReusableCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.RedeemReusableCheckPrice()
+}
+
+func (data RedeemReusableCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedCheck, err := check.DecodeReusableFromBytes(data.RawCheck)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	response := data.basicCheck(tx, checkState, decodedCheck, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	checkSender, err := decodedCheck.Sender()
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	lockPublicKey, err := decodedCheck.LockPubKey()
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if response := checkRedeemProof(sender, data.Proof, lockPublicKey); response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	coin := checkState.Coins().GetCoin(decodedCheck.Coin)
+
+	if decodedCheck.Coin == decodedCheck.GasCoin {
+		totalTxCost := big.NewInt(0).Add(data.Value, commission)
+		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(totalTxCost) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s %s. Wanted %s %s", data.Value.String(), coin.GetFullSymbol(), totalTxCost.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), totalTxCost.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	} else {
+		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(data.Value) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s. Wanted %s %s", checkSender.String(), data.Value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+
+		if checkState.Accounts().GetBalance(checkSender, decodedCheck.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s. Wanted %s %s", checkSender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		hash := decodedCheck.Hash()
+		deliverState.Checks.RedeemReusableCheck(hash, decodedCheck.Value, sender, data.Value)
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(checkSender, decodedCheck.GasCoin, commission)
+		deliverState.Accounts.SubBalance(checkSender, decodedCheck.Coin, data.Value)
+		deliverState.Accounts.AddBalance(sender, decodedCheck.Coin, data.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(sender[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(decodedCheck.Coin.String()), Index: true},
+			{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(checkSender[:])), Index: true},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(hash[:])), Index: true},
+			{Key: []byte("tx.check_remaining"), Value: []byte(deliverState.Checks.GetReusableCheck(hash).Remaining().String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,149 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// RefundHTLCData returns the locked coins to the sender of HTLC after its timeout
+type RefundHTLCData struct {
+	ID uint32
+}
+
+func (data RefundHTLCData) TxType() TxType {
+	return TypeRefundHTLC
+}
+
+func (data RefundHTLCData) Gas() int64 {
+	return gasRefundHTLC
+}
+
+func (data RefundHTLCData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	contract := context.HTLC().Get(data.ID)
+	if contract == nil {
+		return &Response{
+			Code: code.HTLCNotExists,
+			Log:  fmt.Sprintf("HTLC %d not exists", data.ID),
+			Info: EncodeError(code.NewHTLCNotExists(data.ID)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if contract.Sender != sender {
+		return &Response{
+			Code: code.IsNotSenderOfHTLC,
+			Log:  "Sender is not a sender of the HTLC",
+			Info: EncodeError(code.NewIsNotSenderOfHTLC(data.ID, contract.Sender.String())),
+		}
+	}
+
+	if !contract.IsExpired(block) {
+		return &Response{
+			Code: code.HTLCNotExpired,
+			Log:  fmt.Sprintf("HTLC %d can be refunded from height %d", data.ID, contract.Timeout),
+			Info: EncodeError(code.NewHTLCNotExpired(data.ID, strconv.FormatUint(contract.Timeout, 10))),
+		}
+	}
+
+	return nil
+}
+
+func (data RefundHTLCData) String() string {
+	return fmt.Sprintf("REFUND HTLC id:%d", data.ID)
+}
+
+func (data RefundHTLCData) CommissionData(price *commission.Price) *big.Int {
+	return price.RefundHTLCPrice()
+}
+
+func (data RefundHTLCData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	// refunded value can be used to pay the commission
+	balance := checkState.Accounts().GetBalance(sender, tx.GasCoin)
+	if contract := checkState.HTLC().Get(data.ID); contract.Coin == tx.GasCoin {
+		balance.Add(balance, contract.Value)
+	}
+	if balance.Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		contract := deliverState.HTLC.Get(data.ID)
+		deliverState.HTLC.Delete(data.ID)
+		deliverState.Accounts.AddBalance(sender, contract.Coin, contract.Value)
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(contract.Coin.String()), Index: true},
+			{Key: []byte("tx.htlc_id"), Value: []byte(strconv.Itoa(int(data.ID))), Index: true},
+			{Key: []byte("tx.return"), Value: []byte(contract.Value.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,137 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+func createTestReusableCheck(t *testing.T, issuerKey, passphraseKey *ecdsa.PrivateKey, value, maxPerRedeemer *big.Int) []byte {
+	check := c.ReusableCheck{
+		Nonce:          []byte{1, 2, 3},
+		ChainID:        types.CurrentChainID,
+		DueBlock:       100,
+		Coin:           types.GetBaseCoinID(),
+		Value:          value,
+		MaxPerRedeemer: maxPerRedeemer,
+		GasCoin:        types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphraseKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuerKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rawCheck
+}
+
+func createTestCheckProof(t *testing.T, passphraseKey *ecdsa.PrivateKey, address types.Address) [65]byte {
+	var addressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		address,
+	})
+	hw.Sum(addressHash[:0])
+
+	sig, err := crypto.Sign(addressHash.Bytes(), passphraseKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := [65]byte{}
+	copy(proof[:], sig)
+
+	return proof
+}
+
+func TestRedeemReusableCheckTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphraseKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck := createTestReusableCheck(t, issuerKey, passphraseKey, helpers.BipToPip(big.NewInt(30)), helpers.BipToPip(big.NewInt(10)))
+	decodedCheck, _ := c.DecodeReusableFromBytes(rawCheck)
+
+	redeemerKey, _ := crypto.GenerateKey()
+	redeemer := crypto.PubkeyToAddress(redeemerKey.PublicKey)
+	proof := createTestCheckProof(t, passphraseKey, redeemer)
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, redeemerKey, 1, TypeRedeemReusableCheck, RedeemReusableCheckData{RawCheck: rawCheck, Proof: proof, Value: helpers.BipToPip(big.NewInt(6))}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, redeemerKey, 2, TypeRedeemReusableCheck, RedeemReusableCheckData{RawCheck: rawCheck, Proof: proof, Value: helpers.BipToPip(big.NewInt(6))}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.RedeemerLimitExceeded {
+		t.Fatalf("Response code is not %d. Error: %s", code.RedeemerLimitExceeded, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, redeemerKey, 2, TypeRedeemReusableCheck, RedeemReusableCheckData{RawCheck: rawCheck, Proof: proof, Value: helpers.BipToPip(big.NewInt(4))}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(redeemer, coin); balance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Redeemer balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), balance)
+	}
+
+	expectedIssuerBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(990)), big.NewInt(0).Mul(commissionPrice.RedeemCheck, big.NewInt(2)))
+	if balance := cState.Accounts.GetBalance(issuer, coin); balance.Cmp(expectedIssuerBalance) != 0 {
+		t.Fatalf("Issuer balance is not correct. Expected %s, got %s", expectedIssuerBalance, balance)
+	}
+
+	if remaining := cState.Checks.GetReusableCheck(decodedCheck.Hash()).Remaining(); remaining.Cmp(helpers.BipToPip(big.NewInt(20))) != 0 {
+		t.Fatalf("Remaining value is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(20)), remaining)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, redeemerKey, 3, TypeRevokeReusableCheck, RevokeReusableCheckData{RawCheck: rawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotIssuerOfCheck {
+		t.Fatalf("Response code is not %d. Error: %s", code.IsNotIssuerOfCheck, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, issuerKey, 1, TypeRevokeReusableCheck, RevokeReusableCheckData{RawCheck: rawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	anotherKey, _ := crypto.GenerateKey()
+	anotherProof := createTestCheckProof(t, passphraseKey, crypto.PubkeyToAddress(anotherKey.PublicKey))
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, anotherKey, 1, TypeRedeemReusableCheck, RedeemReusableCheckData{RawCheck: rawCheck, Proof: anotherProof, Value: big.NewInt(1)}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.ReusableCheckRevoked {
+		t.Fatalf("Response code is not %d. Error: %s", code.ReusableCheckRevoked, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
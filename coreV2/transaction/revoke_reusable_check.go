@@ -0,0 +1,157 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// RevokeReusableCheckData is sent by the issuer of the reusable check to forbid redeeming of its remaining value
+type RevokeReusableCheckData struct {
+	RawCheck []byte
+}
+
+func (data RevokeReusableCheckData) TxType() TxType {
+	return TypeRevokeReusableCheck
+}
+
+func (data RevokeReusableCheckData) Gas() int64 {
+	return gasRevokeReusableCheck
+}
+
+func (data RevokeReusableCheckData) basicCheck(tx *Transaction, context *state.CheckState, decodedCheck *check.ReusableCheck) *Response {
+	hash := decodedCheck.Hash()
+	checkSender, err := decodedCheck.Sender()
+	if err != nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if checkSender != sender {
+		return &Response{
+			Code: code.IsNotIssuerOfCheck,
+			Log:  "Sender is not an issuer of the check",
+			Info: EncodeError(code.NewIsNotIssuerOfCheck(hash.String(), sender.String())),
+		}
+	}
+
+	if checkState := context.Checks().GetReusableCheck(hash); checkState != nil && checkState.Revoked {
+		return &Response{
+			Code: code.ReusableCheckRevoked,
+			Log:  "Check is already revoked",
+			Info: EncodeError(code.NewReusableCheckRevoked(hash.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data RevokeReusableCheckData) String() string {
+	return fmt.Sprintf("REVOKE REUSABLE CHECK check: %x", data.RawCheck)
+}
+
+func (data RevokeReusableCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.RevokeReusableCheckPrice()
+}
+
+func (data RevokeReusableCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedCheck, err := check.DecodeReusableFromBytes(data.RawCheck)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	response := data.basicCheck(tx, checkState, decodedCheck)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		hash := decodedCheck.Hash()
+		remaining := decodedCheck.Value
+		if checkState := deliverState.Checks.GetReusableCheck(hash); checkState != nil {
+			remaining = checkState.Remaining()
+		}
+		deliverState.Checks.RevokeReusableCheck(hash, decodedCheck.Value)
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(hash[:])), Index: true},
+			{Key: []byte("tx.check_remaining"), Value: []byte(remaining.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
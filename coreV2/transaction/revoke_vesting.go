@@ -0,0 +1,143 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type RevokeVestingData struct {
+	Beneficiary types.Address
+	ID          uint32
+}
+
+func (data RevokeVestingData) TxType() TxType {
+	return TypeRevokeVesting
+}
+
+func (data RevokeVestingData) Gas() int64 {
+	return gasRevokeVesting
+}
+
+func (data RevokeVestingData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	item := context.Vesting().Get(data.Beneficiary, data.ID)
+	if item == nil {
+		return &Response{
+			Code: code.VestingNotExists,
+			Log:  fmt.Sprintf("Vesting %d of %s not exists", data.ID, data.Beneficiary.String()),
+			Info: EncodeError(code.NewVestingNotExists(data.Beneficiary.String(), data.ID)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if !item.IsRevocable() || *item.Revoker != sender {
+		revoker := ""
+		if item.IsRevocable() {
+			revoker = item.Revoker.String()
+		}
+		return &Response{
+			Code: code.IsNotRevokerOfVesting,
+			Log:  "Sender is not a revoker of the vesting",
+			Info: EncodeError(code.NewIsNotRevokerOfVesting(data.ID, revoker)),
+		}
+	}
+
+	return nil
+}
+
+func (data RevokeVestingData) String() string {
+	return fmt.Sprintf("REVOKE VESTING beneficiary:%s id:%d", data.Beneficiary.String(), data.ID)
+}
+
+func (data RevokeVestingData) CommissionData(price *commission.Price) *big.Int {
+	return price.RevokeVestingPrice()
+}
+
+func (data RevokeVestingData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		// unlocked value belongs to the beneficiary, only the locked one is returned
+		coin, claimable, locked := deliverState.Vesting.Revoke(data.Beneficiary, data.ID, currentBlock)
+		deliverState.Accounts.AddBalance(data.Beneficiary, coin, claimable)
+		deliverState.Accounts.AddBalance(sender, coin, locked)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(coin.String()), Index: true},
+			{Key: []byte("tx.beneficiary"), Value: []byte(hex.EncodeToString(data.Beneficiary[:])), Index: true},
+			{Key: []byte("tx.vesting_id"), Value: []byte(strconv.Itoa(int(data.ID))), Index: true},
+			{Key: []byte("tx.return"), Value: []byte(locked.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
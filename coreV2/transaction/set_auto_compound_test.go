@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestSetAutoCompoundTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	pubkey := createTestCandidate(cState)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	data := SetAutoCompoundData{
+		PubKey:  pubkey,
+		Enabled: false,
+	}
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeSetAutoCompound, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.StakeNotFound {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.StakeNotFound, response.Log)
+	}
+
+	cState.Candidates.Delegate(addr, pubkey, coin, helpers.BipToPip(big.NewInt(100)), big.NewInt(0))
+	cState.Candidates.RecalculateStakes(109000)
+
+	if !cState.Candidates.IsAutoCompound(pubkey, addr) {
+		t.Fatal("Auto-compound is not enabled by default")
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeSetAutoCompound, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if cState.Candidates.IsAutoCompound(pubkey, addr) {
+		t.Fatal("Auto-compound is not disabled")
+	}
+
+	data.Enabled = true
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeSetAutoCompound, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if !cState.Candidates.IsAutoCompound(pubkey, addr) {
+		t.Fatal("Auto-compound is not enabled")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,58 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestSetAutoRouteTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	data := SetAutoRouteData{
+		Enabled: true,
+	}
+	rand.Read(data.PubKey[:])
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeSetAutoRoute, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CandidateNotFound {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.CandidateNotFound, response.Log)
+	}
+
+	data.PubKey = createTestCandidate(cState)
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeSetAutoRoute, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if route := cState.Waitlist.GetRoute(addr); route == nil || route.CandidateID != cState.Candidates.ID(data.PubKey) {
+		t.Fatal("Auto-route is not set")
+	}
+
+	data.Enabled = false
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeSetAutoRoute, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	if cState.Waitlist.GetRoute(addr) != nil {
+		t.Fatal("Auto-route is not disabled")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,52 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// creditSponsoredCommission moves the commission of the sponsored tx from the sponsor to the sender before the run of the tx.
+// On the check state the commission is moved without changing the state
+func creditSponsoredCommission(tx *Transaction, context state.Interface, checkState *state.CheckState, sender, sponsor types.Address, price *big.Int) (state.Interface, *big.Int, *Response) {
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.CommissionCoin(), types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.CommissionCoin())
+	commission, _, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, price)
+	if errResp != nil {
+		return nil, nil, errResp
+	}
+
+	if checkState.Accounts().GetBalance(sponsor, tx.CommissionCoin()).Cmp(commission) < 0 {
+		return nil, nil, &Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sponsor account: %s. Wanted %s %s", sponsor.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sponsor.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	deliverState, isDeliver := context.(*state.State)
+	if !isDeliver {
+		return checkState.WithTransfer(sponsor, sender, tx.CommissionCoin(), commission), commission, nil
+	}
+
+	deliverState.Accounts.SubBalance(sponsor, tx.CommissionCoin(), commission)
+	deliverState.Accounts.AddBalance(sender, tx.CommissionCoin(), commission)
+
+	return context, commission, nil
+}
+
+// settleSponsoredCommission returns the credited commission back to the sponsor if the tx has failed and returns the commission paid by the sponsor.
+// The commission of batch is paid by parts, so the sum could exceed the credited one, the excess is paid by the sender
+func settleSponsoredCommission(deliverState *state.State, coin types.CoinID, sender, sponsor types.Address, credited *big.Int, ok bool) *big.Int {
+	if ok {
+		return credited
+	}
+
+	deliverState.Accounts.SubBalance(sender, coin, credited)
+	deliverState.Accounts.AddBalance(sponsor, coin, credited)
+
+	return big.NewInt(0)
+}
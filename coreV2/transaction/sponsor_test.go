@@ -0,0 +1,124 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func encodeSponsoredTestTx(t *testing.T, privateKey, sponsorKey *ecdsa.PrivateKey, nonce, validUntil uint64, txType TxType, data interface{}) []byte {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingleSponsored,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SignSponsor(sponsorKey, validUntil); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+func TestSponsoredTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	sponsorKey, _ := crypto.GenerateKey()
+	sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	value := helpers.BipToPip(big.NewInt(10))
+	cState.Accounts.AddBalance(addr, coin, value)
+	cState.Accounts.AddBalance(sponsor, coin, helpers.BipToPip(big.NewInt(100)))
+
+	data := SendData{Coin: coin, To: types.Address{1}, Value: value}
+
+	response := NewExecutorV3(GetData).RunTx(cState, encodeSponsoredTestTx(t, privateKey, sponsorKey, 1, 10, TypeSend, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error: %s", code.DecodeError, response.Log)
+	}
+
+	response = NewExecutorV340(GetData).RunTx(cState, encodeSponsoredTestTx(t, privateKey, sponsorKey, 1, 10, TypeSend, data), big.NewInt(0), 11, &sync.Map{}, 0, false)
+	if response.Code != code.SponsorshipExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.SponsorshipExpired, response.Log)
+	}
+
+	mempool := &sync.Map{}
+	response = NewExecutorV340(GetData).RunTx(state.NewCheckState(cState), encodeSponsoredTestTx(t, privateKey, sponsorKey, 1, 10, TypeSend, data), big.NewInt(0), 1, mempool, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	if _, has := mempool.Load(sponsor); !has {
+		t.Fatal("Sponsor is not in mempool")
+	}
+
+	response = NewExecutorV340(GetData).RunTx(cState, encodeSponsoredTestTx(t, privateKey, sponsorKey, 1, 10, TypeSend, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Sign() != 0 {
+		t.Fatalf("Sender balance is not correct. Expected 0, got %s", balance)
+	}
+
+	var sponsorCommission string
+	for _, tag := range response.Tags {
+		if string(tag.Key) == "tx.sponsor_commission" {
+			sponsorCommission = string(tag.Value)
+		}
+	}
+	if sponsorCommission != commissionPrice.Send.String() {
+		t.Fatalf("Sponsor commission tag is not correct. Expected %s, got %s", commissionPrice.Send, sponsorCommission)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(100)), commissionPrice.Send)
+	if balance := cState.Accounts.GetBalance(sponsor, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sponsor balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	// the failed tx is paid by the sponsor too
+	response = NewExecutorV340(GetData).RunTx(cState, encodeSponsoredTestTx(t, privateKey, sponsorKey, 2, 10, TypeSend, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+	}
+
+	expectedBalance.Sub(expectedBalance, cState.Commission.GetCommissions().FailedTx)
+	if balance := cState.Accounts.GetBalance(sponsor, coin); balance.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Sponsor balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Sign() != 0 {
+		t.Fatalf("Sender balance is not correct. Expected 0, got %s", balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
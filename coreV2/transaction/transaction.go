@@ -66,6 +66,28 @@ const (
 	TypeRemoveLimitOrder        TxType = 0x24
 	TypeLockStake               TxType = 0x25
 	TypeLock                    TxType = 0x26
+	TypeCreateVesting           TxType = 0x27
+	TypeClaimVesting            TxType = 0x28
+	TypeRevokeVesting           TxType = 0x29
+	TypeBatch                   TxType = 0x2A
+	TypeCreateProposal          TxType = 0x2B
+	TypeApproveProposal         TxType = 0x2C
+	TypeRejectProposal          TxType = 0x2D
+	TypeCreateHTLC              TxType = 0x2E
+	TypeClaimHTLC               TxType = 0x2F
+	TypeRefundHTLC              TxType = 0x30
+	TypeRedeemReusableCheck     TxType = 0x31
+	TypeRevokeReusableCheck     TxType = 0x32
+	TypeEscrowCheck             TxType = 0x33
+	TypeCancelCheck             TxType = 0x34
+	TypeAddLimitOrderV2         TxType = 0x35
+	TypeAddTriggerOrder         TxType = 0x36
+	TypeCancelTriggerOrder      TxType = 0x37
+	TypeSetAutoCompound         TxType = 0x38
+	TypeCancelUnbond            TxType = 0x39
+	TypeVoteParam               TxType = 0x3A
+	TypeVoteRewardPool          TxType = 0x3B
+	TypeSetAutoRoute            TxType = 0x3C
 )
 
 const (
@@ -99,7 +121,11 @@ const (
 	gasMintToken = 1
 	gasBurnToken = 1
 
-	gasRedeemCheck = 20
+	gasRedeemCheck         = 20
+	gasRedeemReusableCheck = 20
+	gasRevokeReusableCheck = 2
+	gasEscrowCheck         = 2
+	gasCancelCheck         = 2
 
 	gasDeclareCandidacy = 10
 	gasDelegate         = 6
@@ -107,6 +133,17 @@ const (
 	gasMoveStake        = 6
 	gasLockStake        = 2
 	gasLock             = 2
+	gasSetAutoCompound  = 1
+	gasSetAutoRoute     = 1
+	gasCancelUnbond     = 6
+
+	gasCreateVesting = 2
+	gasClaimVesting  = 1
+	gasRevokeVesting = 2
+
+	gasCreateHTLC = 2
+	gasClaimHTLC  = 1
+	gasRefundHTLC = 1
 
 	gasSetCandidateOnline      = 1
 	gasSetCandidateOffline     = 1
@@ -117,9 +154,15 @@ const (
 	gasCreateMultisig = 20
 	gasEditMultisig   = 5
 
+	gasCreateProposal  = 5
+	gasApproveProposal = 2
+	gasRejectProposal  = 2
+
 	gasSetHaltBlock   = 5
 	gasVoteCommission = 5
 	gasVoteUpdate     = 5
+	gasVoteParam      = 5
+	gasVoteRewardPool = 5
 )
 
 type SigType byte
@@ -127,6 +170,10 @@ type SigType byte
 const (
 	SigTypeSingle SigType = 0x01
 	SigTypeMulti  SigType = 0x02
+
+	// SigTypeSingleSponsored and SigTypeMultiSponsored are signed by the sponsor too, which pays the commission of the tx
+	SigTypeSingleSponsored SigType = 0x03
+	SigTypeMultiSponsored  SigType = 0x04
 )
 
 var (
@@ -148,7 +195,10 @@ type Transaction struct {
 	decodedData Data
 	sig         *Signature
 	multisig    *SignatureMulti
+	sponsorSig  *Signature
+	validUntil  uint64
 	sender      *types.Address
+	sponsor     *types.Address
 }
 
 type Signature struct {
@@ -162,6 +212,14 @@ type SignatureMulti struct {
 	Signatures []Signature
 }
 
+// SignatureSponsored is the signature data of the sponsored tx: the signature data of the sender
+// and the signature of the sponsor valid until the block ValidUntil
+type SignatureSponsored struct {
+	Data       []byte
+	ValidUntil uint64
+	Sponsor    *Signature `rlp:"nil"`
+}
+
 type RawData []byte
 
 type totalSpends []totalSpend
@@ -202,6 +260,11 @@ type Data interface {
 	Gas() int64
 }
 
+// nestedDataDecoder is implemented by data containing other transactions, which are decoded together with the data
+type nestedDataDecoder interface {
+	decode(decodeTxFunc func(txType TxType) (Data, bool)) error
+}
+
 func (tx *Transaction) Serialize() ([]byte, error) {
 	return rlp.EncodeToBytes(tx)
 }
@@ -214,9 +277,12 @@ func (tx *Transaction) Gas() int64 {
 	if tx.payloadAndServiceDataLen() != 0 {
 		base += tx.payloadAndServiceDataLen() / 1000
 	}
-	if tx.SignatureType == SigTypeMulti {
+	if tx.isMultisig() {
 		base += int64(len(tx.multisig.Signatures)) * gasSign
 	}
+	if tx.IsSponsored() {
+		base += gasSign
+	}
 	return base + tx.decodedData.Gas()
 }
 
@@ -255,7 +321,7 @@ func (tx *Transaction) Sign(prv *ecdsa.PrivateKey) error {
 
 func (tx *Transaction) SetSignature(sig []byte) {
 	switch tx.SignatureType {
-	case SigTypeSingle:
+	case SigTypeSingle, SigTypeSingleSponsored:
 		{
 			if tx.sig == nil {
 				tx.sig = &Signature{}
@@ -265,15 +331,9 @@ func (tx *Transaction) SetSignature(sig []byte) {
 			tx.sig.S = new(big.Int).SetBytes(sig[32:64])
 			tx.sig.V = new(big.Int).SetBytes([]byte{sig[64] + 27})
 
-			data, err := rlp.EncodeToBytes(tx.sig)
-
-			if err != nil {
-				panic(err)
-			}
-
-			tx.SignatureData = data
+			tx.encodeSignatureData()
 		}
-	case SigTypeMulti:
+	case SigTypeMulti, SigTypeMultiSponsored:
 		{
 			if tx.multisig == nil {
 				tx.multisig = &SignatureMulti{
@@ -288,15 +348,71 @@ func (tx *Transaction) SetSignature(sig []byte) {
 				S: new(big.Int).SetBytes(sig[32:64]),
 			})
 
-			data, err := rlp.EncodeToBytes(tx.multisig)
+			tx.encodeSignatureData()
+		}
+	}
+}
 
-			if err != nil {
-				panic(err)
-			}
+// SignSponsor signs the sponsored tx by the sponsor, the signature can't be used after the block validUntil
+func (tx *Transaction) SignSponsor(prv *ecdsa.PrivateKey, validUntil uint64) error {
+	if !tx.IsSponsored() {
+		return errors.New("tx is not sponsored")
+	}
+
+	tx.validUntil = validUntil
+	h := tx.SponsorHash()
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return err
+	}
+
+	tx.SetSponsorSignature(sig)
+
+	return nil
+}
+
+// SetSponsorSignature sets the signature of the sponsor of the sponsored tx
+func (tx *Transaction) SetSponsorSignature(sig []byte) {
+	tx.sponsorSig = &Signature{
+		V: new(big.Int).SetBytes([]byte{sig[64] + 27}),
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:64]),
+	}
+	tx.sponsor = nil
+
+	tx.encodeSignatureData()
+}
+
+// encodeSignatureData encodes the signatures to SignatureData, for sponsored txs it is wrapped together with the signature of the sponsor
+func (tx *Transaction) encodeSignatureData() {
+	var data []byte
+	var err error
+	if tx.isMultisig() {
+		data, err = rlp.EncodeToBytes(tx.multisig)
+	} else {
+		data, err = rlp.EncodeToBytes(tx.sig)
+	}
+	if err != nil {
+		panic(err)
+	}
 
-			tx.SignatureData = data
+	if tx.IsSponsored() {
+		data, err = rlp.EncodeToBytes(SignatureSponsored{Data: data, ValidUntil: tx.validUntil, Sponsor: tx.sponsorSig})
+		if err != nil {
+			panic(err)
 		}
 	}
+
+	tx.SignatureData = data
+}
+
+// IsSponsored reports whether the commission of the tx is paid by the sponsor
+func (tx *Transaction) IsSponsored() bool {
+	return tx.SignatureType == SigTypeSingleSponsored || tx.SignatureType == SigTypeMultiSponsored
+}
+
+func (tx *Transaction) isMultisig() bool {
+	return tx.SignatureType == SigTypeMulti || tx.SignatureType == SigTypeMultiSponsored
 }
 
 func (tx *Transaction) MustSender() types.Address {
@@ -312,7 +428,7 @@ func (tx *Transaction) Sender() (types.Address, error) {
 	}
 
 	switch tx.SignatureType {
-	case SigTypeSingle:
+	case SigTypeSingle, SigTypeSingleSponsored:
 		sender, err := RecoverPlain(tx.Hash(), tx.sig.R, tx.sig.S, tx.sig.V)
 		if err != nil {
 			return types.Address{}, err
@@ -320,13 +436,49 @@ func (tx *Transaction) Sender() (types.Address, error) {
 
 		tx.sender = &sender
 		return sender, nil
-	case SigTypeMulti:
+	case SigTypeMulti, SigTypeMultiSponsored:
 		return tx.multisig.Multisig, nil
 	}
 
 	return types.Address{}, errors.New("unknown signature type")
 }
 
+// CommissionPayer returns the address paying the commission of the tx, which is the sponsor for the sponsored txs
+func (tx *Transaction) CommissionPayer() (types.Address, error) {
+	if !tx.IsSponsored() {
+		return tx.Sender()
+	}
+
+	if tx.sponsor != nil {
+		return *tx.sponsor, nil
+	}
+
+	if tx.sponsorSig == nil {
+		return types.Address{}, errors.New("sponsor signature is missing")
+	}
+
+	sponsor, err := RecoverPlain(tx.SponsorHash(), tx.sponsorSig.R, tx.sponsorSig.S, tx.sponsorSig.V)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	tx.sponsor = &sponsor
+	return sponsor, nil
+}
+
+// SponsorValidUntil returns the last block the signature of the sponsor is valid in
+func (tx *Transaction) SponsorValidUntil() uint64 {
+	return tx.validUntil
+}
+
+// SponsorHash returns the hash signed by the sponsor: the hash of the tx with the last block of the sponsorship
+func (tx *Transaction) SponsorHash() types.Hash {
+	return rlpHash([]interface{}{
+		tx.Hash(),
+		tx.validUntil,
+	})
+}
+
 func (tx *Transaction) Hash() types.Hash {
 	return rlpHash([]interface{}{
 		tx.Nonce,
@@ -356,6 +508,11 @@ func (tx *Transaction) SetMultisigAddress(address types.Address) {
 
 	tx.multisig.Multisig = address
 
+	if tx.IsSponsored() {
+		tx.encodeSignatureData()
+		return
+	}
+
 	data, err := rlp.EncodeToBytes(tx.multisig)
 
 	if err != nil {
@@ -3,6 +3,7 @@ package transaction
 import (
 	"github.com/MinterTeam/minter-go-node/coreV2/code"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/crypto"
 	"github.com/MinterTeam/minter-go-node/helpers"
@@ -87,6 +88,45 @@ func TestUnbondTx(t *testing.T) {
 	}
 }
 
+func TestUnbondTxWithVotedPeriod(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	pubkey := createTestCandidate(cState)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	cState.Candidates.Delegate(addr, pubkey, coin, value, big.NewInt(0))
+	cState.Candidates.RecalculateStakes(109000)
+
+	cState.Params.Set(params.UnbondPeriod, 100)
+
+	data := UnbondDataV260{
+		PubKey: pubkey,
+		Coin:   coin,
+		Value:  value,
+	}
+
+	response := NewExecutorV3(GetDataV340).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeUnbond, data), big.NewInt(0), 5, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	funds := cState.FrozenFunds.GetFrozenFunds(105)
+	if funds == nil || len(funds.List) != 1 || funds.List[0].Value.Cmp(value) != 0 {
+		t.Fatalf("Stake is not frozen until the voted unbond period")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestFullUnbondTxWithWaitlist(t *testing.T) {
 	t.Parallel()
 	cState := getState()
@@ -0,0 +1,138 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func encodeTestTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, txType TxType, data interface{}) []byte {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+// getStateWithLockPrice returns the state with the price of Lock, which is used by transactions without own prices
+func getStateWithLockPrice() *state.State {
+	s := getState()
+
+	commissionPrice := commissionPrice
+	commissionPrice.Lock = helpers.StringToBigInt("100000000000000000")
+	s.Commission.SetNewCommissions(commissionPrice.Encode())
+	return s
+}
+
+func TestVestingTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateWithLockPrice()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	beneficiaryKey, _ := crypto.GenerateKey()
+	beneficiary := crypto.PubkeyToAddress(beneficiaryKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	cState.Accounts.AddBalance(beneficiary, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	create := CreateVestingData{
+		Beneficiary: beneficiary,
+		Coin:        coin,
+		Value:       value,
+		StartHeight: 10,
+		CliffHeight: 20,
+		EndHeight:   110,
+		Revocable:   true,
+		Revoker:     addr,
+	}
+
+	response := NewExecutor(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeCreateVesting, create), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	item := cState.Vesting.Get(beneficiary, 1)
+	if item == nil {
+		t.Fatal("Vesting not found")
+	}
+
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, beneficiaryKey, 1, TypeClaimVesting, ClaimVestingData{ID: 1}), big.NewInt(0), 15, &sync.Map{}, 0, false)
+	if response.Code != code.NothingToClaimOfVesting {
+		t.Fatalf("Response code is not %d. Error: %s", code.NothingToClaimOfVesting, response.Log)
+	}
+
+	balance := cState.Accounts.GetBalance(beneficiary, coin)
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, beneficiaryKey, 1, TypeClaimVesting, ClaimVestingData{ID: 1}), big.NewInt(0), 60, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	claimed := helpers.BipToPip(big.NewInt(50))
+	commission := big.NewInt(0).Sub(big.NewInt(0).Add(balance, claimed), cState.Accounts.GetBalance(beneficiary, coin))
+	if item.Claimed.Cmp(claimed) != 0 {
+		t.Fatalf("Claimed value is not correct. Expected %s, got %s", claimed, item.Claimed)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, beneficiaryKey, 2, TypeRevokeVesting, RevokeVestingData{Beneficiary: beneficiary, ID: 1}), big.NewInt(0), 85, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotRevokerOfVesting {
+		t.Fatalf("Response code is not %d. Error: %s", code.IsNotRevokerOfVesting, response.Log)
+	}
+
+	balance = cState.Accounts.GetBalance(addr, coin)
+	response = NewExecutor(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeRevokeVesting, RevokeVestingData{Beneficiary: beneficiary, ID: 1}), big.NewInt(0), 85, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if cState.Vesting.Get(beneficiary, 1) != nil {
+		t.Fatal("Vesting is not removed")
+	}
+
+	expectedBalance := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(1000+75)), commission)
+	if b := cState.Accounts.GetBalance(beneficiary, coin); b.Cmp(expectedBalance) != 0 {
+		t.Fatalf("Beneficiary balance is not correct. Expected %s, got %s", expectedBalance, b)
+	}
+
+	if b := cState.Accounts.GetBalance(addr, coin); b.Cmp(balance) <= 0 {
+		t.Fatalf("Locked value is not returned to revoker. Balance %s", b)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type VoteParamData struct {
+	PubKey types.Pubkey
+	Height uint64
+	Name   string
+	Value  uint64
+}
+
+func (data VoteParamData) Gas() int64 {
+	return gasVoteParam
+}
+func (data VoteParamData) TxType() TxType {
+	return TypeVoteParam
+}
+
+func (data VoteParamData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data VoteParamData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if !params.IsKnown(data.Name) {
+		return &Response{
+			Code: code.WrongParamName,
+			Log:  fmt.Sprintf("unknown parameter %q", data.Name),
+			Info: EncodeError(code.NewWrongParamName(data.Name)),
+		}
+	}
+
+	min, max := params.Bounds(data.Name)
+	if data.Value < min || data.Value > max {
+		return &Response{
+			Code: code.WrongParamValue,
+			Log:  fmt.Sprintf("value of %s must be from %d to %d", data.Name, min, max),
+			Info: EncodeError(code.NewWrongParamValue(data.Name, strconv.FormatUint(data.Value, 10), strconv.FormatUint(min, 10), strconv.FormatUint(max, 10))),
+		}
+	}
+
+	if data.Height < block {
+		return &Response{
+			Code: code.VoteExpired,
+			Log:  "vote is produced for the past state",
+			Info: EncodeError(code.NewVoteExpired(strconv.Itoa(int(block)), strconv.Itoa(int(data.Height)))),
+		}
+	}
+
+	if data.Name == params.UpdateStakesPeriod {
+		// the period can be changed only at the height of the payment of rewards for both the current and the new one
+		if period := context.Params().Get(params.UpdateStakesPeriod); data.Height%period != 0 || data.Height%data.Value != 0 {
+			return &Response{
+				Code: code.WrongParamValue,
+				Log:  fmt.Sprintf("height of the vote for %s must be a multiple of %d and %d", data.Name, period, data.Value),
+				Info: EncodeError(code.NewWrongParamValue(data.Name, strconv.FormatUint(data.Value, 10), strconv.FormatUint(min, 10), strconv.FormatUint(max, 10))),
+			}
+		}
+	}
+
+	if context.Params().IsVoteExists(data.Height, data.PubKey, data.Name) {
+		return &Response{
+			Code: code.VoteAlreadyExists,
+			Log:  "Parameter vote with such public key and height already exists",
+			Info: EncodeError(code.NewVoteAlreadyExists(strconv.FormatUint(data.Height, 10), data.GetPubKey().String())),
+		}
+	}
+	return checkCandidateOwnership(data, tx, context)
+}
+
+func (data VoteParamData) String() string {
+	return fmt.Sprintf("VOTE PARAM %s=%d on height: %d", data.Name, data.Value, data.Height)
+}
+
+func (data VoteParamData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteParamPrice()
+}
+
+func (data VoteParamData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		deliverState.Params.AddVote(data.Height, data.PubKey, data.Name, data.Value)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(data.PubKey[:])), Index: true},
+			{Key: []byte("tx.param"), Value: []byte(data.Name), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestVoteParamTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+	privateKey, addr := getAccount()
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+	cState.Validators.Create(pubkey, helpers.BipToPip(big.NewInt(1)))
+
+	data := VoteParamData{
+		PubKey: pubkey,
+		Height: 100500,
+		Name:   "unknown",
+		Value:  100,
+	}
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeVoteParam, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongParamName {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongParamName, response.Log)
+	}
+
+	data.Name = params.JailPeriod
+	data.Value = 0
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeVoteParam, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongParamValue {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongParamValue, response.Log)
+	}
+
+	data.Value = 100
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeVoteParam, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeVoteParam, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.VoteAlreadyExists, response.Log)
+	}
+
+	votes := cState.Params.GetVotes(data.Height)
+	if len(votes) != 1 || votes[0].Name != params.JailPeriod || votes[0].Value != 100 || len(votes[0].Votes) != 1 || votes[0].Votes[0] != pubkey {
+		t.Fatalf("Vote is not saved: %v", votes)
+	}
+
+	data.Name = params.UpdateStakesPeriod
+	data.Value = 1000
+	data.Height = 720 * 1000 * 3
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeVoteParam, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	data.Height += 720
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 3, TypeVoteParam, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongParamValue {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongParamValue, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
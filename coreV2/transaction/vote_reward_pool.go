@@ -0,0 +1,158 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+type VoteRewardPoolData struct {
+	PubKey     types.Pubkey
+	Height     uint64
+	Name       string
+	Address    types.Address
+	Commission uint32
+}
+
+func (data VoteRewardPoolData) Gas() int64 {
+	return gasVoteRewardPool
+}
+func (data VoteRewardPoolData) TxType() TxType {
+	return TypeVoteRewardPool
+}
+
+func (data VoteRewardPoolData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data VoteRewardPoolData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if !params.IsValidPoolName(data.Name) {
+		return &Response{
+			Code: code.WrongParamName,
+			Log:  fmt.Sprintf("wrong reward pool name %q", data.Name),
+			Info: EncodeError(code.NewWrongParamName(data.Name)),
+		}
+	}
+
+	pool := types.RewardPool{Name: data.Name, Address: data.Address, Commission: data.Commission}
+	if _, err := params.WithRewardPool(context.Params().RewardPools(), pool); err != nil {
+		return &Response{
+			Code: code.WrongRewardPool,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewWrongRewardPool(data.Name, strconv.FormatUint(uint64(data.Commission), 10))),
+		}
+	}
+
+	if data.Height < block {
+		return &Response{
+			Code: code.VoteExpired,
+			Log:  "vote is produced for the past state",
+			Info: EncodeError(code.NewVoteExpired(strconv.Itoa(int(block)), strconv.Itoa(int(data.Height)))),
+		}
+	}
+
+	if context.Params().IsVoteExists(data.Height, data.PubKey, params.PoolVoteName(data.Name)) {
+		return &Response{
+			Code: code.VoteAlreadyExists,
+			Log:  "Reward pool vote with such public key and height already exists",
+			Info: EncodeError(code.NewVoteAlreadyExists(strconv.FormatUint(data.Height, 10), data.GetPubKey().String())),
+		}
+	}
+	return checkCandidateOwnership(data, tx, context)
+}
+
+func (data VoteRewardPoolData) String() string {
+	return fmt.Sprintf("VOTE REWARD POOL %s=%d%% to %s on height: %d", data.Name, data.Commission, data.Address.String(), data.Height)
+}
+
+func (data VoteRewardPoolData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteRewardPoolPrice()
+}
+
+func (data VoteRewardPoolData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		deliverState.Params.AddRewardPoolVote(data.Height, data.PubKey, data.Name, data.Address, data.Commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(data.PubKey[:])), Index: true},
+			{Key: []byte("tx.reward_pool"), Value: []byte(data.Name), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestVoteRewardPoolTx(t *testing.T) {
+	t.Parallel()
+	cState := getStateV3()
+	privateKey, addr := getAccount()
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+	cState.Validators.Create(pubkey, helpers.BipToPip(big.NewInt(1)))
+
+	data := VoteRewardPoolData{
+		PubKey:     pubkey,
+		Height:     100500,
+		Name:       "Grants",
+		Address:    types.Address{1},
+		Commission: 5,
+	}
+	response := NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeVoteRewardPool, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongParamName {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongParamName, response.Log)
+	}
+
+	data.Name = "grants"
+	data.Commission = 31
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeVoteRewardPool, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongRewardPool {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.WrongRewardPool, response.Log)
+	}
+
+	data.Commission = 5
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 1, TypeVoteRewardPool, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != 0 {
+		t.Fatalf("Response code %d is not 0. Error: %s", response.Code, response.Log)
+	}
+
+	response = NewExecutorV3(GetData).RunTx(cState, encodeTestTx(t, privateKey, 2, TypeVoteRewardPool, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code %d is not %d. Error: %s", response.Code, code.VoteAlreadyExists, response.Log)
+	}
+
+	votes := cState.Params.GetVotes(data.Height)
+	if len(votes) != 1 || votes[0].Name != params.PoolVoteName("grants") || votes[0].Value != 5 || votes[0].Address != data.Address {
+		t.Fatalf("Vote is not saved: %v", votes)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -14,17 +14,34 @@ type AppState struct {
 	Candidates          []Candidate        `json:"candidates,omitempty"`
 	BlockListCandidates []Pubkey           `json:"block_list_candidates,omitempty"`
 	DeletedCandidates   []DeletedCandidate `json:"deleted_candidates,omitempty"`
+	CommissionChanges   []CommissionChange `json:"commission_changes,omitempty"`
 	Waitlist            []Waitlist         `json:"waitlist,omitempty"`
+	AutoRoutes          []AutoRoute        `json:"auto_routes,omitempty"`
 	Pools               []Pool             `json:"pools,omitempty"`
 	NextOrderID         uint64             `json:"next_order_id"`
+	TriggerOrders       []TriggerOrder     `json:"trigger_orders,omitempty"`
 	Accounts            []Account          `json:"accounts,omitempty"`
 	Coins               []Coin             `json:"coins,omitempty"`
 	FrozenFunds         []FrozenFund       `json:"frozen_funds,omitempty"`
+	Vestings            []Vesting          `json:"vestings,omitempty"`
+	NextVestingID       uint64             `json:"next_vesting_id,omitempty"`
+	Proposals           []Proposal         `json:"proposals,omitempty"`
+	NextProposalID      uint64             `json:"next_proposal_id,omitempty"`
+	HTLCs               []HTLC             `json:"htlcs,omitempty"`
+	NextHTLCID          uint64             `json:"next_htlc_id,omitempty"`
 	HaltBlocks          []HaltBlock        `json:"halt_blocks,omitempty"`
 	Commission          Commission         `json:"commission,omitempty"`
 	CommissionVotes     []CommissionVote   `json:"commission_votes,omitempty"`
 	UpdateVotes         []UpdateVote       `json:"update_votes,omitempty"`
+	Params              []Param            `json:"params,omitempty"`
+	ParamVotes          []ParamVote        `json:"param_votes,omitempty"`
+	RewardPools         []RewardPool       `json:"reward_pools,omitempty"`
 	UsedChecks          []UsedCheck        `json:"used_checks,omitempty"`
+	CancelledChecks     []UsedCheck        `json:"cancelled_checks,omitempty"`
+	CheckEscrows        []CheckEscrow      `json:"check_escrows,omitempty"`
+	ReusableChecks      []ReusableCheck    `json:"reusable_checks,omitempty"`
+	Slashes             []Slash            `json:"slashes,omitempty"`
+	SlashSummaries      []SlashSummary     `json:"slash_summaries,omitempty"`
 	MaxGas              uint64             `json:"max_gas"`
 	TotalSlashed        string             `json:"total_slashed"`
 
@@ -203,6 +220,30 @@ func (s *AppState) Verify() error {
 
 		}
 
+		for _, o := range s.TriggerOrders {
+			if o.CoinToSell == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(o.ValueToSell))
+			}
+		}
+
+		for _, v := range s.Vestings {
+			if v.Coin == coin.ID {
+				volume.Add(volume, big.NewInt(0).Sub(helpers.StringToBigInt(v.Value), helpers.StringToBigInt(v.Claimed)))
+			}
+		}
+
+		for _, h := range s.HTLCs {
+			if h.Coin == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(h.Value))
+			}
+		}
+
+		for _, e := range s.CheckEscrows {
+			if e.Coin == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(e.Value))
+			}
+		}
+
 		if coin.Crr == 0 {
 			if volume.Cmp(helpers.StringToBigInt(coin.Volume)) != 0 {
 				return fmt.Errorf("wrong token %s (%d) volume (%s)", coin.Symbol.String(), coin.ID, big.NewInt(0).Sub(volume, helpers.StringToBigInt(coin.Volume)))
@@ -263,6 +304,28 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	autoRoutes := map[Address]struct{}{}
+	for _, route := range s.AutoRoutes {
+		if _, exists := autoRoutes[route.Owner]; exists {
+			return fmt.Errorf("duplicated auto-route of address %s", route.Owner.String())
+		}
+		autoRoutes[route.Owner] = struct{}{}
+
+		if route.CandidateID == 0 {
+			continue
+		}
+		foundCandidate := false
+		for _, candidate := range s.Candidates {
+			if candidate.ID == route.CandidateID {
+				foundCandidate = true
+				break
+			}
+		}
+		if !foundCandidate {
+			return fmt.Errorf("candidate %d of auto-route of address %s not found", route.CandidateID, route.Owner.String())
+		}
+	}
+
 	for _, ff := range s.FrozenFunds {
 		if !helpers.IsValidBigInt(ff.Value) {
 			return fmt.Errorf("wrong frozen fund value: %s", ff.Value)
@@ -286,15 +349,225 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	vestings := map[uint64]struct{}{}
+	for _, v := range s.Vestings {
+		if !helpers.IsValidBigInt(v.Value) || !helpers.IsValidBigInt(v.Claimed) {
+			return fmt.Errorf("wrong vesting %d value: %s, claimed: %s", v.ID, v.Value, v.Claimed)
+		}
+		if helpers.StringToBigInt(v.Claimed).Cmp(helpers.StringToBigInt(v.Value)) >= 0 {
+			return fmt.Errorf("vesting %d is fully claimed", v.ID)
+		}
+		if v.StartHeight > v.CliffHeight || v.CliffHeight > v.EndHeight || v.StartHeight == v.EndHeight {
+			return fmt.Errorf("wrong vesting %d schedule", v.ID)
+		}
+		if _, exists := vestings[v.ID]; exists || v.ID == 0 || v.ID >= s.NextVestingID {
+			return fmt.Errorf("wrong vesting id %d", v.ID)
+		}
+		vestings[v.ID] = struct{}{}
+
+		coinID := CoinID(v.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	proposals := map[uint64]struct{}{}
+	for _, p := range s.Proposals {
+		if _, exists := proposals[p.ID]; exists || p.ID == 0 || p.ID >= s.NextProposalID {
+			return fmt.Errorf("wrong proposal id %d", p.ID)
+		}
+		proposals[p.ID] = struct{}{}
+
+		if len(p.TxData) == 0 || len(p.Approvals) == 0 {
+			return fmt.Errorf("wrong proposal %d", p.ID)
+		}
+
+		foundMultisig := false
+		for _, account := range s.Accounts {
+			if account.Address == p.Multisig {
+				foundMultisig = account.MultisigData != nil
+				break
+			}
+		}
+		if !foundMultisig {
+			return fmt.Errorf("multisig %s of proposal %d not found", p.Multisig.String(), p.ID)
+		}
+	}
+
+	for _, pool := range s.Pools {
+		if pool.PriceCumulativeHeight == 0 {
+			continue
+		}
+		if !helpers.IsValidBigInt(pool.Price0Cumulative) || !helpers.IsValidBigInt(pool.Price1Cumulative) {
+			return fmt.Errorf("wrong cumulative prices of pool %d", pool.ID)
+		}
+	}
+
+	triggerOrders := map[uint64]struct{}{}
+	for _, o := range s.TriggerOrders {
+		if _, exists := triggerOrders[o.ID]; exists || o.ID == 0 || o.ID >= s.NextOrderID {
+			return fmt.Errorf("wrong trigger order id %d", o.ID)
+		}
+		triggerOrders[o.ID] = struct{}{}
+
+		for _, value := range []string{o.ValueToSell, o.MinimumValueToBuy, o.TriggerValueToBuy} {
+			if !helpers.IsValidBigInt(value) || helpers.StringToBigInt(value).Sign() != 1 {
+				return fmt.Errorf("wrong trigger order %d value: %s", o.ID, value)
+			}
+		}
+
+		foundPool := false
+		for _, pool := range s.Pools {
+			if (pool.Coin0 == o.CoinToSell && pool.Coin1 == o.CoinToBuy) || (pool.Coin0 == o.CoinToBuy && pool.Coin1 == o.CoinToSell) {
+				foundPool = true
+				break
+			}
+		}
+		if !foundPool {
+			return fmt.Errorf("pool %d-%d of trigger order %d not found", o.CoinToSell, o.CoinToBuy, o.ID)
+		}
+	}
+
+	htlcs := map[uint64]struct{}{}
+	for _, h := range s.HTLCs {
+		if !helpers.IsValidBigInt(h.Value) || helpers.StringToBigInt(h.Value).Sign() != 1 {
+			return fmt.Errorf("wrong htlc %d value: %s", h.ID, h.Value)
+		}
+		if _, exists := htlcs[h.ID]; exists || h.ID == 0 || h.ID >= s.NextHTLCID {
+			return fmt.Errorf("wrong htlc id %d", h.ID)
+		}
+		htlcs[h.ID] = struct{}{}
+
+		coinID := CoinID(h.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
 	// check used checks length
-	for _, check := range s.UsedChecks {
-		b, err := hex.DecodeString(string(check))
-		if err != nil {
-			return err
+	for _, checks := range [][]UsedCheck{s.UsedChecks, s.CancelledChecks} {
+		for _, check := range checks {
+			b, err := hex.DecodeString(string(check))
+			if err != nil {
+				return err
+			}
+
+			if len(b) != 32 {
+				return fmt.Errorf("wrong used check size %s", check)
+			}
+		}
+	}
+
+	escrows := map[Hash]struct{}{}
+	for _, escrow := range s.CheckEscrows {
+		if _, exists := escrows[escrow.Hash]; exists {
+			return fmt.Errorf("duplicated escrow of check %s", escrow.Hash.String())
+		}
+		escrows[escrow.Hash] = struct{}{}
+
+		if !helpers.IsValidBigInt(escrow.Value) {
+			return fmt.Errorf("wrong value of escrow of check %s", escrow.Hash.String())
+		}
+	}
+
+	commissionChanges := map[uint64]struct{}{}
+	for _, change := range s.CommissionChanges {
+		if _, exists := commissionChanges[change.CandidateID]; exists {
+			return fmt.Errorf("duplicated commission change of candidate %d", change.CandidateID)
+		}
+		commissionChanges[change.CandidateID] = struct{}{}
+
+		foundCandidate := false
+		for _, candidate := range s.Candidates {
+			if candidate.ID == change.CandidateID {
+				foundCandidate = true
+				break
+			}
 		}
+		if !foundCandidate {
+			return fmt.Errorf("candidate %d of commission change not found", change.CandidateID)
+		}
+
+		if change.Commission > 100 {
+			return fmt.Errorf("wrong commission change of candidate %d: %d", change.CandidateID, change.Commission)
+		}
+	}
+
+	for _, slash := range s.Slashes {
+		if !helpers.IsValidBigInt(slash.Amount) {
+			return fmt.Errorf("wrong slash amount of %s: %s", slash.Address.String(), slash.Amount)
+		}
+	}
+
+	summaries := map[uint64]struct{}{}
+	for _, summary := range s.SlashSummaries {
+		if _, exists := summaries[summary.CandidateID]; exists {
+			return fmt.Errorf("duplicated slash summary of candidate %d", summary.CandidateID)
+		}
+		summaries[summary.CandidateID] = struct{}{}
+
+		for _, slashed := range summary.Slashed {
+			if !helpers.IsValidBigInt(slashed.Amount) {
+				return fmt.Errorf("wrong slashed amount of candidate %d: %s", summary.CandidateID, slashed.Amount)
+			}
+		}
+	}
 
-		if len(b) != 32 {
-			return fmt.Errorf("wrong used check size %s", check)
+	reusableChecks := map[Hash]struct{}{}
+	for _, check := range s.ReusableChecks {
+		if _, exists := reusableChecks[check.Hash]; exists {
+			return fmt.Errorf("duplicated reusable check %s", check.Hash.String())
+		}
+		reusableChecks[check.Hash] = struct{}{}
+
+		if !helpers.IsValidBigInt(check.Value) || !helpers.IsValidBigInt(check.Redeemed) {
+			return fmt.Errorf("wrong value of reusable check %s", check.Hash.String())
+		}
+
+		redeemed := big.NewInt(0)
+		for _, redeemer := range check.Redeemers {
+			if !helpers.IsValidBigInt(redeemer.Value) {
+				return fmt.Errorf("wrong redeemed value of reusable check %s", check.Hash.String())
+			}
+			redeemed.Add(redeemed, helpers.StringToBigInt(redeemer.Value))
+		}
+
+		if redeemed.Cmp(helpers.StringToBigInt(check.Redeemed)) != 0 || redeemed.Cmp(helpers.StringToBigInt(check.Value)) == 1 {
+			return fmt.Errorf("wrong redeemed value of reusable check %s", check.Hash.String())
+		}
+	}
+
+	rewardPools := map[string]struct{}{}
+	var rewardPoolsCommission uint32
+	for _, pool := range s.RewardPools {
+		if _, exists := rewardPools[pool.Name]; exists {
+			return fmt.Errorf("duplicated reward pool %s", pool.Name)
+		}
+		rewardPools[pool.Name] = struct{}{}
+
+		rewardPoolsCommission += pool.Commission
+		if pool.Commission == 0 || rewardPoolsCommission > 100 {
+			return fmt.Errorf("wrong commission of reward pool %s", pool.Name)
 		}
 	}
 
@@ -309,18 +582,32 @@ type Validator struct {
 }
 
 type Candidate struct {
-	ID                       uint64  `json:"id"`
-	RewardAddress            Address `json:"reward_address"`
-	OwnerAddress             Address `json:"owner_address"`
-	ControlAddress           Address `json:"control_address"`
-	TotalBipStake            string  `json:"total_bip_stake"`
-	PubKey                   Pubkey  `json:"public_key"`
-	Commission               uint64  `json:"commission"`
-	Stakes                   []Stake `json:"stakes,omitempty"`
-	Updates                  []Stake `json:"updates,omitempty"`
-	Status                   uint64  `json:"status"`
-	JailedUntil              uint64  `json:"jailed_until,omitempty"`
-	LastEditCommissionHeight uint64  `json:"last_edit_commission_height,omitempty"`
+	ID                       uint64       `json:"id"`
+	RewardAddress            Address      `json:"reward_address"`
+	OwnerAddress             Address      `json:"owner_address"`
+	ControlAddress           Address      `json:"control_address"`
+	TotalBipStake            string       `json:"total_bip_stake"`
+	PubKey                   Pubkey       `json:"public_key"`
+	Commission               uint64       `json:"commission"`
+	Stakes                   []Stake      `json:"stakes,omitempty"`
+	Updates                  []Stake      `json:"updates,omitempty"`
+	Status                   uint64       `json:"status"`
+	JailedUntil              uint64       `json:"jailed_until,omitempty"`
+	LastEditCommissionHeight uint64       `json:"last_edit_commission_height,omitempty"`
+	NoAutoCompound           []Address    `json:"no_auto_compound,omitempty"`
+	Infractions              []Infraction `json:"infractions,omitempty"`
+}
+
+type CommissionChange struct {
+	CandidateID     uint64 `json:"candidate_id"`
+	Commission      uint64 `json:"commission"`
+	AnnouncedHeight uint64 `json:"announced_height"`
+	Height          uint64 `json:"height"`
+}
+
+type Infraction struct {
+	Height uint64 `json:"height"`
+	Level  uint64 `json:"level"`
 }
 
 type Stake struct {
@@ -336,13 +623,29 @@ type Waitlist struct {
 	Coin        uint64  `json:"coin"`
 	Value       string  `json:"value"`
 }
+type AutoRoute struct {
+	Owner       Address `json:"owner"`
+	CandidateID uint64  `json:"candidate_id"`
+}
 type Order struct {
-	IsSale  bool    `json:"is_sale"` // true
-	Volume0 string  `json:"volume0"` // buy
-	Volume1 string  `json:"volume1"` // sell
-	ID      uint64  `json:"id"`
-	Owner   Address `json:"owner"`
-	Height  uint64  `json:"height"`
+	IsSale       bool    `json:"is_sale"` // true
+	Volume0      string  `json:"volume0"` // buy
+	Volume1      string  `json:"volume1"` // sell
+	ID           uint64  `json:"id"`
+	Owner        Address `json:"owner"`
+	Height       uint64  `json:"height"`
+	ExpireHeight uint64  `json:"expire_height,omitempty"` // 0 - expired by the global period
+}
+type TriggerOrder struct {
+	ID                uint64  `json:"id"`
+	Owner             Address `json:"owner"`
+	CoinToSell        uint64  `json:"coin_to_sell"`
+	ValueToSell       string  `json:"value_to_sell"`
+	CoinToBuy         uint64  `json:"coin_to_buy"`
+	MinimumValueToBuy string  `json:"minimum_value_to_buy"`
+	TriggerValueToBuy string  `json:"trigger_value_to_buy"`
+	StopLoss          bool    `json:"stop_loss,omitempty"`
+	Height            uint64  `json:"height"`
 }
 type Pool struct {
 	Coin0    uint64  `json:"coin0,omitempty"`
@@ -351,6 +654,10 @@ type Pool struct {
 	Reserve1 string  `json:"reserve1"`
 	ID       uint64  `json:"id"`
 	Orders   []Order `json:"orders,omitempty"`
+
+	Price0Cumulative      string `json:"price0_cumulative,omitempty"`
+	Price1Cumulative      string `json:"price1_cumulative,omitempty"`
+	PriceCumulativeHeight uint64 `json:"price_cumulative_height,omitempty"`
 }
 
 type Coin struct {
@@ -382,8 +689,86 @@ type FrozenFund struct {
 	MoveToCandidateID uint64  `json:"move_to_candidate_id,omitempty"`
 }
 
+type Vesting struct {
+	ID          uint64   `json:"id"`
+	Beneficiary Address  `json:"beneficiary"`
+	Coin        uint64   `json:"coin"`
+	Value       string   `json:"value"`
+	Claimed     string   `json:"claimed"`
+	StartHeight uint64   `json:"start_height"`
+	CliffHeight uint64   `json:"cliff_height"`
+	EndHeight   uint64   `json:"end_height"`
+	Revoker     *Address `json:"revoker,omitempty"`
+}
+
+type Proposal struct {
+	ID           uint64    `json:"id"`
+	Multisig     Address   `json:"multisig"`
+	Proposer     Address   `json:"proposer"`
+	GasCoin      uint64    `json:"gas_coin"`
+	TxType       uint64    `json:"tx_type"`
+	TxData       []byte    `json:"tx_data"`
+	ExpireHeight uint64    `json:"expire_height"`
+	Approvals    []Address `json:"approvals"`
+	Rejections   []Address `json:"rejections,omitempty"`
+}
+
+type HTLC struct {
+	ID        uint64  `json:"id"`
+	Sender    Address `json:"sender"`
+	Recipient Address `json:"recipient"`
+	Coin      uint64  `json:"coin"`
+	Value     string  `json:"value"`
+	Hashlock  Hash    `json:"hashlock"`
+	Timeout   uint64  `json:"timeout"`
+}
+
+type Slash struct {
+	Height      uint64  `json:"height"`
+	Infraction  uint64  `json:"infraction"`
+	CandidateID uint64  `json:"candidate_id"`
+	Address     Address `json:"address"`
+	Coin        uint64  `json:"coin"`
+	Amount      string  `json:"amount"`
+}
+
+type SlashSummary struct {
+	CandidateID    uint64         `json:"candidate_id"`
+	DoubleSigns    uint64         `json:"double_signs"`
+	Downtimes      uint64         `json:"downtimes"`
+	LastHeight     uint64         `json:"last_height"`
+	LastInfraction uint64         `json:"last_infraction"`
+	Slashed        []SlashedValue `json:"slashed,omitempty"`
+}
+
+type SlashedValue struct {
+	Coin   uint64 `json:"coin"`
+	Amount string `json:"amount"`
+}
+
 type UsedCheck string
 
+type CheckEscrow struct {
+	Hash     Hash    `json:"hash"`
+	Issuer   Address `json:"issuer"`
+	Coin     uint64  `json:"coin"`
+	Value    string  `json:"value"`
+	DueBlock uint64  `json:"due_block"`
+}
+
+type ReusableCheck struct {
+	Hash      Hash                    `json:"hash"`
+	Value     string                  `json:"value"`
+	Redeemed  string                  `json:"redeemed"`
+	Revoked   bool                    `json:"revoked,omitempty"`
+	Redeemers []ReusableCheckRedeemer `json:"redeemers,omitempty"`
+}
+
+type ReusableCheckRedeemer struct {
+	Address Address `json:"address"`
+	Value   string  `json:"value"`
+}
+
 type Account struct {
 	Address             Address   `json:"address"`
 	Balance             []Balance `json:"balance,omitempty"`
@@ -419,6 +804,25 @@ type UpdateVote struct {
 	Version string   `json:"version"`
 }
 
+type Param struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+}
+
+type ParamVote struct {
+	Height  uint64   `json:"height"`
+	Votes   []Pubkey `json:"votes"`
+	Name    string   `json:"name"`
+	Value   uint64   `json:"value"`
+	Address *Address `json:"address,omitempty"`
+}
+
+type RewardPool struct {
+	Name       string  `json:"name"`
+	Address    Address `json:"address"`
+	Commission uint32  `json:"commission"`
+}
+
 type Commission struct {
 	Coin                    uint64 `json:"coin"`
 	PayloadByte             string `json:"payload_byte"`
@@ -26,6 +26,10 @@ func GetExpireOrdersPeriod() uint64 {
 	return GetExpireOrdersPeriodWithChain(CurrentChainID)
 }
 
+func GetUpdateStakesPeriod() uint64 {
+	return GetUpdateStakesPeriodWithChain(CurrentChainID)
+}
+
 const yearX3 = 18921600    // 3y = 94608000sec/5
 const mounth = week * 4    // 1m
 const week = day * 7       // 1w
@@ -33,6 +37,9 @@ const weekCorrect = 134400 // 1w
 const day = 8640 * 2       // 1d
 const m15 = 518400 / 2920  // 15m
 
+// BlocksPerDay is the number of blocks of a day by the target block time
+const BlocksPerDay = day
+
 func GetExpireOrdersPeriodWithChain(chain ChainID) uint64 {
 	if chain == ChainTestnet {
 		return day * 5
@@ -40,6 +47,12 @@ func GetExpireOrdersPeriodWithChain(chain ChainID) uint64 {
 	return mounth
 }
 
+const updateStakesPeriod = 720
+
+func GetUpdateStakesPeriodWithChain(chain ChainID) uint64 {
+	return updateStakesPeriod
+}
+
 func GetIncreasedRewardsPeriodWithChain(chain ChainID) uint64 {
 	if chain == ChainTestnet {
 		return day * 2
@@ -72,6 +85,17 @@ func GetJailPeriodWithChain(chain ChainID) uint64 {
 	return jailPeriod
 }
 
+func GetDowntimeEpoch() uint64 {
+	return GetDowntimeEpochWithChain(CurrentChainID)
+}
+
+func GetDowntimeEpochWithChain(chain ChainID) uint64 {
+	if chain == ChainTestnet {
+		return m15 * 8
+	}
+	return week
+}
+
 // CurrentChainID is current ChainID of the network
 var CurrentChainID = ChainMainnet
 